@@ -44,6 +44,8 @@ func Provider() *schema.Provider {
 			"freeipa_hbac_policy_user_membership":     resourceFreeIPAHBACPolicyUserMembership(),
 			"freeipa_host_hostgroup_membership":       resourceFreeIPAHostHostGroupMembership(),
 			"freeipa_hostgroup":                       resourceFreeIPAHostGroup(),
+			"freeipa_selinux_usermap":                 resourceFreeIPASelinuxUsermap(),
+			"freeipa_stage_user":                      resourceFreeIPAStageUser(),
 			"freeipa_sudo_cmd":                        resourceFreeIPASudocmd(),
 			"freeipa_sudo_cmdgroup":                   resourceFreeIPASudocmdgroup(),
 			"freeipa_sudo_cmdgroup_membership":        resourceFreeIPASudocmdgroupMembership(),
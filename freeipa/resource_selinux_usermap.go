@@ -0,0 +1,396 @@
+package freeipa
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	ipa "github.com/camptocamp/go-freeipa/freeipa"
+	"github.com/camptocamp/terraform-provider-freeipa/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceFreeIPASelinuxUsermap() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceFreeIPASelinuxUsermapCreate,
+		ReadContext:   resourceFreeIPASelinuxUsermapRead,
+		UpdateContext: resourceFreeIPASelinuxUsermapUpdate,
+		DeleteContext: resourceFreeIPASelinuxUsermapDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "SELinux user map name",
+			},
+			"selinuxuser": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "SELinux user the users are mapped to (e.g. `staff_u:s0-s0:c0.c1023`)",
+			},
+			"hbac_rule": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"usercategory", "hostcategory", "users", "groups", "hosts", "hostgroups"},
+				Description:   "HBAC rule whose users and hosts the map applies to, instead of its own",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "SELinux user map description",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Enable this SELinux user map (Defaults to `true`)",
+			},
+			"usercategory": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"users", "groups"},
+				Description:   "User category the map is applied to (allowed value: `all`)",
+			},
+			"hostcategory": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"hosts", "hostgroups"},
+				Description:   "Host category the map is applied to (allowed value: `all`)",
+			},
+			"users": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Users the map is applied to",
+			},
+			"groups": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "User groups the map is applied to",
+			},
+			"hosts": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Hosts the map is applied to",
+			},
+			"hostgroups": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Host groups the map is applied to",
+			},
+		},
+	}
+}
+
+func resourceFreeIPASelinuxUsermapCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Creating freeipa SELinux user map")
+
+	client, err := meta.(*Config).Client()
+	if err != nil {
+		return diag.Errorf("Error creating freeipa identity client: %s", err)
+	}
+
+	optArgs := ipa.SelinuxusermapAddOptionalArgs{}
+
+	args := ipa.SelinuxusermapAddArgs{
+		Cn:             d.Get("name").(string),
+		Ipaselinuxuser: d.Get("selinuxuser").(string),
+	}
+	if _v, ok := d.GetOk("hbac_rule"); ok {
+		v := _v.(string)
+		optArgs.Seealso = &v
+	}
+	if _v, ok := d.GetOk("description"); ok {
+		v := _v.(string)
+		optArgs.Description = &v
+	}
+	if _v, ok := d.GetOkExists("enabled"); ok {
+		v := _v.(bool)
+		optArgs.Ipaenabledflag = &v
+	}
+	if _v, ok := d.GetOk("usercategory"); ok {
+		v := _v.(string)
+		optArgs.Usercategory = &v
+	}
+	if _v, ok := d.GetOk("hostcategory"); ok {
+		v := _v.(string)
+		optArgs.Hostcategory = &v
+	}
+
+	_, err = client.SelinuxusermapAdd(&args, &optArgs)
+	if err != nil {
+		return diag.Errorf("Error creating freeipa SELinux user map: %s", err)
+	}
+
+	d.SetId(d.Get("name").(string))
+
+	if err := resourceFreeIPASelinuxUsermapUpdateMembers(client, d); err != nil {
+		return diag.Errorf("Error creating freeipa SELinux user map members: %s", err)
+	}
+
+	return resourceFreeIPASelinuxUsermapRead(ctx, d, meta)
+}
+
+func resourceFreeIPASelinuxUsermapRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Read freeipa SELinux user map")
+
+	client, err := meta.(*Config).Client()
+	if err != nil {
+		return diag.Errorf("Error creating freeipa identity client: %s", err)
+	}
+
+	all := true
+	optArgs := ipa.SelinuxusermapShowOptionalArgs{
+		All: &all,
+	}
+
+	args := ipa.SelinuxusermapShowArgs{
+		Cn: d.Id(),
+	}
+
+	res, err := client.SelinuxusermapShow(&args, &optArgs)
+	if err != nil {
+		if strings.Contains(err.Error(), "NotFound") {
+			d.SetId("")
+			log.Printf("[DEBUG] SELinux user map not found")
+			return nil
+		} else {
+			return diag.Errorf("Error reading freeipa SELinux user map: %s", err)
+		}
+	}
+
+	// The members are managed authoritatively, so they are read back to
+	// detect changes made outside of Terraform.
+	d.Set("name", res.Result.Cn)
+	d.Set("selinuxuser", res.Result.Ipaselinuxuser)
+	d.Set("enabled", res.Result.Ipaenabledflag == nil || *res.Result.Ipaenabledflag)
+
+	for key, value := range map[string]*string{
+		"hbac_rule":    res.Result.Seealso,
+		"description":  res.Result.Description,
+		"usercategory": res.Result.Usercategory,
+		"hostcategory": res.Result.Hostcategory,
+	} {
+		v := ""
+		if value != nil {
+			v = *value
+		}
+		d.Set(key, v)
+	}
+
+	for key, members := range map[string]*[]string{
+		"users":      res.Result.MemberuserUser,
+		"groups":     res.Result.MemberuserGroup,
+		"hosts":      res.Result.MemberhostHost,
+		"hostgroups": res.Result.MemberhostHostgroup,
+	} {
+		var v []string
+		if members != nil {
+			v = *members
+		}
+		d.Set(key, v)
+	}
+
+	log.Printf("[DEBUG] Read freeipa SELinux user map %s", res.Result.Cn)
+	return nil
+}
+
+func resourceFreeIPASelinuxUsermapUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Update freeipa SELinux user map")
+
+	client, err := meta.(*Config).Client()
+	if err != nil {
+		return diag.Errorf("Error creating freeipa identity client: %s", err)
+	}
+
+	args := ipa.SelinuxusermapModArgs{
+		Cn: d.Id(),
+	}
+	optArgs := ipa.SelinuxusermapModOptionalArgs{}
+
+	var hasChange = false
+
+	// Members are removed before the categories or the HBAC rule are set,
+	// which FreeIPA refuses while the map has members.
+	if err := resourceFreeIPASelinuxUsermapRemoveMembers(client, d); err != nil {
+		return diag.Errorf("Error update freeipa SELinux user map members: %s", err)
+	}
+
+	if d.HasChange("selinuxuser") {
+		v := d.Get("selinuxuser").(string)
+		optArgs.Ipaselinuxuser = &v
+		hasChange = true
+	}
+	if d.HasChange("hbac_rule") {
+		v := d.Get("hbac_rule").(string)
+		optArgs.Seealso = &v
+		hasChange = true
+	}
+	if d.HasChange("description") {
+		v := d.Get("description").(string)
+		optArgs.Description = &v
+		hasChange = true
+	}
+	if d.HasChange("enabled") {
+		v := d.Get("enabled").(bool)
+		optArgs.Ipaenabledflag = &v
+		hasChange = true
+	}
+	if d.HasChange("usercategory") {
+		v := d.Get("usercategory").(string)
+		optArgs.Usercategory = &v
+		hasChange = true
+	}
+	if d.HasChange("hostcategory") {
+		v := d.Get("hostcategory").(string)
+		optArgs.Hostcategory = &v
+		hasChange = true
+	}
+
+	if hasChange {
+		_, err = client.SelinuxusermapMod(&args, &optArgs)
+		if err != nil {
+			if strings.Contains(err.Error(), "EmptyModlist") {
+				log.Printf("[DEBUG] EmptyModlist (4202): no modifications to be performed")
+			} else {
+				return diag.Errorf("Error update freeipa SELinux user map: %s", err)
+			}
+		}
+	}
+
+	if err := resourceFreeIPASelinuxUsermapUpdateMembers(client, d); err != nil {
+		return diag.Errorf("Error update freeipa SELinux user map members: %s", err)
+	}
+
+	return resourceFreeIPASelinuxUsermapRead(ctx, d, meta)
+}
+
+func resourceFreeIPASelinuxUsermapDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Delete freeipa SELinux user map")
+
+	client, err := meta.(*Config).Client()
+	if err != nil {
+		return diag.Errorf("Error creating freeipa identity client: %s", err)
+	}
+
+	args := ipa.SelinuxusermapDelArgs{
+		Cn: []string{d.Id()},
+	}
+	_, err = client.SelinuxusermapDel(&args, &ipa.SelinuxusermapDelOptionalArgs{})
+	if err != nil {
+		return diag.Errorf("Error delete freeipa SELinux user map: %s", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// resourceFreeIPASelinuxUsermapMembersDiff returns the members of the given
+// attribute to add and to remove.
+func resourceFreeIPASelinuxUsermapMembersDiff(d *schema.ResourceData, key string) (toAdd, toRemove []string) {
+	o, n := d.GetChange(key)
+
+	return utils.SetDiff(utilsGetArry(o.(*schema.Set).List()), utilsGetArry(n.(*schema.Set).List()))
+}
+
+func resourceFreeIPASelinuxUsermapRemoveMembers(client *ipa.Client, d *schema.ResourceData) error {
+	_, users := resourceFreeIPASelinuxUsermapMembersDiff(d, "users")
+	_, groups := resourceFreeIPASelinuxUsermapMembersDiff(d, "groups")
+	_, hosts := resourceFreeIPASelinuxUsermapMembersDiff(d, "hosts")
+	_, hostgroups := resourceFreeIPASelinuxUsermapMembersDiff(d, "hostgroups")
+
+	if len(users) > 0 || len(groups) > 0 {
+		optArgs := ipa.SelinuxusermapRemoveUserOptionalArgs{}
+		if len(users) > 0 {
+			optArgs.User = &users
+		}
+		if len(groups) > 0 {
+			optArgs.Group = &groups
+		}
+
+		res, err := client.SelinuxusermapRemoveUser(&ipa.SelinuxusermapRemoveUserArgs{Cn: d.Id()}, &optArgs)
+		if err != nil {
+			return err
+		}
+		if failures := res.Failed.GetFailures(); len(failures) > 0 {
+			return errors.New(failures.String())
+		}
+	}
+
+	if len(hosts) > 0 || len(hostgroups) > 0 {
+		optArgs := ipa.SelinuxusermapRemoveHostOptionalArgs{}
+		if len(hosts) > 0 {
+			optArgs.Host = &hosts
+		}
+		if len(hostgroups) > 0 {
+			optArgs.Hostgroup = &hostgroups
+		}
+
+		res, err := client.SelinuxusermapRemoveHost(&ipa.SelinuxusermapRemoveHostArgs{Cn: d.Id()}, &optArgs)
+		if err != nil {
+			return err
+		}
+		if failures := res.Failed.GetFailures(); len(failures) > 0 {
+			return errors.New(failures.String())
+		}
+	}
+
+	return nil
+}
+
+// resourceFreeIPASelinuxUsermapUpdateMembers adds the configured members
+// missing from the map.
+func resourceFreeIPASelinuxUsermapUpdateMembers(client *ipa.Client, d *schema.ResourceData) error {
+	users, _ := resourceFreeIPASelinuxUsermapMembersDiff(d, "users")
+	groups, _ := resourceFreeIPASelinuxUsermapMembersDiff(d, "groups")
+	hosts, _ := resourceFreeIPASelinuxUsermapMembersDiff(d, "hosts")
+	hostgroups, _ := resourceFreeIPASelinuxUsermapMembersDiff(d, "hostgroups")
+
+	if len(users) > 0 || len(groups) > 0 {
+		optArgs := ipa.SelinuxusermapAddUserOptionalArgs{}
+		if len(users) > 0 {
+			optArgs.User = &users
+		}
+		if len(groups) > 0 {
+			optArgs.Group = &groups
+		}
+
+		res, err := client.SelinuxusermapAddUser(&ipa.SelinuxusermapAddUserArgs{Cn: d.Id()}, &optArgs)
+		if err != nil {
+			return err
+		}
+		if failures := res.Failed.GetFailures(); len(failures) > 0 {
+			return errors.New(failures.String())
+		}
+	}
+
+	if len(hosts) > 0 || len(hostgroups) > 0 {
+		optArgs := ipa.SelinuxusermapAddHostOptionalArgs{}
+		if len(hosts) > 0 {
+			optArgs.Host = &hosts
+		}
+		if len(hostgroups) > 0 {
+			optArgs.Hostgroup = &hostgroups
+		}
+
+		res, err := client.SelinuxusermapAddHost(&ipa.SelinuxusermapAddHostArgs{Cn: d.Id()}, &optArgs)
+		if err != nil {
+			return err
+		}
+		if failures := res.Failed.GetFailures(); len(failures) > 0 {
+			return errors.New(failures.String())
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package freeipa
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccFreeIPASelinuxUsermap(t *testing.T) {
+	testSelinuxUsermap := map[string]string{
+		"name":         "selinux_usermap_test",
+		"selinuxuser":  "staff_u:s0-s0:c0.c1023",
+		"description":  "Automatic test SELinux user map",
+		"usercategory": "all",
+		"hostgroup":    "selinux_usermap_test_hosts",
+		"hbac_rule":    "selinux_usermap_test_hbac",
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFreeIPASelinuxUsermapResource_basic(testSelinuxUsermap),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("freeipa_selinux_usermap.usermap", "name", testSelinuxUsermap["name"]),
+					resource.TestCheckResourceAttr("freeipa_selinux_usermap.usermap", "selinuxuser", testSelinuxUsermap["selinuxuser"]),
+					resource.TestCheckResourceAttr("freeipa_selinux_usermap.usermap", "enabled", "true"),
+				),
+			},
+			{
+				Config: testAccFreeIPASelinuxUsermapResource_full(testSelinuxUsermap),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("freeipa_selinux_usermap.usermap", "description", testSelinuxUsermap["description"]),
+					resource.TestCheckResourceAttr("freeipa_selinux_usermap.usermap", "usercategory", testSelinuxUsermap["usercategory"]),
+					resource.TestCheckResourceAttr("freeipa_selinux_usermap.usermap", "hostgroups.#", "1"),
+					resource.TestCheckTypeSetElemAttr("freeipa_selinux_usermap.usermap", "hostgroups.*", testSelinuxUsermap["hostgroup"]),
+				),
+			},
+			{
+				Config: testAccFreeIPASelinuxUsermapResource_hbac(testSelinuxUsermap),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("freeipa_selinux_usermap.usermap", "hbac_rule", testSelinuxUsermap["hbac_rule"]),
+					resource.TestCheckResourceAttr("freeipa_selinux_usermap.usermap", "usercategory", ""),
+					resource.TestCheckResourceAttr("freeipa_selinux_usermap.usermap", "hostgroups.#", "0"),
+				),
+			},
+			{
+				ResourceName:      "freeipa_selinux_usermap.usermap",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccFreeIPASelinuxUsermapResource_basic(dataset map[string]string) string {
+	return fmt.Sprintf(`
+	resource "freeipa_selinux_usermap" "usermap" {
+		name        = "%s"
+		selinuxuser = "%s"
+	}
+	`, dataset["name"], dataset["selinuxuser"])
+}
+
+func testAccFreeIPASelinuxUsermapResource_full(dataset map[string]string) string {
+	return fmt.Sprintf(`
+	resource "freeipa_hostgroup" "hostgroup" {
+		name = "%s"
+	}
+
+	resource "freeipa_selinux_usermap" "usermap" {
+		name         = "%s"
+		selinuxuser  = "%s"
+		description  = "%s"
+		usercategory = "%s"
+		hostgroups   = [freeipa_hostgroup.hostgroup.name]
+	}
+	`, dataset["hostgroup"], dataset["name"], dataset["selinuxuser"], dataset["description"], dataset["usercategory"])
+}
+
+func testAccFreeIPASelinuxUsermapResource_hbac(dataset map[string]string) string {
+	return fmt.Sprintf(`
+	resource "freeipa_hbac_policy" "hbac_policy" {
+		name = "%s"
+	}
+
+	resource "freeipa_selinux_usermap" "usermap" {
+		name        = "%s"
+		selinuxuser = "%s"
+		description = "%s"
+		hbac_rule   = freeipa_hbac_policy.hbac_policy.name
+	}
+	`, dataset["hbac_rule"], dataset["name"], dataset["selinuxuser"], dataset["description"])
+}
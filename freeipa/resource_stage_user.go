@@ -0,0 +1,493 @@
+package freeipa
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	ipa "github.com/camptocamp/go-freeipa/freeipa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceFreeIPAStageUser() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceFreeIPAStageUserCreate,
+		ReadContext:   resourceFreeIPAStageUserRead,
+		UpdateContext: resourceFreeIPAStageUserUpdate,
+		DeleteContext: resourceFreeIPAStageUserDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"first_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "First name",
+			},
+			"last_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Last name",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "UID",
+			},
+			"full_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Full name",
+			},
+			"display_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Display name",
+			},
+			"initials": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Initials",
+			},
+			"home_directory": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Home directory",
+			},
+			"login_shell": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Login shell",
+			},
+			"email_address": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Email address",
+			},
+			"telephone_numbers": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Telephone Number",
+			},
+			"mobile_numbers": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Mobile Telephone Number",
+			},
+			"uid_number": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "User ID Number (system will assign one on activation if not provided)",
+			},
+			"gid_number": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Group ID Number",
+			},
+			"organisation_unit": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Org. Unit",
+			},
+			"job_title": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Job Title",
+			},
+			"manager": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Manager",
+			},
+			"employee_number": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Employee Number",
+			},
+			"employee_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Employee Type",
+			},
+			"preferred_language": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Preferred Language",
+			},
+			"userclass": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "User category (semantics placed on this attribute are for local interpretation)",
+			},
+			"activated": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "The stage user has been activated, for instance by a `freeipa_user` with `from_stage_user` set. Activated stage users are neither updated nor deleted by the resource",
+			},
+		},
+	}
+}
+
+func resourceFreeIPAStageUserCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Creating freeipa stage user")
+
+	client, err := meta.(*Config).Client()
+	if err != nil {
+		return diag.Errorf("Error creating freeipa identity client: %s", err)
+	}
+
+	optArgs := ipa.StageuserAddOptionalArgs{}
+
+	args := ipa.StageuserAddArgs{
+		Givenname: d.Get("first_name").(string),
+		Sn:        d.Get("last_name").(string),
+	}
+
+	if _v, ok := d.GetOkExists("name"); ok {
+		v := _v.(string)
+		optArgs.UID = &v
+	}
+	if _v, ok := d.GetOkExists("full_name"); ok {
+		v := _v.(string)
+		optArgs.Cn = &v
+	}
+	if _v, ok := d.GetOkExists("display_name"); ok {
+		v := _v.(string)
+		optArgs.Displayname = &v
+	}
+	if _v, ok := d.GetOkExists("initials"); ok {
+		v := _v.(string)
+		optArgs.Initials = &v
+	}
+	if _v, ok := d.GetOkExists("home_directory"); ok {
+		v := _v.(string)
+		optArgs.Homedirectory = &v
+	}
+	if _v, ok := d.GetOkExists("login_shell"); ok {
+		v := _v.(string)
+		optArgs.Loginshell = &v
+	}
+	if _v, ok := d.GetOkExists("email_address"); ok {
+		v := utilsGetArry(_v.([]interface{}))
+		optArgs.Mail = &v
+	}
+	if _v, ok := d.GetOkExists("telephone_numbers"); ok {
+		v := utilsGetArry(_v.([]interface{}))
+		optArgs.Telephonenumber = &v
+	}
+	if _v, ok := d.GetOkExists("mobile_numbers"); ok {
+		v := utilsGetArry(_v.([]interface{}))
+		optArgs.Mobile = &v
+	}
+	if _v, ok := d.GetOkExists("uid_number"); ok {
+		v := _v.(int)
+		optArgs.Uidnumber = &v
+	}
+	if _v, ok := d.GetOkExists("gid_number"); ok {
+		v := _v.(int)
+		optArgs.Gidnumber = &v
+	}
+	if _v, ok := d.GetOkExists("organisation_unit"); ok {
+		v := _v.(string)
+		optArgs.Ou = &v
+	}
+	if _v, ok := d.GetOkExists("job_title"); ok {
+		v := _v.(string)
+		optArgs.Title = &v
+	}
+	if _v, ok := d.GetOkExists("manager"); ok {
+		v := _v.(string)
+		optArgs.Manager = &v
+	}
+	if _v, ok := d.GetOkExists("employee_number"); ok {
+		v := _v.(string)
+		optArgs.Employeenumber = &v
+	}
+	if _v, ok := d.GetOkExists("employee_type"); ok {
+		v := _v.(string)
+		optArgs.Employeetype = &v
+	}
+	if _v, ok := d.GetOkExists("preferred_language"); ok {
+		v := _v.(string)
+		optArgs.Preferredlanguage = &v
+	}
+	if _v, ok := d.GetOkExists("userclass"); ok {
+		v := utilsGetArry(_v.([]interface{}))
+		optArgs.Userclass = &v
+	}
+
+	_, err = client.StageuserAdd(&args, &optArgs)
+	if err != nil {
+		return diag.Errorf("Error creating freeipa stage user: %s", err)
+	}
+
+	d.SetId(d.Get("name").(string))
+
+	return resourceFreeIPAStageUserRead(ctx, d, meta)
+}
+
+func resourceFreeIPAStageUserRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Read freeipa stage user")
+
+	client, err := meta.(*Config).Client()
+	if err != nil {
+		return diag.Errorf("Error creating freeipa identity client: %s", err)
+	}
+
+	all := true
+	optArgs := ipa.StageuserShowOptionalArgs{
+		All: &all,
+	}
+
+	if _v, ok := d.GetOkExists("name"); ok {
+		v := _v.(string)
+		optArgs.UID = &v
+	} else {
+		var tempId = d.Id()
+		optArgs.UID = &tempId
+		d.Set("name", d.Id())
+	}
+
+	log.Printf("[DEBUG] Read freeipa stage user %s", d.Id())
+	res, err := client.StageuserShow(&ipa.StageuserShowArgs{}, &optArgs)
+	if err != nil {
+		if strings.Contains(err.Error(), "NotFound") {
+			// Activated stage users are moved out of the staging area.
+			_, err = client.UserShow(&ipa.UserShowArgs{}, &ipa.UserShowOptionalArgs{UID: optArgs.UID})
+			if err == nil {
+				d.Set("activated", true)
+				log.Printf("[DEBUG] Stage user activated")
+				return nil
+			}
+
+			d.SetId("")
+			log.Printf("[DEBUG] Stage user not found")
+			return nil
+		} else {
+			return diag.Errorf("Error reading freeipa stage user: %s", err)
+		}
+	}
+
+	log.Printf("[DEBUG] Read freeipa stage user %s", res.Result.UID)
+
+	d.Set("activated", false)
+
+	return nil
+}
+
+func resourceFreeIPAStageUserUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Update freeipa stage user")
+
+	client, err := meta.(*Config).Client()
+	if err != nil {
+		return diag.Errorf("Error creating freeipa identity client: %s", err)
+	}
+	if d.Get("activated").(bool) {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "Stage user already activated",
+			Detail:   "The stage user has been activated, its changes are not applied. Update the user instead.",
+		}}
+	}
+
+	var hasChange = false
+	optArgs := ipa.StageuserModOptionalArgs{}
+
+	if _v, ok := d.GetOkExists("name"); ok {
+		v := _v.(string)
+		optArgs.UID = &v
+	}
+
+	if d.HasChange("full_name") {
+		if _v, ok := d.GetOkExists("full_name"); ok {
+			v := _v.(string)
+			if v != "" {
+				optArgs.Cn = &v
+				hasChange = true
+			}
+		}
+	}
+	if d.HasChange("first_name") {
+		if _v, ok := d.GetOkExists("first_name"); ok {
+			v := _v.(string)
+			optArgs.Givenname = &v
+			hasChange = true
+		}
+	}
+	if d.HasChange("last_name") {
+		if _v, ok := d.GetOkExists("last_name"); ok {
+			v := _v.(string)
+			optArgs.Sn = &v
+			hasChange = true
+		}
+	}
+	if d.HasChange("display_name") {
+		if _v, ok := d.GetOkExists("display_name"); ok {
+			v := _v.(string)
+			optArgs.Displayname = &v
+			hasChange = true
+		}
+	}
+	if d.HasChange("initials") {
+		if _v, ok := d.GetOkExists("initials"); ok {
+			v := _v.(string)
+			optArgs.Initials = &v
+			hasChange = true
+		}
+	}
+	if d.HasChange("home_directory") {
+		if _v, ok := d.GetOkExists("home_directory"); ok {
+			v := _v.(string)
+			if v != "" {
+				optArgs.Homedirectory = &v
+				hasChange = true
+			}
+		}
+	}
+	if d.HasChange("login_shell") {
+		if _v, ok := d.GetOkExists("login_shell"); ok {
+			v := _v.(string)
+			if v != "" {
+				optArgs.Loginshell = &v
+				hasChange = true
+			}
+		}
+	}
+	if d.HasChange("uid_number") {
+		if _v, ok := d.GetOkExists("uid_number"); ok {
+			v := _v.(int)
+			if v != 0 {
+				optArgs.Uidnumber = &v
+				hasChange = true
+			}
+		}
+	}
+	if d.HasChange("gid_number") {
+		if _v, ok := d.GetOkExists("gid_number"); ok {
+			v := _v.(int)
+			if v != 0 {
+				optArgs.Gidnumber = &v
+				hasChange = true
+			}
+		}
+	}
+	if d.HasChange("organisation_unit") {
+		if _v, ok := d.GetOkExists("organisation_unit"); ok {
+			v := _v.(string)
+			optArgs.Ou = &v
+			hasChange = true
+		}
+	}
+	if d.HasChange("job_title") {
+		if _v, ok := d.GetOkExists("job_title"); ok {
+			v := _v.(string)
+			optArgs.Title = &v
+			hasChange = true
+		}
+	}
+	if d.HasChange("manager") {
+		if _v, ok := d.GetOkExists("manager"); ok {
+			v := _v.(string)
+			optArgs.Manager = &v
+			hasChange = true
+		}
+	}
+	if d.HasChange("employee_number") {
+		if _v, ok := d.GetOkExists("employee_number"); ok {
+			v := _v.(string)
+			optArgs.Employeenumber = &v
+			hasChange = true
+		}
+	}
+	if d.HasChange("employee_type") {
+		if _v, ok := d.GetOkExists("employee_type"); ok {
+			v := _v.(string)
+			optArgs.Employeetype = &v
+			hasChange = true
+		}
+	}
+	if d.HasChange("preferred_language") {
+		if _v, ok := d.GetOkExists("preferred_language"); ok {
+			v := _v.(string)
+			optArgs.Preferredlanguage = &v
+			hasChange = true
+		}
+	}
+	if d.HasChange("telephone_numbers") {
+		if _v, ok := d.GetOkExists("telephone_numbers"); ok {
+			v := utilsGetArry(_v.([]interface{}))
+			optArgs.Telephonenumber = &v
+			hasChange = true
+		}
+	}
+	if d.HasChange("mobile_numbers") {
+		if _v, ok := d.GetOkExists("mobile_numbers"); ok {
+			v := utilsGetArry(_v.([]interface{}))
+			optArgs.Mobile = &v
+			hasChange = true
+		}
+	}
+	if d.HasChange("email_address") {
+		if _v, ok := d.GetOkExists("email_address"); ok {
+			v := utilsGetArry(_v.([]interface{}))
+			optArgs.Mail = &v
+			hasChange = true
+		}
+	}
+	if d.HasChange("userclass") {
+		if _v, ok := d.GetOkExists("userclass"); ok {
+			v := utilsGetArry(_v.([]interface{}))
+			optArgs.Userclass = &v
+			hasChange = true
+		}
+	}
+
+	if hasChange {
+		_, err = client.StageuserMod(&ipa.StageuserModArgs{}, &optArgs)
+		if err != nil {
+			if strings.Contains(err.Error(), "EmptyModlist") {
+				log.Printf("[DEBUG] EmptyModlist (4202): no modifications to be performed")
+			} else {
+				return diag.Errorf("Error update freeipa stage user: %s", err)
+			}
+		}
+	}
+
+	return resourceFreeIPAStageUserRead(ctx, d, meta)
+}
+
+func resourceFreeIPAStageUserDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Delete freeipa stage user")
+
+	client, err := meta.(*Config).Client()
+	if err != nil {
+		return diag.Errorf("Error creating freeipa identity client: %s", err)
+	}
+	if d.Get("activated").(bool) {
+		log.Printf("[DEBUG] Stage user activated, not deleting the user")
+		d.SetId("")
+		return nil
+	}
+
+	optArgs := ipa.StageuserDelOptionalArgs{}
+
+	if _v, ok := d.GetOkExists("name"); ok {
+		v := []string{_v.(string)}
+		optArgs.UID = &v
+	}
+	_, err = client.StageuserDel(&ipa.StageuserDelArgs{}, &optArgs)
+	if err != nil && !strings.Contains(err.Error(), "NotFound") {
+		return diag.Errorf("Error delete freeipa stage user: %s", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package freeipa
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccFreeIPAStageUser(t *testing.T) {
+	testDataset := map[string]string{
+		"login":     "teststageuser",
+		"firstname": "Test",
+		"lastname":  "Stage",
+		"job_title": "Developer",
+	}
+	testDataset2 := map[string]string{
+		"login":     "teststageuser",
+		"firstname": "Chuck",
+		"lastname":  "Norris",
+		"job_title": "Ranger",
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFreeIPAStageUserResource_basic(testDataset),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("freeipa_stage_user.user", "name", testDataset["login"]),
+					resource.TestCheckResourceAttr("freeipa_stage_user.user", "first_name", testDataset["firstname"]),
+					resource.TestCheckResourceAttr("freeipa_stage_user.user", "job_title", testDataset["job_title"]),
+				),
+			},
+			{
+				Config: testAccFreeIPAStageUserResource_basic(testDataset2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("freeipa_stage_user.user", "name", testDataset2["login"]),
+					resource.TestCheckResourceAttr("freeipa_stage_user.user", "first_name", testDataset2["firstname"]),
+					resource.TestCheckResourceAttr("freeipa_stage_user.user", "job_title", testDataset2["job_title"]),
+				),
+			},
+		},
+	})
+}
+
+func testAccFreeIPAStageUserResource_basic(dataset map[string]string) string {
+	return fmt.Sprintf(`
+	resource "freeipa_stage_user" "user" {
+		name       = "%s"
+		first_name = "%s"
+		last_name  = "%s"
+		job_title  = "%s"
+	}
+	`, dataset["login"], dataset["firstname"], dataset["lastname"], dataset["job_title"])
+}
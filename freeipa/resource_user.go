@@ -9,6 +9,7 @@ import (
 	ipa "github.com/camptocamp/go-freeipa/freeipa"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceFreeIPAUser() *schema.Resource {
@@ -202,6 +203,42 @@ func resourceFreeIPAUser() *schema.Resource {
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Description: "User category (semantics placed on this attribute are for local interpretation)",
 			},
+			"user_auth_types": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{"password", "radius", "otp", "pkinit", "hardened", "idp", "passkey"}, false),
+				},
+				Description: "Types of supported user authentication, the global configuration applies when unset",
+			},
+			"idp": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "External identity provider the user authenticates with, typically managed by a `freeipa_idp` resource",
+			},
+			"idp_user_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Identifier of the user at the external identity provider",
+			},
+			"radius_config": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "RADIUS proxy the user authenticates with, typically managed by a `freeipa_radius_proxy` resource",
+			},
+			"lifecycle_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "active",
+				ValidateFunc: validation.StringInSlice([]string{"active", "preserved"}, false),
+				Description:  "Lifecycle state of the user, `active` or `preserved`. Preserved users are deleted but kept aside, and restored when switched back to `active`",
+			},
+			"from_stage_user": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Activate the stage user of the same name instead of creating the user, typically managed by a `freeipa_stage_user` resource referenced in `name`",
+			},
 		},
 	}
 }
@@ -353,6 +390,37 @@ func resourceFreeIPADNSUserCreate(ctx context.Context, d *schema.ResourceData, m
 		v := utilsGetArry(_v.([]interface{}))
 		optArgs.Userclass = &v
 	}
+	if _v, ok := d.GetOkExists("user_auth_types"); ok {
+		v := utilsGetArry(_v.([]interface{}))
+		optArgs.Ipauserauthtype = &v
+	}
+	if _v, ok := d.GetOkExists("idp"); ok {
+		v := _v.(string)
+		optArgs.Ipaidpconfiglink = &v
+	}
+	if _v, ok := d.GetOkExists("idp_user_id"); ok {
+		v := _v.(string)
+		optArgs.Ipaidpsub = &v
+	}
+	if _v, ok := d.GetOkExists("radius_config"); ok {
+		v := _v.(string)
+		optArgs.Ipatokenradiusconfiglink = &v
+	}
+
+	if d.Get("from_stage_user").(bool) {
+		uid := d.Get("name").(string)
+
+		_, err = client.StageuserActivate(&ipa.StageuserActivateArgs{}, &ipa.StageuserActivateOptionalArgs{UID: &uid})
+		if err != nil {
+			return diag.Errorf("Error activating freeipa stage user: %s", err)
+		}
+
+		d.SetId(uid)
+
+		// The attributes of the stage user are kept on activation: apply
+		// the configured ones as an update.
+		return resourceFreeIPADNSUserUpdate(ctx, d, meta)
+	}
 
 	_, err = client.UserAdd(&args, &optArgs)
 	if err != nil {
@@ -361,6 +429,12 @@ func resourceFreeIPADNSUserCreate(ctx context.Context, d *schema.ResourceData, m
 
 	d.SetId(d.Get("name").(string))
 
+	if d.Get("lifecycle_mode").(string) == "preserved" {
+		if err := resourceFreeIPAUserPreserve(client, d.Id()); err != nil {
+			return diag.Errorf("Error preserving freeipa user: %s", err)
+		}
+	}
+
 	return resourceFreeIPADNSUserRead(ctx, d, meta)
 }
 
@@ -400,6 +474,13 @@ func resourceFreeIPADNSUserRead(ctx context.Context, d *schema.ResourceData, met
 
 	log.Printf("[DEBUG] Read freeipa user %s", res.Result.UID)
 
+	// Preserved users are still returned by user_show.
+	if res.Result.Preserved != nil && *res.Result.Preserved {
+		d.Set("lifecycle_mode", "preserved")
+	} else {
+		d.Set("lifecycle_mode", "active")
+	}
+
 	return nil
 }
 
@@ -657,6 +738,42 @@ func resourceFreeIPADNSUserUpdate(ctx context.Context, d *schema.ResourceData, m
 			hasChange = true
 		}
 	}
+	if d.HasChange("user_auth_types") {
+		// An empty list falls back to the global configuration.
+		v := utilsGetArry(d.Get("user_auth_types").([]interface{}))
+		optArgs.Ipauserauthtype = &v
+		hasChange = true
+	}
+	if d.HasChange("idp") {
+		v := d.Get("idp").(string)
+		optArgs.Ipaidpconfiglink = &v
+		hasChange = true
+	}
+	if d.HasChange("idp_user_id") {
+		v := d.Get("idp_user_id").(string)
+		optArgs.Ipaidpsub = &v
+		hasChange = true
+	}
+	if d.HasChange("radius_config") {
+		v := d.Get("radius_config").(string)
+		optArgs.Ipatokenradiusconfiglink = &v
+		hasChange = true
+	}
+
+	oldMode, newMode := d.GetChange("lifecycle_mode")
+
+	// Preserved users cannot be modified: restore them before updating
+	// their attributes, and preserve them afterwards.
+	if oldMode == "preserved" && newMode == "active" {
+		_, err = client.UserUndel(&ipa.UserUndelArgs{}, &ipa.UserUndelOptionalArgs{UID: optArgs.UID})
+		if err != nil {
+			return diag.Errorf("Error restoring freeipa user: %s", err)
+		}
+	}
+
+	if hasChange && oldMode == "preserved" && newMode == "preserved" {
+		return diag.Errorf("Error update freeipa user: preserved users cannot be modified, set lifecycle_mode to active first")
+	}
 
 	if hasChange {
 		_, err = client.UserMod(&ipa.UserModArgs{}, &optArgs)
@@ -669,6 +786,12 @@ func resourceFreeIPADNSUserUpdate(ctx context.Context, d *schema.ResourceData, m
 		}
 	}
 
+	if oldMode != "preserved" && newMode == "preserved" {
+		if err := resourceFreeIPAUserPreserve(client, d.Id()); err != nil {
+			return diag.Errorf("Error preserving freeipa user: %s", err)
+		}
+	}
+
 	return resourceFreeIPADNSUserRead(ctx, d, meta)
 }
 
@@ -694,3 +817,15 @@ func resourceFreeIPADNSUserDelete(ctx context.Context, d *schema.ResourceData, m
 
 	return nil
 }
+
+func resourceFreeIPAUserPreserve(client *ipa.Client, uid string) error {
+	preserve := true
+	uids := []string{uid}
+
+	_, err := client.UserDel(&ipa.UserDelArgs{}, &ipa.UserDelOptionalArgs{
+		UID:      &uids,
+		Preserve: &preserve,
+	})
+
+	return err
+}
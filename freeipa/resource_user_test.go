@@ -42,6 +42,7 @@ func TestAccFreeIPADNSUser(t *testing.T) {
 		"krb_principal_expiration": "2049-12-31T23:59:59Z",
 		"krb_password_expiration":  "2049-12-31T23:59:59Z",
 		"userclass":                "user-account",
+		"user_auth_types":          "otp",
 	}
 	testDataset2 := map[string]string{
 		"login":     "testuser2",
@@ -94,6 +95,43 @@ func TestAccFreeIPADNSUser(t *testing.T) {
 	})
 }
 
+func TestAccFreeIPAUser_lifecycle(t *testing.T) {
+	testDataset := map[string]string{
+		"login":     "testlifecycleuser",
+		"firstname": "Test",
+		"lastname":  "Lifecycle",
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFreeIPAUserResource_lifecycle(testDataset, "active"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("freeipa_user.user", "name", testDataset["login"]),
+					resource.TestCheckResourceAttr("freeipa_user.user", "lifecycle_mode", "active"),
+				),
+			},
+			{
+				Config: testAccFreeIPAUserResource_lifecycle(testDataset, "preserved"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("freeipa_user.user", "name", testDataset["login"]),
+					resource.TestCheckResourceAttr("freeipa_user.user", "lifecycle_mode", "preserved"),
+					resource.TestCheckResourceAttr("freeipa_stage_user.user", "activated", "true"),
+				),
+			},
+			{
+				Config: testAccFreeIPAUserResource_lifecycle(testDataset, "active"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("freeipa_user.user", "name", testDataset["login"]),
+					resource.TestCheckResourceAttr("freeipa_user.user", "lifecycle_mode", "active"),
+				),
+			},
+		},
+	})
+}
+
 func testAccFreeIPADNSUserResource_basic(dataset map[string]string) string {
 	return fmt.Sprintf(`
 	resource "freeipa_user" "user" {
@@ -140,11 +178,30 @@ func testAccFreeIPADNSUserResource_full(dataset map[string]string) string {
 		krb_principal_expiration = "%s"
 		krb_password_expiration = "%s"
 		userclass = ["%s"]
+		user_auth_types = ["%s"]
 	}
 	`, dataset["login"], dataset["firstname"], dataset["lastname"], dataset["account_disabled"],
 		dataset["car_license"], dataset["city"], dataset["display_name"], dataset["email_address"], dataset["employee_number"], dataset["employee_type"],
 		dataset["full_name"], dataset["gecos"], dataset["gid_number"], dataset["home_directory"], dataset["initials"], dataset["job_title"],
 		dataset["krb_principal_name"], dataset["login_shell"], dataset["manager"], dataset["mobile_numbers"], dataset["organisation_unit"], dataset["postal_code"],
 		dataset["preferred_language"], dataset["province"], dataset["random_password"], dataset["ssh_public_key"], dataset["street_address"], dataset["telephone_numbers"],
-		dataset["uid_number"], dataset["userpassword"], dataset["krb_principal_expiration"], dataset["krb_password_expiration"], dataset["userclass"])
+		dataset["uid_number"], dataset["userpassword"], dataset["krb_principal_expiration"], dataset["krb_password_expiration"], dataset["userclass"], dataset["user_auth_types"])
+}
+
+func testAccFreeIPAUserResource_lifecycle(dataset map[string]string, lifecycleMode string) string {
+	return fmt.Sprintf(`
+	resource "freeipa_stage_user" "user" {
+		name       = "%[1]s"
+		first_name = "%[2]s"
+		last_name  = "%[3]s"
+	}
+
+	resource "freeipa_user" "user" {
+		name            = freeipa_stage_user.user.name
+		first_name      = "%[2]s"
+		last_name       = "%[3]s"
+		from_stage_user = true
+		lifecycle_mode  = "%[4]s"
+	}
+	`, dataset["login"], dataset["firstname"], dataset["lastname"], lifecycleMode)
 }
@@ -0,0 +1,160 @@
+package datasources
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/camptocamp/go-freeipa/freeipa"
+	"github.com/camptocamp/terraform-provider-freeipa/internal/provider"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type AutomountLocation struct {
+	provider *provider.Provider
+}
+
+type AutomountLocationModel struct {
+	Name types.String `tfsdk:"cn"`
+	Maps types.Map    `tfsdk:"maps"`
+}
+
+func (d *AutomountLocation) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_automount_location"
+}
+
+func (d *AutomountLocation) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Renders the automount maps of a location",
+		Attributes: map[string]schema.Attribute{
+			"cn": schema.StringAttribute{
+				Description: "Automount location name",
+				Required:    true,
+			},
+			"maps": schema.MapAttribute{
+				Description: "Maps of the location by name, in the autofs map file format",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *AutomountLocation) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config, state AutomountLocationModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.AutomountmapFindArgs{
+		Automountlocationcn: config.Name.ValueString(),
+	}
+
+	optArgs := &freeipa.AutomountmapFindOptionalArgs{
+		Sizelimit: freeipa.Int(0),
+	}
+
+	tflog.Trace(ctx, "Calling AutomountmapFind", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	res, err := d.provider.Client().AutomountmapFind("", args, optArgs)
+
+	tflog.Trace(ctx, "Called AutomountmapFind", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read automount maps", "Reason: "+err.Error())
+		return
+	}
+
+	maps := make(map[string]string, len(res.Result))
+
+	for _, m := range res.Result {
+		content, err := d.renderMap(ctx, config.Name.ValueString(), m.Automountmapname)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to read automount map keys", "Reason: "+err.Error())
+			return
+		}
+
+		maps[m.Automountmapname] = content
+	}
+
+	var diags diag.Diagnostics
+
+	state.Name = config.Name
+
+	state.Maps, diags = types.MapValueFrom(ctx, types.StringType, maps)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func NewAutomountLocation(p *provider.Provider) datasource.DataSource {
+	d := &AutomountLocation{
+		provider: p,
+	}
+
+	var _ datasource.DataSource = d
+
+	return d
+}
+
+func init() {
+	dataSources = append(dataSources, NewAutomountLocation)
+}
+
+// renderMap returns the keys of a map in the autofs map file format, sorted
+// by key.
+func (d *AutomountLocation) renderMap(ctx context.Context, location, name string) (string, error) {
+	args := &freeipa.AutomountkeyFindArgs{
+		Automountlocationcn:          location,
+		Automountmapautomountmapname: name,
+	}
+
+	optArgs := &freeipa.AutomountkeyFindOptionalArgs{
+		Sizelimit: freeipa.Int(0),
+	}
+
+	tflog.Trace(ctx, "Calling AutomountkeyFind", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	res, err := d.provider.Client().AutomountkeyFind("", args, optArgs)
+
+	tflog.Trace(ctx, "Called AutomountkeyFind", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	keys := res.Result
+
+	slices.SortFunc(keys, func(a, b freeipa.Automountkey) int {
+		return strings.Compare(a.Automountkey, b.Automountkey)
+	})
+
+	var content strings.Builder
+
+	for _, key := range keys {
+		content.WriteString(key.Automountkey + "\t" + key.Automountinformation + "\n")
+	}
+
+	return content.String(), nil
+}
@@ -0,0 +1,166 @@
+package datasources
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/pem"
+
+	"github.com/camptocamp/go-freeipa/freeipa"
+	"github.com/camptocamp/terraform-provider-freeipa/internal/provider"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// ipaCAName is the name of the main IPA CA, which issues the certificates
+// of all lightweight sub-CAs.
+const ipaCAName = "ipa"
+
+type CA struct {
+	provider *provider.Provider
+}
+
+type CAModel struct {
+	Name        types.String `tfsdk:"cn"`
+	SubjectDN   types.String `tfsdk:"subject_dn"`
+	Description types.String `tfsdk:"description"`
+	IssuerDN    types.String `tfsdk:"issuer_dn"`
+	Certificate types.String `tfsdk:"certificate"`
+	Chain       types.String `tfsdk:"chain"`
+}
+
+func (d *CA) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ca"
+}
+
+func (d *CA) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"cn": schema.StringAttribute{
+				Description: "Name of the CA (defaults to the IPA CA, “ipa”)",
+				Optional:    true,
+				Computed:    true,
+			},
+			"subject_dn": schema.StringAttribute{
+				Description: "Subject distinguished name",
+				Computed:    true,
+			},
+			"description": schema.StringAttribute{
+				Computed: true,
+			},
+			"issuer_dn": schema.StringAttribute{
+				Description: "Issuer distinguished name",
+				Computed:    true,
+			},
+			"certificate": schema.StringAttribute{
+				Description: "CA certificate in PEM format",
+				Computed:    true,
+			},
+			"chain": schema.StringAttribute{
+				Description: "CA certificate chain up to the IPA CA in PEM format",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *CA) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config, state CAModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := ipaCAName
+
+	if !config.Name.IsNull() {
+		name = config.Name.ValueString()
+	}
+
+	res, err := d.show(ctx, name)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read CA", "Reason: "+err.Error())
+		return
+	}
+
+	certificate, err := certificatePEM(res.Result.Certificate)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read CA certificate", "Reason: "+err.Error())
+		return
+	}
+
+	chain := certificate
+
+	if name != ipaCAName {
+		res, err := d.show(ctx, ipaCAName)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to read IPA CA", "Reason: "+err.Error())
+			return
+		}
+
+		ipaCertificate, err := certificatePEM(res.Result.Certificate)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to read IPA CA certificate", "Reason: "+err.Error())
+			return
+		}
+
+		chain += ipaCertificate
+	}
+
+	state.Name = types.StringValue(name)
+	state.SubjectDN = types.StringValue(res.Result.Ipacasubjectdn)
+	state.Description = types.StringPointerValue(res.Result.Description)
+	state.IssuerDN = types.StringValue(res.Result.Ipacaissuerdn)
+	state.Certificate = types.StringValue(certificate)
+	state.Chain = types.StringValue(chain)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func NewCA(p *provider.Provider) datasource.DataSource {
+	d := &CA{
+		provider: p,
+	}
+
+	var _ datasource.DataSource = d
+
+	return d
+}
+
+func init() {
+	dataSources = append(dataSources, NewCA)
+}
+
+// show returns a CA along with its certificate. The certificate chain is not
+// requested, as the client cannot decode it.
+func (d *CA) show(ctx context.Context, cn string) (*freeipa.CaShowResult, error) {
+	args := &freeipa.CaShowArgs{
+		Cn: cn,
+	}
+
+	tflog.Trace(ctx, "Calling CaShow", map[string]any{
+		"args":     args,
+		"opt_args": nil,
+	})
+
+	res, err := d.provider.Client().CaShow(args, nil)
+
+	tflog.Trace(ctx, "Called CaShow", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	return res, err
+}
+
+// certificatePEM converts a base64 DER certificate to the PEM format.
+func certificatePEM(certificate string) (string, error) {
+	der, err := base64.StdEncoding.DecodeString(certificate)
+	if err != nil {
+		return "", err
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})), nil
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+)
+
+// ldapClient performs LDAP extended operations on the directory server of
+// FreeIPA, for the features which are not exposed by the JSON-RPC API.
+type ldapClient struct {
+	host      string
+	username  string
+	password  string
+	tlsConfig *tls.Config
+
+	rpc *rpcClient
+}
+
+func newLDAPClient(host string, tlsConfig *tls.Config, username, password string, rpc *rpcClient) *ldapClient {
+	return &ldapClient{
+		host:      host,
+		username:  username,
+		password:  password,
+		tlsConfig: tlsConfig,
+		rpc:       rpc,
+	}
+}
+
+// Extended performs an LDAP extended operation with the given request value,
+// bound as the configured user, and returns the value of its response.
+func (p *Provider) Extended(name string, value []byte) ([]byte, error) {
+	if p.ldap == nil {
+		return nil, fmt.Errorf("provider is not configured")
+	}
+
+	return p.ldap.extended(name, value)
+}
+
+func (c *ldapClient) extended(name string, value []byte) ([]byte, error) {
+	bindDN, err := c.bindDN()
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := ldap.DialURL("ldaps://"+c.address(), ldap.DialWithTLSConfig(c.tlsConfig))
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Close()
+
+	if err := conn.Bind(bindDN, c.password); err != nil {
+		return nil, err
+	}
+
+	res, err := conn.Extended(ldap.NewExtendedRequest(name, ber.NewString(ber.ClassContext, ber.TypePrimitive, 1, string(value), "Extended Request Value")))
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Value == nil {
+		return nil, fmt.Errorf("missing value in extended operation response")
+	}
+
+	return res.Value.Data.Bytes(), nil
+}
+
+// address returns the address of the directory server, which runs on the
+// FreeIPA host.
+func (c *ldapClient) address() string {
+	if host, _, err := net.SplitHostPort(c.host); err == nil {
+		return net.JoinHostPort(host, "636")
+	}
+
+	return net.JoinHostPort(c.host, "636")
+}
+
+// bindDN returns the DN of the configured user, under the base DN of the
+// FreeIPA domain.
+func (c *ldapClient) bindDN() (string, error) {
+	var res struct {
+		Result struct {
+			BaseDN string `json:"basedn"`
+		} `json:"result"`
+	}
+
+	if err := c.rpc.call("env", []any{"basedn"}, nil, &res); err != nil {
+		return "", err
+	}
+
+	if res.Result.BaseDN == "" {
+		return "", fmt.Errorf("failed to read the base DN of the FreeIPA domain")
+	}
+
+	return fmt.Sprintf("uid=%s,cn=users,cn=accounts,%s", ldap.EscapeDN(c.username), res.Result.BaseDN), nil
+}
@@ -25,6 +25,8 @@ type Provider struct {
 	resources   []func() resource.Resource
 
 	client *freeipa.Client
+	rpc    *rpcClient
+	ldap   *ldapClient
 }
 
 type Model struct {
@@ -116,14 +118,16 @@ func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest,
 
 	var err error
 
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		TLSClientConfig: &tls.Config{
+			InsecureSkipVerify: insecureSkipVerify,
+		},
+	}
+
 	p.client, err = freeipa.Connect(
 		host,
-		&http.Transport{
-			Proxy: http.ProxyFromEnvironment,
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: insecureSkipVerify,
-			},
-		},
+		transport,
 		username,
 		password,
 	)
@@ -132,6 +136,14 @@ func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest,
 		return
 	}
 
+	p.rpc, err = newRPCClient(host, transport, username, password)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to connect to FreeIPA", "Reason: "+err.Error())
+		return
+	}
+
+	p.ldap = newLDAPClient(host, transport.TLSClientConfig, username, password, p.rpc)
+
 	tflog.Info(ctx, "Successfully connected to FreeIPA", map[string]any{
 		"host":     host,
 		"username": username,
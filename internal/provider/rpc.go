@@ -0,0 +1,299 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/camptocamp/go-freeipa/freeipa"
+)
+
+// apiVersion is the FreeIPA API version the go-freeipa client is generated
+// for.
+const apiVersion = "2.251"
+
+// rpcClient performs raw JSON-RPC calls, for the commands whose results the
+// go-freeipa client fails to decode.
+type rpcClient struct {
+	host     string
+	username string
+	password string
+
+	hc       *http.Client
+	loginMtx sync.Mutex
+	loggedIn bool
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *freeipa.Error  `json:"error"`
+}
+
+func newRPCClient(host string, transport http.RoundTripper, username, password string) (*rpcClient, error) {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rpcClient{
+		host:     host,
+		username: username,
+		password: password,
+		hc: &http.Client{
+			Transport: transport,
+			Jar:       jar,
+		},
+	}, nil
+}
+
+// Call calls a FreeIPA command with the given positional arguments and
+// options, decoding its result into result. API errors are returned as
+// *freeipa.Error, like the go-freeipa client does.
+//
+// Resources use it where the client fails to decode the results, mostly for
+// optional or multi-valued attributes it models as required or single values.
+// The argument structs of the client can be embedded in the options, which
+// is how the client itself sends the required arguments.
+func (p *Provider) Call(method string, args []any, options any, result any) error {
+	if p.rpc == nil {
+		return fmt.Errorf("provider is not configured")
+	}
+
+	return p.rpc.call(method, args, options, result)
+}
+
+// Get requests the given path of the FreeIPA host and decodes its JSON
+// response into result, for the data which is only exposed by the REST API
+// of the Dogtag CA.
+func (p *Provider) Get(path string, result any) error {
+	if p.rpc == nil {
+		return fmt.Errorf("provider is not configured")
+	}
+
+	return p.rpc.get(path, result)
+}
+
+func (c *rpcClient) get(path string, result any) error {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("https://%s%s", c.host, path), nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	res, err := c.hc.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected http status code: %v", res.StatusCode)
+	}
+
+	return json.NewDecoder(res.Body).Decode(result)
+}
+
+// BatchCall is a command called as part of a batch.
+type BatchCall struct {
+	Method  string
+	Args    []any
+	Options any
+}
+
+// Batch calls the given commands in a single request. FreeIPA runs all the
+// commands even when some of them fail, so the error of each command is
+// returned, as *freeipa.Error or nil, along with the error of the request.
+func (p *Provider) Batch(calls []BatchCall) ([]error, error) {
+	if p.rpc == nil {
+		return nil, fmt.Errorf("provider is not configured")
+	}
+
+	return p.rpc.batch(calls)
+}
+
+func (c *rpcClient) batch(calls []BatchCall) ([]error, error) {
+	methods := make([]any, 0, len(calls))
+
+	for _, call := range calls {
+		params, err := rpcParams(call.Args, call.Options)
+		if err != nil {
+			return nil, err
+		}
+
+		methods = append(methods, map[string]any{
+			"method": call.Method,
+			"params": params,
+		})
+	}
+
+	var res struct {
+		Results []struct {
+			Error     *string `json:"error"`
+			ErrorCode int     `json:"error_code"`
+			ErrorName string  `json:"error_name"`
+		} `json:"results"`
+	}
+
+	if err := c.call("batch", methods, nil, &res); err != nil {
+		return nil, err
+	}
+
+	if len(res.Results) != len(calls) {
+		return nil, fmt.Errorf("unexpected number of batch results: %d, expected %d", len(res.Results), len(calls))
+	}
+
+	errs := make([]error, len(calls))
+
+	for i, result := range res.Results {
+		if result.Error != nil {
+			errs[i] = &freeipa.Error{
+				Message: *result.Error,
+				Code:    result.ErrorCode,
+				Name:    result.ErrorName,
+			}
+		}
+	}
+
+	return errs, nil
+}
+
+// rpcParams returns the JSON-RPC parameters of a command.
+func rpcParams(args []any, options any) ([]any, error) {
+	params := map[string]any{}
+
+	if options != nil {
+		b, err := json.Marshal(options)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(b, &params); err != nil {
+			return nil, err
+		}
+	}
+
+	params["version"] = apiVersion
+
+	if args == nil {
+		args = []any{}
+	}
+
+	return []any{args, params}, nil
+}
+
+func (c *rpcClient) call(method string, args []any, options any, result any) error {
+	params, err := rpcParams(args, options)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"method": method,
+		"params": params,
+		"id":     0,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := c.login(false); err != nil {
+		return err
+	}
+
+	res, err := c.send(body)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode == http.StatusUnauthorized {
+		res.Body.Close()
+
+		if err := c.login(true); err != nil {
+			return err
+		}
+
+		if res, err = c.send(body); err != nil {
+			return err
+		}
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected http status code: %v", res.StatusCode)
+	}
+
+	var rpcRes rpcResponse
+
+	if err := json.NewDecoder(res.Body).Decode(&rpcRes); err != nil {
+		return err
+	}
+
+	if rpcRes.Error != nil {
+		return rpcRes.Error
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.Unmarshal(rpcRes.Result, result)
+}
+
+func (c *rpcClient) send(body []byte) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("https://%s/ipa/session/json", c.host), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Referer", fmt.Sprintf("https://%s/ipa/ui", c.host))
+
+	return c.hc.Do(req)
+}
+
+// login opens a session, unless one is already open and renew is false.
+func (c *rpcClient) login(renew bool) error {
+	c.loginMtx.Lock()
+	defer c.loginMtx.Unlock()
+
+	if c.loggedIn && !renew {
+		return nil
+	}
+
+	data := url.Values{
+		"user":     []string{c.username},
+		"password": []string{c.password},
+	}
+
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("https://%s/ipa/session/login_password", c.host), strings.NewReader(data.Encode()))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Referer", fmt.Sprintf("https://%s/ipa", c.host))
+
+	res, err := c.hc.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("login failed: unexpected http status code: %v", res.StatusCode)
+	}
+
+	c.loggedIn = true
+
+	return nil
+}
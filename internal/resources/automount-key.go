@@ -0,0 +1,269 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/camptocamp/go-freeipa/freeipa"
+	"github.com/camptocamp/terraform-provider-freeipa/internal/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type AutomountKey struct {
+	provider *provider.Provider
+}
+
+type AutomountKeyModel struct {
+	Location    types.String `tfsdk:"location"`
+	Map         types.String `tfsdk:"map"`
+	Key         types.String `tfsdk:"key"`
+	Information types.String `tfsdk:"information"`
+}
+
+func (r *AutomountKey) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_automount_key"
+}
+
+func (r *AutomountKey) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version:     0,
+		Description: "Manages a key of an automount map",
+		Attributes: map[string]schema.Attribute{
+			"location": schema.StringAttribute{
+				Description: "Automount location of the map",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"map": schema.StringAttribute{
+				Description: "Automount map of the key",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key": schema.StringAttribute{
+				Description: "Automount key, the mount point relative to the map mount point or absolute for direct maps",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"information": schema.StringAttribute{
+				Description: "Mount information, the mount options and location (e.g. “-rw,soft nfs.example.test:/export/home/&”)",
+				Required:    true,
+			},
+		},
+	}
+}
+
+func (r *AutomountKey) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan, state AutomountKeyModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.AutomountkeyAddArgs{
+		Automountlocationcn:          plan.Location.ValueString(),
+		Automountmapautomountmapname: plan.Map.ValueString(),
+		Automountkey:                 plan.Key.ValueString(),
+		Automountinformation:         plan.Information.ValueString(),
+	}
+
+	tflog.Trace(ctx, "Calling AutomountkeyAdd", map[string]any{
+		"args":     args,
+		"opt_args": nil,
+	})
+
+	res, err := r.provider.Client().AutomountkeyAdd(args, nil)
+
+	tflog.Trace(ctx, "Called AutomountkeyAdd", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create automount key", "Reason: "+err.Error())
+		return
+	}
+
+	state = plan
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *AutomountKey) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state AutomountKeyModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.AutomountkeyShowArgs{
+		Automountlocationcn:          state.Location.ValueString(),
+		Automountmapautomountmapname: state.Map.ValueString(),
+		Automountkey:                 state.Key.ValueString(),
+	}
+
+	tflog.Trace(ctx, "Calling AutomountkeyShow", map[string]any{
+		"args":     args,
+		"opt_args": nil,
+	})
+
+	res, err := r.provider.Client().AutomountkeyShow(args, nil)
+
+	tflog.Trace(ctx, "Called AutomountkeyShow", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if errors.As(err, &freeipaErr) && freeipaErr.Code == freeipa.NotFoundCode {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Failed to read automount key", "Reason: "+err.Error())
+		return
+	}
+
+	state.Information = types.StringValue(res.Result.Automountinformation)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *AutomountKey) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state, plan AutomountKeyModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Information.Equal(state.Information) {
+		args := &freeipa.AutomountkeyModArgs{
+			Automountlocationcn:          plan.Location.ValueString(),
+			Automountmapautomountmapname: plan.Map.ValueString(),
+			Automountkey:                 plan.Key.ValueString(),
+		}
+
+		optArgs := &freeipa.AutomountkeyModOptionalArgs{
+			Automountinformation:    state.Information.ValueStringPointer(),
+			Newautomountinformation: plan.Information.ValueStringPointer(),
+		}
+
+		tflog.Trace(ctx, "Calling AutomountkeyMod", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		res, err := r.provider.Client().AutomountkeyMod(args, optArgs)
+
+		tflog.Trace(ctx, "Called AutomountkeyMod", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		if err != nil {
+			var freeipaErr *freeipa.Error
+
+			if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.EmptyModlistCode {
+				resp.Diagnostics.AddError("Failed to update automount key", "Reason: "+err.Error())
+				return
+			}
+
+			tflog.Debug(ctx, "Updated automount key has no effective difference", map[string]any{
+				"args":     args,
+				"opt_args": optArgs,
+			})
+		}
+	}
+
+	state = plan
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *AutomountKey) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state AutomountKeyModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.AutomountkeyDelArgs{
+		Automountlocationcn:          state.Location.ValueString(),
+		Automountmapautomountmapname: state.Map.ValueString(),
+		Automountkey:                 state.Key.ValueString(),
+	}
+
+	tflog.Trace(ctx, "Calling AutomountkeyDel", map[string]any{
+		"args":     args,
+		"opt_args": nil,
+	})
+
+	res, err := r.provider.Client().AutomountkeyDel(args, nil)
+
+	tflog.Trace(ctx, "Called AutomountkeyDel", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.NotFoundCode {
+			resp.Diagnostics.AddError("Failed to delete automount key", "Reason: "+err.Error())
+			return
+		}
+	}
+}
+
+func (r *AutomountKey) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Keys of direct maps are absolute paths, only split the location and
+	// map name.
+	id := strings.SplitN(req.ID, "/", 3)
+
+	if len(id) != 3 {
+		resp.Diagnostics.AddError("Invalid ID format", "Expected ID format is “<location>/<map name>/<key>”")
+
+		return
+	}
+
+	state := AutomountKeyModel{
+		Location: types.StringValue(id[0]),
+		Map:      types.StringValue(id[1]),
+		Key:      types.StringValue(id[2]),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func NewAutomountKey(p *provider.Provider) resource.Resource {
+	r := &AutomountKey{
+		provider: p,
+	}
+
+	var _ resource.Resource = r
+	var _ resource.ResourceWithImportState = r
+
+	return r
+}
+
+func init() {
+	resources = append(resources, NewAutomountKey)
+}
@@ -0,0 +1,403 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/camptocamp/go-freeipa/freeipa"
+	"github.com/camptocamp/terraform-provider-freeipa/internal/provider"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// automountMapImportedKey is the private state key flagging imported maps,
+// whose mount point is read once from their parent map.
+const automountMapImportedKey = "imported"
+
+type AutomountMap struct {
+	provider *provider.Provider
+}
+
+type AutomountMapModel struct {
+	Location    types.String `tfsdk:"location"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	MountPoint  types.String `tfsdk:"mount_point"`
+	ParentMap   types.String `tfsdk:"parent_map"`
+}
+
+func (r *AutomountMap) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_automount_map"
+}
+
+func (r *AutomountMap) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version:     0,
+		Description: "Manages an automount map. Setting a mount point creates an indirect map, mounted by a key of its parent map. Indirect maps mounted by another parent map than “auto.master” are imported with the parent map appended to their ID, as in “default/auto.home/auto.other”",
+		Attributes: map[string]schema.Attribute{
+			"location": schema.StringAttribute{
+				Description: "Automount location of the map",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Automount map name",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description: "Automount map description",
+				Optional:    true,
+			},
+			"mount_point": schema.StringAttribute{
+				Description: "Mount point of an indirect map, added as a key of its parent map",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"parent_map": schema.StringAttribute{
+				Description: "Parent map of an indirect map (defaults to “auto.master”)",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *AutomountMap) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config AutomountMapModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.ParentMap.IsNull() && config.MountPoint.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("parent_map"),
+			"Invalid configuration",
+			"“parent_map” can only be set along with “mount_point”.",
+		)
+	}
+}
+
+func (r *AutomountMap) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan, state AutomountMapModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var err error
+
+	if plan.MountPoint.IsNull() {
+		args := &freeipa.AutomountmapAddArgs{
+			Automountlocationcn: plan.Location.ValueString(),
+			Automountmapname:    plan.Name.ValueString(),
+		}
+
+		optArgs := &freeipa.AutomountmapAddOptionalArgs{
+			Description: plan.Description.ValueStringPointer(),
+		}
+
+		tflog.Trace(ctx, "Calling AutomountmapAdd", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		var res *freeipa.AutomountmapAddResult
+
+		res, err = r.provider.Client().AutomountmapAdd(args, optArgs)
+
+		tflog.Trace(ctx, "Called AutomountmapAdd", map[string]any{
+			"res": res,
+			"err": err,
+		})
+	} else {
+		args := &freeipa.AutomountmapAddIndirectArgs{
+			Automountlocationcn: plan.Location.ValueString(),
+			Automountmapname:    plan.Name.ValueString(),
+			Key:                 plan.MountPoint.ValueString(),
+		}
+
+		optArgs := &freeipa.AutomountmapAddIndirectOptionalArgs{
+			Description: plan.Description.ValueStringPointer(),
+			Parentmap:   plan.ParentMap.ValueStringPointer(),
+		}
+
+		tflog.Trace(ctx, "Calling AutomountmapAddIndirect", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		var res *freeipa.AutomountmapAddIndirectResult
+
+		res, err = r.provider.Client().AutomountmapAddIndirect(args, optArgs)
+
+		tflog.Trace(ctx, "Called AutomountmapAddIndirect", map[string]any{
+			"res": res,
+			"err": err,
+		})
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create automount map", "Reason: "+err.Error())
+		return
+	}
+
+	state = plan
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *AutomountMap) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state AutomountMapModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.AutomountmapShowArgs{
+		Automountlocationcn: state.Location.ValueString(),
+		Automountmapname:    state.Name.ValueString(),
+	}
+
+	tflog.Trace(ctx, "Calling AutomountmapShow", map[string]any{
+		"args":     args,
+		"opt_args": nil,
+	})
+
+	res, err := r.provider.Client().AutomountmapShow(args, nil)
+
+	tflog.Trace(ctx, "Called AutomountmapShow", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if errors.As(err, &freeipaErr) && freeipaErr.Code == freeipa.NotFoundCode {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Failed to read automount map", "Reason: "+err.Error())
+		return
+	}
+
+	state.Description = types.StringPointerValue(res.Result.Description)
+
+	// Imported maps do not know yet whether they are mounted by their parent map.
+	imported, diags := req.Private.GetKey(ctx, automountMapImportedKey)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !state.MountPoint.IsNull() || imported != nil {
+		state.MountPoint, err = r.readMountPoint(ctx, state)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to read automount map", "Reason: "+err.Error())
+			return
+		}
+	}
+
+	if imported != nil {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, automountMapImportedKey, nil)...)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// readMountPoint returns the key of the parent map mounting the map, or null if
+// there is none.
+func (r *AutomountMap) readMountPoint(ctx context.Context, state AutomountMapModel) (types.String, error) {
+	parentMap := "auto.master"
+
+	if !state.ParentMap.IsNull() {
+		parentMap = state.ParentMap.ValueString()
+	}
+
+	args := &freeipa.AutomountkeyFindArgs{
+		Automountlocationcn:          state.Location.ValueString(),
+		Automountmapautomountmapname: parentMap,
+	}
+
+	optArgs := &freeipa.AutomountkeyFindOptionalArgs{
+		Automountinformation: state.Name.ValueStringPointer(),
+	}
+
+	tflog.Trace(ctx, "Calling AutomountkeyFind", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	res, err := r.provider.Client().AutomountkeyFind("", args, optArgs)
+
+	tflog.Trace(ctx, "Called AutomountkeyFind", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if errors.As(err, &freeipaErr) && freeipaErr.Code == freeipa.NotFoundCode {
+			return types.StringNull(), nil
+		}
+
+		return types.StringNull(), err
+	}
+
+	// Indirect maps are mounted by a key whose information is the map name.
+	for _, key := range res.Result {
+		if key.Automountinformation == state.Name.ValueString() {
+			return types.StringValue(key.Automountkey), nil
+		}
+	}
+
+	return types.StringNull(), nil
+}
+
+func (r *AutomountMap) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state, plan AutomountMapModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Description.Equal(state.Description) {
+		args := &freeipa.AutomountmapModArgs{
+			Automountlocationcn: plan.Location.ValueString(),
+			Automountmapname:    plan.Name.ValueString(),
+		}
+
+		optArgs := &freeipa.AutomountmapModOptionalArgs{
+			Description: freeipa.String(plan.Description.ValueString()),
+		}
+
+		tflog.Trace(ctx, "Calling AutomountmapMod", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		res, err := r.provider.Client().AutomountmapMod(args, optArgs)
+
+		tflog.Trace(ctx, "Called AutomountmapMod", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		if err != nil {
+			var freeipaErr *freeipa.Error
+
+			if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.EmptyModlistCode {
+				resp.Diagnostics.AddError("Failed to update automount map", "Reason: "+err.Error())
+				return
+			}
+
+			tflog.Debug(ctx, "Updated automount map has no effective difference", map[string]any{
+				"args":     args,
+				"opt_args": optArgs,
+			})
+		}
+	}
+
+	state = plan
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *AutomountMap) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state AutomountMapModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Deleting the map also deletes the keys of other maps mounting it.
+	args := &freeipa.AutomountmapDelArgs{
+		Automountlocationcn: state.Location.ValueString(),
+		Automountmapname:    []string{state.Name.ValueString()},
+	}
+
+	tflog.Trace(ctx, "Calling AutomountmapDel", map[string]any{
+		"args":     args,
+		"opt_args": nil,
+	})
+
+	res, err := r.provider.Client().AutomountmapDel(args, nil)
+
+	tflog.Trace(ctx, "Called AutomountmapDel", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.NotFoundCode {
+			resp.Diagnostics.AddError("Failed to delete automount map", "Reason: "+err.Error())
+			return
+		}
+	}
+}
+
+func (r *AutomountMap) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := strings.Split(req.ID, "/")
+
+	if len(id) != 2 && len(id) != 3 {
+		resp.Diagnostics.AddError("Invalid ID format", "Expected ID format is “<location>/<map name>” or “<location>/<map name>/<parent map>”")
+
+		return
+	}
+
+	state := AutomountMapModel{
+		Location:  types.StringValue(id[0]),
+		Name:      types.StringValue(id[1]),
+		ParentMap: types.StringNull(),
+	}
+
+	if len(id) == 3 {
+		state.ParentMap = types.StringValue(id[2])
+	}
+
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, automountMapImportedKey, []byte("true"))...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func NewAutomountMap(p *provider.Provider) resource.Resource {
+	r := &AutomountMap{
+		provider: p,
+	}
+
+	var _ resource.Resource = r
+	var _ resource.ResourceWithValidateConfig = r
+	var _ resource.ResourceWithImportState = r
+
+	return r
+}
+
+func init() {
+	resources = append(resources, NewAutomountMap)
+}
@@ -0,0 +1,52 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestAutomountMapRead(t *testing.T) {
+	p := newTestProvider(t, map[string]rpcHandler{
+		"automountmap_show": func(t *testing.T, params map[string]any) string {
+			return rpcResult(map[string]any{
+				"value": "auto.home",
+				"result": map[string]any{
+					"automountmapname": []string{"auto.home"},
+				},
+			})
+		},
+		"automountkey_find": func(t *testing.T, params map[string]any) string {
+			if params["automountmapautomountmapname"] != "auto.master" || params["automountinformation"] != "auto.home" {
+				t.Errorf("unexpected parameters %v", params)
+			}
+
+			return rpcResult(map[string]any{
+				"count":     2,
+				"truncated": false,
+				"result": []map[string]any{
+					{
+						"automountkey":         []string{"/home2"},
+						"automountinformation": []string{"auto.home2"},
+					},
+					{
+						"automountkey":         []string{"/export/home"},
+						"automountinformation": []string{"auto.home"},
+					},
+				},
+			})
+		},
+	})
+
+	model := testRead(t, NewAutomountMap(p), AutomountMapModel{
+		Location:    types.StringValue("default"),
+		Name:        types.StringValue("auto.home"),
+		Description: types.StringNull(),
+		MountPoint:  types.StringValue("/home"),
+		ParentMap:   types.StringNull(),
+	})
+
+	if !model.MountPoint.Equal(types.StringValue("/export/home")) {
+		t.Errorf("unexpected mount_point %v", model.MountPoint)
+	}
+}
@@ -0,0 +1,920 @@
+package resources
+
+import (
+	"context"
+	"errors"
+
+	"github.com/camptocamp/go-freeipa/freeipa"
+	"github.com/camptocamp/terraform-provider-freeipa/internal/provider"
+	"github.com/camptocamp/terraform-provider-freeipa/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type CAACL struct {
+	provider *provider.Provider
+}
+
+type CAACLModel struct {
+	Name             types.String `tfsdk:"cn"`
+	Description      types.String `tfsdk:"description"`
+	Enabled          types.Bool   `tfsdk:"enabled"`
+	UserCategory     types.String `tfsdk:"usercategory"`
+	HostCategory     types.String `tfsdk:"hostcategory"`
+	ServiceCategory  types.String `tfsdk:"servicecategory"`
+	CACategory       types.String `tfsdk:"cacategory"`
+	ProfileCategory  types.String `tfsdk:"profilecategory"`
+	MemberUsers      types.Set    `tfsdk:"member_users"`
+	MemberGroups     types.Set    `tfsdk:"member_groups"`
+	MemberHosts      types.Set    `tfsdk:"member_hosts"`
+	MemberHostgroups types.Set    `tfsdk:"member_hostgroups"`
+	MemberServices   types.Set    `tfsdk:"member_services"`
+	MemberProfiles   types.Set    `tfsdk:"member_profiles"`
+	MemberCAs        types.Set    `tfsdk:"member_cas"`
+}
+
+// caACLMembers holds the members of a CA ACL, by member type.
+type caACLMembers struct {
+	users      []string
+	groups     []string
+	hosts      []string
+	hostgroups []string
+	services   []string
+	profiles   []string
+	cas        []string
+}
+
+func (r *CAACL) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ca_acl"
+}
+
+func (r *CAACL) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version: 0,
+		Attributes: map[string]schema.Attribute{
+			"cn": schema.StringAttribute{
+				Description: "ACL name",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Optional: true,
+			},
+			"enabled": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"usercategory": schema.StringAttribute{
+				Description: "User category the ACL applies to (allowed value: all)",
+				Optional:    true,
+			},
+			"hostcategory": schema.StringAttribute{
+				Description: "Host category the ACL applies to (allowed value: all)",
+				Optional:    true,
+			},
+			"servicecategory": schema.StringAttribute{
+				Description: "Service category the ACL applies to (allowed value: all)",
+				Optional:    true,
+			},
+			"cacategory": schema.StringAttribute{
+				Description: "CA category the ACL applies to (allowed value: all)",
+				Optional:    true,
+			},
+			"profilecategory": schema.StringAttribute{
+				Description: "Profile category the ACL applies to (allowed value: all)",
+				Optional:    true,
+			},
+			"member_users": schema.SetAttribute{
+				Description: "Users the ACL applies to",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"member_groups": schema.SetAttribute{
+				Description: "User groups the ACL applies to",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"member_hosts": schema.SetAttribute{
+				Description: "Hosts the ACL applies to",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"member_hostgroups": schema.SetAttribute{
+				Description: "Host groups the ACL applies to",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"member_services": schema.SetAttribute{
+				Description: "Services the ACL applies to",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"member_profiles": schema.SetAttribute{
+				Description: "Certificate profiles the ACL applies to",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"member_cas": schema.SetAttribute{
+				Description: "CAs the ACL applies to",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *CAACL) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config CAACLModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	categories := []struct {
+		name     string
+		category types.String
+		members  map[string]types.Set
+	}{
+		{"usercategory", config.UserCategory, map[string]types.Set{"member_users": config.MemberUsers, "member_groups": config.MemberGroups}},
+		{"hostcategory", config.HostCategory, map[string]types.Set{"member_hosts": config.MemberHosts, "member_hostgroups": config.MemberHostgroups}},
+		{"servicecategory", config.ServiceCategory, map[string]types.Set{"member_services": config.MemberServices}},
+		{"cacategory", config.CACategory, map[string]types.Set{"member_cas": config.MemberCAs}},
+		{"profilecategory", config.ProfileCategory, map[string]types.Set{"member_profiles": config.MemberProfiles}},
+	}
+
+	for _, c := range categories {
+		if c.category.IsNull() || c.category.IsUnknown() {
+			continue
+		}
+
+		if c.category.ValueString() != "all" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(c.name),
+				"Invalid configuration",
+				"“"+c.name+"” only accepts the value “all”.",
+			)
+
+			continue
+		}
+
+		for name, members := range c.members {
+			if !members.IsNull() && (members.IsUnknown() || len(members.Elements()) > 0) {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(name),
+					"Invalid configuration",
+					"“"+name+"” cannot be set when “"+c.name+"” is “all”.",
+				)
+			}
+		}
+	}
+}
+
+func (r *CAACL) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan, state CAACLModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	members, diags := caACLMembersFromModel(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.CaaclAddArgs{
+		Cn: plan.Name.ValueString(),
+	}
+
+	optArgs := &freeipa.CaaclAddOptionalArgs{
+		Description:            plan.Description.ValueStringPointer(),
+		Usercategory:           plan.UserCategory.ValueStringPointer(),
+		Hostcategory:           plan.HostCategory.ValueStringPointer(),
+		Servicecategory:        plan.ServiceCategory.ValueStringPointer(),
+		Ipacacategory:          plan.CACategory.ValueStringPointer(),
+		Ipacertprofilecategory: plan.ProfileCategory.ValueStringPointer(),
+		All:                    freeipa.Bool(true),
+	}
+
+	tflog.Trace(ctx, "Calling CaaclAdd", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	res, err := r.provider.Client().CaaclAdd(args, optArgs)
+
+	tflog.Trace(ctx, "Called CaaclAdd", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create CA ACL", "Reason: "+err.Error())
+		return
+	}
+
+	state = plan
+
+	saveCreated(ctx, resp, state)
+
+	resp.Diagnostics.Append(r.addMembers(ctx, plan.Name.ValueString(), members)...)
+
+	if !plan.Enabled.ValueBool() {
+		resp.Diagnostics.Append(r.setEnabled(ctx, plan.Name.ValueString(), false)...)
+	}
+}
+
+func (r *CAACL) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state CAACLModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	optArgs := &freeipa.CaaclShowOptionalArgs{
+		All: freeipa.Bool(true),
+	}
+
+	tflog.Trace(ctx, "Calling CaaclShow", map[string]any{
+		"args":     state.Name.ValueString(),
+		"opt_args": optArgs,
+	})
+
+	var res rpcEntryResult
+
+	err := r.provider.Call("caacl_show", []any{state.Name.ValueString()}, optArgs, &res)
+	tflog.Trace(ctx, "Called CaaclShow", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if errors.As(err, &freeipaErr) && freeipaErr.Code == freeipa.NotFoundCode {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Failed to read CA ACL", "Reason: "+err.Error())
+		return
+	}
+
+	enabled := entryBool(res.Result, "ipaenabledflag")
+
+	state.Description = types.StringPointerValue(entryString(res.Result, "description"))
+	state.Enabled = types.BoolValue(enabled == nil || *enabled)
+	state.UserCategory = types.StringPointerValue(entryString(res.Result, "usercategory"))
+	state.HostCategory = types.StringPointerValue(entryString(res.Result, "hostcategory"))
+	state.ServiceCategory = types.StringPointerValue(entryString(res.Result, "servicecategory"))
+	state.CACategory = types.StringPointerValue(entryString(res.Result, "ipacacategory"))
+	state.ProfileCategory = types.StringPointerValue(entryString(res.Result, "ipacertprofilecategory"))
+
+	for _, m := range []struct {
+		set  *types.Set
+		name string
+	}{
+		{&state.MemberUsers, "memberuser_user"},
+		{&state.MemberGroups, "memberuser_group"},
+		{&state.MemberHosts, "memberhost_host"},
+		{&state.MemberHostgroups, "memberhost_hostgroup"},
+		{&state.MemberServices, "memberservice_service"},
+		{&state.MemberProfiles, "ipamembercertprofile_certprofile"},
+		{&state.MemberCAs, "ipamemberca_ca"},
+	} {
+		var diags diag.Diagnostics
+
+		members := entryStrings(res.Result, m.name)
+
+		*m.set, diags = membersSetValue(ctx, *m.set, &members)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *CAACL) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state, plan CAACLModel
+	var hasDiff bool
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	actualMembers, diags := caACLMembersFromModel(ctx, state)
+	resp.Diagnostics.Append(diags...)
+
+	desiredMembers, diags := caACLMembersFromModel(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	membersToAdd, membersToRemove := actualMembers.diff(desiredMembers)
+
+	// Remove members first, as they cannot coexist with a category set to
+	// “all”.
+	resp.Diagnostics.Append(r.removeMembers(ctx, plan.Name.ValueString(), membersToRemove)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.CaaclModArgs{
+		Cn: plan.Name.ValueString(),
+	}
+
+	optArgs := &freeipa.CaaclModOptionalArgs{
+		NoMembers: freeipa.Bool(true),
+	}
+
+	if !plan.Description.Equal(state.Description) {
+		optArgs.Description = freeipa.String(plan.Description.ValueString())
+		hasDiff = true
+	}
+
+	if !plan.UserCategory.Equal(state.UserCategory) {
+		optArgs.Usercategory = freeipa.String(plan.UserCategory.ValueString())
+		hasDiff = true
+	}
+
+	if !plan.HostCategory.Equal(state.HostCategory) {
+		optArgs.Hostcategory = freeipa.String(plan.HostCategory.ValueString())
+		hasDiff = true
+	}
+
+	if !plan.ServiceCategory.Equal(state.ServiceCategory) {
+		optArgs.Servicecategory = freeipa.String(plan.ServiceCategory.ValueString())
+		hasDiff = true
+	}
+
+	if !plan.CACategory.Equal(state.CACategory) {
+		optArgs.Ipacacategory = freeipa.String(plan.CACategory.ValueString())
+		hasDiff = true
+	}
+
+	if !plan.ProfileCategory.Equal(state.ProfileCategory) {
+		optArgs.Ipacertprofilecategory = freeipa.String(plan.ProfileCategory.ValueString())
+		hasDiff = true
+	}
+
+	if hasDiff {
+		tflog.Trace(ctx, "Calling CaaclMod", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		res, err := r.provider.Client().CaaclMod(args, optArgs)
+
+		tflog.Trace(ctx, "Called CaaclMod", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		if err != nil {
+			var freeipaErr *freeipa.Error
+
+			if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.EmptyModlistCode {
+				resp.Diagnostics.AddError("Failed to update CA ACL", "Reason: "+err.Error())
+				return
+			}
+		}
+	} else {
+		tflog.Debug(ctx, "Updated CA ACL has no effective difference", map[string]any{
+			"cn": plan.Name.ValueString(),
+		})
+	}
+
+	resp.Diagnostics.Append(r.addMembers(ctx, plan.Name.ValueString(), membersToAdd)...)
+
+	if !plan.Enabled.Equal(state.Enabled) {
+		resp.Diagnostics.Append(r.setEnabled(ctx, plan.Name.ValueString(), plan.Enabled.ValueBool())...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state = plan
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *CAACL) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state CAACLModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.CaaclDelArgs{
+		Cn: []string{state.Name.ValueString()},
+	}
+
+	tflog.Trace(ctx, "Calling CaaclDel", map[string]any{
+		"args":     args,
+		"opt_args": nil,
+	})
+
+	res, err := r.provider.Client().CaaclDel(args, nil)
+
+	tflog.Trace(ctx, "Called CaaclDel", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.NotFoundCode {
+			resp.Diagnostics.AddError("Failed to delete CA ACL", "Reason: "+err.Error())
+			return
+		}
+	}
+}
+
+func (r *CAACL) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	state := CAACLModel{
+		Name:             types.StringValue(req.ID),
+		MemberUsers:      types.SetNull(types.StringType),
+		MemberGroups:     types.SetNull(types.StringType),
+		MemberHosts:      types.SetNull(types.StringType),
+		MemberHostgroups: types.SetNull(types.StringType),
+		MemberServices:   types.SetNull(types.StringType),
+		MemberProfiles:   types.SetNull(types.StringType),
+		MemberCAs:        types.SetNull(types.StringType),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func NewCAACL(p *provider.Provider) resource.Resource {
+	r := &CAACL{
+		provider: p,
+	}
+
+	var _ resource.Resource = r
+	var _ resource.ResourceWithValidateConfig = r
+	var _ resource.ResourceWithImportState = r
+
+	return r
+}
+
+func init() {
+	resources = append(resources, NewCAACL)
+}
+
+func caACLMembersFromModel(ctx context.Context, model CAACLModel) (members caACLMembers, diags diag.Diagnostics) {
+	diags.Append(model.MemberUsers.ElementsAs(ctx, &members.users, false)...)
+	diags.Append(model.MemberGroups.ElementsAs(ctx, &members.groups, false)...)
+	diags.Append(model.MemberHosts.ElementsAs(ctx, &members.hosts, false)...)
+	diags.Append(model.MemberHostgroups.ElementsAs(ctx, &members.hostgroups, false)...)
+	diags.Append(model.MemberServices.ElementsAs(ctx, &members.services, false)...)
+	diags.Append(model.MemberProfiles.ElementsAs(ctx, &members.profiles, false)...)
+	diags.Append(model.MemberCAs.ElementsAs(ctx, &members.cas, false)...)
+
+	return
+}
+
+// diff returns the members to add and to remove to go from the actual
+// members to the desired ones.
+func (actual caACLMembers) diff(desired caACLMembers) (toAdd, toRemove caACLMembers) {
+	toAdd.users, toRemove.users = utils.SetDiff(actual.users, desired.users)
+	toAdd.groups, toRemove.groups = utils.SetDiff(actual.groups, desired.groups)
+	toAdd.hosts, toRemove.hosts = utils.SetDiff(actual.hosts, desired.hosts)
+	toAdd.hostgroups, toRemove.hostgroups = utils.SetDiff(actual.hostgroups, desired.hostgroups)
+	toAdd.services, toRemove.services = utils.SetDiff(actual.services, desired.services)
+	toAdd.profiles, toRemove.profiles = utils.SetDiff(actual.profiles, desired.profiles)
+	toAdd.cas, toRemove.cas = utils.SetDiff(actual.cas, desired.cas)
+
+	return
+}
+
+// addMembers adds members to an ACL. Like the other calls returning the ACL,
+// the members are left out of the result, which the client fails to decode
+// when there are several CAs, profiles or services.
+func (r *CAACL) addMembers(ctx context.Context, cn string, members caACLMembers) (diags diag.Diagnostics) {
+	if len(members.users) > 0 || len(members.groups) > 0 {
+		args := &freeipa.CaaclAddUserArgs{
+			Cn: cn,
+		}
+
+		optArgs := &freeipa.CaaclAddUserOptionalArgs{
+			NoMembers: freeipa.Bool(true),
+		}
+
+		if len(members.users) > 0 {
+			optArgs.User = &members.users
+		}
+
+		if len(members.groups) > 0 {
+			optArgs.Group = &members.groups
+		}
+
+		tflog.Trace(ctx, "Calling CaaclAddUser", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		res, err := r.provider.Client().CaaclAddUser(args, optArgs)
+
+		tflog.Trace(ctx, "Called CaaclAddUser", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		var failed freeipa.FailedOperations
+		if res != nil {
+			failed = res.Failed
+		}
+
+		diags.Append(membershipDiags("Failed to add CA ACL users", failed, err)...)
+	}
+
+	if len(members.hosts) > 0 || len(members.hostgroups) > 0 {
+		args := &freeipa.CaaclAddHostArgs{
+			Cn: cn,
+		}
+
+		optArgs := &freeipa.CaaclAddHostOptionalArgs{
+			NoMembers: freeipa.Bool(true),
+		}
+
+		if len(members.hosts) > 0 {
+			optArgs.Host = &members.hosts
+		}
+
+		if len(members.hostgroups) > 0 {
+			optArgs.Hostgroup = &members.hostgroups
+		}
+
+		tflog.Trace(ctx, "Calling CaaclAddHost", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		res, err := r.provider.Client().CaaclAddHost(args, optArgs)
+
+		tflog.Trace(ctx, "Called CaaclAddHost", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		var failed freeipa.FailedOperations
+		if res != nil {
+			failed = res.Failed
+		}
+
+		diags.Append(membershipDiags("Failed to add CA ACL hosts", failed, err)...)
+	}
+
+	if len(members.services) > 0 {
+		args := &freeipa.CaaclAddServiceArgs{
+			Cn: cn,
+		}
+
+		optArgs := &freeipa.CaaclAddServiceOptionalArgs{
+			NoMembers: freeipa.Bool(true),
+		}
+
+		if len(members.services) > 0 {
+			optArgs.Service = &members.services
+		}
+
+		tflog.Trace(ctx, "Calling CaaclAddService", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		res, err := r.provider.Client().CaaclAddService(args, optArgs)
+
+		tflog.Trace(ctx, "Called CaaclAddService", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		var failed freeipa.FailedOperations
+		if res != nil {
+			failed = res.Failed
+		}
+
+		diags.Append(membershipDiags("Failed to add CA ACL services", failed, err)...)
+	}
+
+	if len(members.profiles) > 0 {
+		args := &freeipa.CaaclAddProfileArgs{
+			Cn: cn,
+		}
+
+		optArgs := &freeipa.CaaclAddProfileOptionalArgs{
+			NoMembers: freeipa.Bool(true),
+		}
+
+		if len(members.profiles) > 0 {
+			optArgs.Certprofile = &members.profiles
+		}
+
+		tflog.Trace(ctx, "Calling CaaclAddProfile", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		res, err := r.provider.Client().CaaclAddProfile(args, optArgs)
+
+		tflog.Trace(ctx, "Called CaaclAddProfile", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		var failed freeipa.FailedOperations
+		if res != nil {
+			failed = res.Failed
+		}
+
+		diags.Append(membershipDiags("Failed to add CA ACL profiles", failed, err)...)
+	}
+
+	if len(members.cas) > 0 {
+		args := &freeipa.CaaclAddCaArgs{
+			Cn: cn,
+		}
+
+		optArgs := &freeipa.CaaclAddCaOptionalArgs{
+			NoMembers: freeipa.Bool(true),
+		}
+
+		if len(members.cas) > 0 {
+			optArgs.Ca = &members.cas
+		}
+
+		tflog.Trace(ctx, "Calling CaaclAddCa", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		res, err := r.provider.Client().CaaclAddCa(args, optArgs)
+
+		tflog.Trace(ctx, "Called CaaclAddCa", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		var failed freeipa.FailedOperations
+		if res != nil {
+			failed = res.Failed
+		}
+
+		diags.Append(membershipDiags("Failed to add CA ACL CAs", failed, err)...)
+	}
+
+	return
+}
+
+func (r *CAACL) removeMembers(ctx context.Context, cn string, members caACLMembers) (diags diag.Diagnostics) {
+	if len(members.users) > 0 || len(members.groups) > 0 {
+		args := &freeipa.CaaclRemoveUserArgs{
+			Cn: cn,
+		}
+
+		optArgs := &freeipa.CaaclRemoveUserOptionalArgs{
+			NoMembers: freeipa.Bool(true),
+		}
+
+		if len(members.users) > 0 {
+			optArgs.User = &members.users
+		}
+
+		if len(members.groups) > 0 {
+			optArgs.Group = &members.groups
+		}
+
+		tflog.Trace(ctx, "Calling CaaclRemoveUser", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		res, err := r.provider.Client().CaaclRemoveUser(args, optArgs)
+
+		tflog.Trace(ctx, "Called CaaclRemoveUser", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		var failed freeipa.FailedOperations
+		if res != nil {
+			failed = res.Failed
+		}
+
+		diags.Append(membershipDiags("Failed to remove CA ACL users", failed, err)...)
+	}
+
+	if len(members.hosts) > 0 || len(members.hostgroups) > 0 {
+		args := &freeipa.CaaclRemoveHostArgs{
+			Cn: cn,
+		}
+
+		optArgs := &freeipa.CaaclRemoveHostOptionalArgs{
+			NoMembers: freeipa.Bool(true),
+		}
+
+		if len(members.hosts) > 0 {
+			optArgs.Host = &members.hosts
+		}
+
+		if len(members.hostgroups) > 0 {
+			optArgs.Hostgroup = &members.hostgroups
+		}
+
+		tflog.Trace(ctx, "Calling CaaclRemoveHost", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		res, err := r.provider.Client().CaaclRemoveHost(args, optArgs)
+
+		tflog.Trace(ctx, "Called CaaclRemoveHost", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		var failed freeipa.FailedOperations
+		if res != nil {
+			failed = res.Failed
+		}
+
+		diags.Append(membershipDiags("Failed to remove CA ACL hosts", failed, err)...)
+	}
+
+	if len(members.services) > 0 {
+		args := &freeipa.CaaclRemoveServiceArgs{
+			Cn: cn,
+		}
+
+		optArgs := &freeipa.CaaclRemoveServiceOptionalArgs{
+			NoMembers: freeipa.Bool(true),
+		}
+
+		if len(members.services) > 0 {
+			optArgs.Service = &members.services
+		}
+
+		tflog.Trace(ctx, "Calling CaaclRemoveService", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		res, err := r.provider.Client().CaaclRemoveService(args, optArgs)
+
+		tflog.Trace(ctx, "Called CaaclRemoveService", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		var failed freeipa.FailedOperations
+		if res != nil {
+			failed = res.Failed
+		}
+
+		diags.Append(membershipDiags("Failed to remove CA ACL services", failed, err)...)
+	}
+
+	if len(members.profiles) > 0 {
+		args := &freeipa.CaaclRemoveProfileArgs{
+			Cn: cn,
+		}
+
+		optArgs := &freeipa.CaaclRemoveProfileOptionalArgs{
+			NoMembers: freeipa.Bool(true),
+		}
+
+		if len(members.profiles) > 0 {
+			optArgs.Certprofile = &members.profiles
+		}
+
+		tflog.Trace(ctx, "Calling CaaclRemoveProfile", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		res, err := r.provider.Client().CaaclRemoveProfile(args, optArgs)
+
+		tflog.Trace(ctx, "Called CaaclRemoveProfile", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		var failed freeipa.FailedOperations
+		if res != nil {
+			failed = res.Failed
+		}
+
+		diags.Append(membershipDiags("Failed to remove CA ACL profiles", failed, err)...)
+	}
+
+	if len(members.cas) > 0 {
+		args := &freeipa.CaaclRemoveCaArgs{
+			Cn: cn,
+		}
+
+		optArgs := &freeipa.CaaclRemoveCaOptionalArgs{
+			NoMembers: freeipa.Bool(true),
+		}
+
+		if len(members.cas) > 0 {
+			optArgs.Ca = &members.cas
+		}
+
+		tflog.Trace(ctx, "Calling CaaclRemoveCa", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		res, err := r.provider.Client().CaaclRemoveCa(args, optArgs)
+
+		tflog.Trace(ctx, "Called CaaclRemoveCa", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		var failed freeipa.FailedOperations
+		if res != nil {
+			failed = res.Failed
+		}
+
+		diags.Append(membershipDiags("Failed to remove CA ACL CAs", failed, err)...)
+	}
+
+	return
+}
+
+func (r *CAACL) setEnabled(ctx context.Context, cn string, enabled bool) (diags diag.Diagnostics) {
+	var err error
+
+	if enabled {
+		args := &freeipa.CaaclEnableArgs{
+			Cn: cn,
+		}
+
+		tflog.Trace(ctx, "Calling CaaclEnable", map[string]any{
+			"args":     args,
+			"opt_args": nil,
+		})
+
+		var res *freeipa.CaaclEnableResult
+
+		res, err = r.provider.Client().CaaclEnable(args, nil)
+
+		tflog.Trace(ctx, "Called CaaclEnable", map[string]any{
+			"res": res,
+			"err": err,
+		})
+	} else {
+		args := &freeipa.CaaclDisableArgs{
+			Cn: cn,
+		}
+
+		tflog.Trace(ctx, "Calling CaaclDisable", map[string]any{
+			"args":     args,
+			"opt_args": nil,
+		})
+
+		var res *freeipa.CaaclDisableResult
+
+		res, err = r.provider.Client().CaaclDisable(args, nil)
+
+		tflog.Trace(ctx, "Called CaaclDisable", map[string]any{
+			"res": res,
+			"err": err,
+		})
+	}
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if !errors.As(err, &freeipaErr) || (freeipaErr.Code != freeipa.AlreadyActiveCode && freeipaErr.Code != freeipa.AlreadyInactiveCode) {
+			diags.AddError("Failed to enable or disable CA ACL", "Reason: "+err.Error())
+		}
+	}
+
+	return
+}
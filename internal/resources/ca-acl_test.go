@@ -0,0 +1,49 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCAACLRead(t *testing.T) {
+	p := newTestProvider(t, map[string]rpcHandler{
+		"caacl_show": func(t *testing.T, params map[string]any) string {
+			// Several services and profiles, which the client fails to
+			// decode.
+			return rpcResult(map[string]any{
+				"value": "web",
+				"result": map[string]any{
+					"cn":                               []string{"web"},
+					"ipaenabledflag":                   []string{"FALSE"},
+					"memberservice_service":            []string{"HTTP/a.example.test@EXAMPLE.TEST", "HTTP/b.example.test@EXAMPLE.TEST"},
+					"ipamembercertprofile_certprofile": []string{"caIPAserviceCert", "webServer"},
+				},
+			})
+		},
+	})
+
+	ctx := context.Background()
+
+	profiles, _ := types.SetValueFrom(ctx, types.StringType, []string{"caIPAserviceCert"})
+
+	model := testRead(t, NewCAACL(p), CAACLModel{
+		Name:             types.StringValue("web"),
+		Enabled:          types.BoolValue(true),
+		MemberUsers:      types.SetNull(types.StringType),
+		MemberGroups:     types.SetNull(types.StringType),
+		MemberHosts:      types.SetNull(types.StringType),
+		MemberHostgroups: types.SetNull(types.StringType),
+		MemberServices:   types.SetNull(types.StringType),
+		MemberProfiles:   profiles,
+		MemberCAs:        types.SetNull(types.StringType),
+	})
+
+	services, _ := types.SetValueFrom(ctx, types.StringType, []string{"HTTP/a.example.test@EXAMPLE.TEST", "HTTP/b.example.test@EXAMPLE.TEST"})
+	profiles, _ = types.SetValueFrom(ctx, types.StringType, []string{"caIPAserviceCert", "webServer"})
+
+	if !model.MemberServices.Equal(services) || !model.MemberProfiles.Equal(profiles) || model.Enabled.ValueBool() {
+		t.Errorf("unexpected ACL %v", *model)
+	}
+}
@@ -0,0 +1,423 @@
+package resources
+
+import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"net/url"
+
+	"github.com/camptocamp/go-freeipa/freeipa"
+	"github.com/camptocamp/terraform-provider-freeipa/internal/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// ipaCAName is the name of the main IPA CA, which issues the certificates
+// of all lightweight sub-CAs.
+const ipaCAName = "ipa"
+
+type CA struct {
+	provider *provider.Provider
+}
+
+type CAModel struct {
+	Name        types.String `tfsdk:"cn"`
+	SubjectDN   types.String `tfsdk:"subject_dn"`
+	Description types.String `tfsdk:"description"`
+	Enabled     types.Bool   `tfsdk:"enabled"`
+	IssuerDN    types.String `tfsdk:"issuer_dn"`
+	Certificate types.String `tfsdk:"certificate"`
+	Chain       types.String `tfsdk:"chain"`
+}
+
+func (r *CA) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ca"
+}
+
+func (r *CA) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version: 0,
+		Attributes: map[string]schema.Attribute{
+			"cn": schema.StringAttribute{
+				Description: "Name of the lightweight sub-CA",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"subject_dn": schema.StringAttribute{
+				Description: "Subject distinguished name (e.g. CN=VPN CA,O=EXAMPLE.COM)",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Optional: true,
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Whether the CA can issue certificates",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"issuer_dn": schema.StringAttribute{
+				Description: "Issuer distinguished name",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"certificate": schema.StringAttribute{
+				Description: "CA certificate in PEM format",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"chain": schema.StringAttribute{
+				Description: "CA certificate chain up to the IPA CA in PEM format",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *CA) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan, state CAModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.CaAddArgs{
+		Cn:             plan.Name.ValueString(),
+		Ipacasubjectdn: plan.SubjectDN.ValueString(),
+	}
+
+	optArgs := &freeipa.CaAddOptionalArgs{
+		Description: plan.Description.ValueStringPointer(),
+	}
+
+	tflog.Trace(ctx, "Calling CaAdd", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	res, err := r.provider.Client().CaAdd(args, optArgs)
+
+	tflog.Trace(ctx, "Called CaAdd", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create CA", "Reason: "+err.Error())
+		return
+	}
+
+	state = plan
+
+	if err := r.setCertificates(ctx, &state, &res.Result); err != nil {
+		resp.Diagnostics.AddError("Failed to read CA certificates", "Reason: "+err.Error())
+	}
+
+	saveCreated(ctx, resp, state)
+
+	if !plan.Enabled.ValueBool() {
+		if err := r.setEnabled(ctx, plan.Name.ValueString(), false); err != nil {
+			resp.Diagnostics.AddError("Failed to disable CA", "Reason: "+err.Error())
+		}
+	}
+}
+
+func (r *CA) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state CAModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	res, err := r.show(ctx, state.Name.ValueString())
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if errors.As(err, &freeipaErr) && freeipaErr.Code == freeipa.NotFoundCode {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Failed to read CA", "Reason: "+err.Error())
+		return
+	}
+
+	state.SubjectDN = types.StringValue(res.Result.Ipacasubjectdn)
+	state.Description = types.StringPointerValue(res.Result.Description)
+
+	// FreeIPA does not report whether a CA is enabled, the Dogtag authority
+	// of the CA does.
+	var authority struct {
+		Enabled bool `json:"enabled"`
+	}
+
+	authorityPath := "/ca/rest/authorities/" + url.PathEscape(res.Result.Ipacaid)
+
+	tflog.Trace(ctx, "Calling GET "+authorityPath)
+
+	err = r.provider.Get(authorityPath, &authority)
+
+	tflog.Trace(ctx, "Called GET "+authorityPath, map[string]any{
+		"res": authority,
+		"err": err,
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read CA", "Reason: "+err.Error())
+		return
+	}
+
+	state.Enabled = types.BoolValue(authority.Enabled)
+
+	if err := r.setCertificates(ctx, &state, &res.Result); err != nil {
+		resp.Diagnostics.AddError("Failed to read CA certificates", "Reason: "+err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *CA) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state, plan CAModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Description.Equal(state.Description) {
+		args := &freeipa.CaModArgs{
+			Cn: plan.Name.ValueString(),
+		}
+
+		optArgs := &freeipa.CaModOptionalArgs{
+			Description: freeipa.String(plan.Description.ValueString()),
+		}
+
+		tflog.Trace(ctx, "Calling CaMod", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		res, err := r.provider.Client().CaMod(args, optArgs)
+
+		tflog.Trace(ctx, "Called CaMod", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		if err != nil {
+			var freeipaErr *freeipa.Error
+
+			if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.EmptyModlistCode {
+				resp.Diagnostics.AddError("Failed to update CA", "Reason: "+err.Error())
+				return
+			}
+		}
+	}
+
+	if !plan.Enabled.Equal(state.Enabled) {
+		if err := r.setEnabled(ctx, plan.Name.ValueString(), plan.Enabled.ValueBool()); err != nil {
+			resp.Diagnostics.AddError("Failed to enable or disable CA", "Reason: "+err.Error())
+			return
+		}
+	}
+
+	plan.IssuerDN = state.IssuerDN
+	plan.Certificate = state.Certificate
+	plan.Chain = state.Chain
+	state = plan
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *CA) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state CAModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only disabled CAs can be deleted.
+	if err := r.setEnabled(ctx, state.Name.ValueString(), false); err != nil {
+		var freeipaErr *freeipa.Error
+
+		if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.NotFoundCode {
+			resp.Diagnostics.AddError("Failed to disable CA", "Reason: "+err.Error())
+			return
+		}
+	}
+
+	args := &freeipa.CaDelArgs{
+		Cn: []string{state.Name.ValueString()},
+	}
+
+	tflog.Trace(ctx, "Calling CaDel", map[string]any{
+		"args":     args,
+		"opt_args": nil,
+	})
+
+	res, err := r.provider.Client().CaDel(args, nil)
+
+	tflog.Trace(ctx, "Called CaDel", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.NotFoundCode {
+			resp.Diagnostics.AddError("Failed to delete CA", "Reason: "+err.Error())
+			return
+		}
+	}
+}
+
+func (r *CA) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	state := CAModel{
+		Name: types.StringValue(req.ID),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func NewCA(p *provider.Provider) resource.Resource {
+	r := &CA{
+		provider: p,
+	}
+
+	var _ resource.Resource = r
+	var _ resource.ResourceWithImportState = r
+
+	return r
+}
+
+func init() {
+	resources = append(resources, NewCA)
+}
+
+// show returns a CA along with its certificate. The certificate chain is not
+// requested, as the client cannot decode it.
+func (r *CA) show(ctx context.Context, cn string) (*freeipa.CaShowResult, error) {
+	args := &freeipa.CaShowArgs{
+		Cn: cn,
+	}
+
+	tflog.Trace(ctx, "Calling CaShow", map[string]any{
+		"args":     args,
+		"opt_args": nil,
+	})
+
+	res, err := r.provider.Client().CaShow(args, nil)
+
+	tflog.Trace(ctx, "Called CaShow", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	return res, err
+}
+
+// setCertificates sets the issuer, certificate and chain of a CA, the chain
+// being made of its certificate followed by the IPA CA one.
+func (r *CA) setCertificates(ctx context.Context, m *CAModel, ca *freeipa.Ca) error {
+	der, err := decodeCertificate(ca.Certificate)
+	if err != nil {
+		return err
+	}
+
+	certificate := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	chain := certificate
+
+	if ca.Cn != ipaCAName {
+		res, err := r.show(ctx, ipaCAName)
+		if err != nil {
+			return err
+		}
+
+		der, err := decodeCertificate(res.Result.Certificate)
+		if err != nil {
+			return err
+		}
+
+		chain += string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	}
+
+	m.IssuerDN = types.StringValue(ca.Ipacaissuerdn)
+	m.Certificate = types.StringValue(certificate)
+	m.Chain = types.StringValue(chain)
+
+	return nil
+}
+
+// setEnabled enables or disables a CA, ignoring CAs already in the desired
+// state.
+func (r *CA) setEnabled(ctx context.Context, cn string, enabled bool) (err error) {
+	if enabled {
+		args := &freeipa.CaEnableArgs{
+			Cn: cn,
+		}
+
+		tflog.Trace(ctx, "Calling CaEnable", map[string]any{
+			"args":     args,
+			"opt_args": nil,
+		})
+
+		var res *freeipa.CaEnableResult
+
+		res, err = r.provider.Client().CaEnable(args, nil)
+
+		tflog.Trace(ctx, "Called CaEnable", map[string]any{
+			"res": res,
+			"err": err,
+		})
+	} else {
+		args := &freeipa.CaDisableArgs{
+			Cn: cn,
+		}
+
+		tflog.Trace(ctx, "Calling CaDisable", map[string]any{
+			"args":     args,
+			"opt_args": nil,
+		})
+
+		var res *freeipa.CaDisableResult
+
+		res, err = r.provider.Client().CaDisable(args, nil)
+
+		tflog.Trace(ctx, "Called CaDisable", map[string]any{
+			"res": res,
+			"err": err,
+		})
+	}
+
+	var freeipaErr *freeipa.Error
+
+	if errors.As(err, &freeipaErr) && (freeipaErr.Code == freeipa.AlreadyActiveCode || freeipaErr.Code == freeipa.AlreadyInactiveCode) {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,50 @@
+package resources
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCARead(t *testing.T) {
+	now := time.Now()
+	der := testCertificateDER(t, now.Add(-time.Hour), now.Add(24*time.Hour))
+
+	p := newTestProvider(t, map[string]rpcHandler{
+		"ca_show": func(t *testing.T, params map[string]any) string {
+			if params["cn"] != "ipa" {
+				t.Errorf("unexpected CA %v", params["cn"])
+			}
+
+			return rpcResult(map[string]any{
+				"value": "ipa",
+				"result": map[string]any{
+					"cn":             []string{"ipa"},
+					"ipacaid":        "3f9bd2a8-3dfa-4e3a-9a61-4b8ee5e7c1d0",
+					"ipacasubjectdn": []string{"CN=Certificate Authority,O=EXAMPLE.TEST"},
+					"ipacaissuerdn":  []string{"CN=Certificate Authority,O=EXAMPLE.TEST"},
+					"certificate":    base64.StdEncoding.EncodeToString(der),
+				},
+			})
+		},
+		"/ca/rest/authorities/3f9bd2a8-3dfa-4e3a-9a61-4b8ee5e7c1d0": func(t *testing.T, params map[string]any) string {
+			return `{"id": "3f9bd2a8-3dfa-4e3a-9a61-4b8ee5e7c1d0", "isHostAuthority": true, "enabled": false, "ready": true}`
+		},
+	})
+
+	model := testRead(t, NewCA(p), CAModel{
+		Name:        types.StringValue("ipa"),
+		SubjectDN:   types.StringValue("CN=Certificate Authority,O=EXAMPLE.TEST"),
+		Description: types.StringNull(),
+		Enabled:     types.BoolValue(true),
+		IssuerDN:    types.StringNull(),
+		Certificate: types.StringNull(),
+		Chain:       types.StringNull(),
+	})
+
+	if !model.Enabled.Equal(types.BoolValue(false)) {
+		t.Errorf("unexpected enabled %v", model.Enabled)
+	}
+}
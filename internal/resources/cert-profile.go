@@ -0,0 +1,273 @@
+package resources
+
+import (
+	"context"
+	"errors"
+
+	"github.com/camptocamp/go-freeipa/freeipa"
+	"github.com/camptocamp/terraform-provider-freeipa/internal/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type CertProfile struct {
+	provider *provider.Provider
+}
+
+type CertProfileModel struct {
+	Name        types.String `tfsdk:"cn"`
+	Description types.String `tfsdk:"description"`
+	Config      types.String `tfsdk:"config"`
+	Store       types.Bool   `tfsdk:"store"`
+}
+
+func (r *CertProfile) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cert_profile"
+}
+
+func (r *CertProfile) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version: 0,
+		Attributes: map[string]schema.Attribute{
+			"cn": schema.StringAttribute{
+				Description: "Profile ID",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Required: true,
+			},
+			"config": schema.StringAttribute{
+				Description: "Raw profile configuration (e.g. loaded with “file()”), the XML format is not supported",
+				Required:    true,
+			},
+			"store": schema.BoolAttribute{
+				Description: "Whether to store certificates issued using this profile",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+		},
+	}
+}
+
+func (r *CertProfile) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan, state CertProfileModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.CertprofileImportArgs{
+		Cn:          plan.Name.ValueString(),
+		Description: plan.Description.ValueString(),
+		File:        plan.Config.ValueString(),
+	}
+
+	optArgs := &freeipa.CertprofileImportOptionalArgs{
+		Ipacertprofilestoreissued: plan.Store.ValueBoolPointer(),
+	}
+
+	tflog.Trace(ctx, "Calling CertprofileImport", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	res, err := r.provider.Client().CertprofileImport(args, optArgs)
+
+	tflog.Trace(ctx, "Called CertprofileImport", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create certificate profile", "Reason: "+err.Error())
+		return
+	}
+
+	state = plan
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *CertProfile) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state CertProfileModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.CertprofileShowArgs{
+		Cn: state.Name.ValueString(),
+	}
+
+	// The profile configuration is only returned when an output file is
+	// requested, the file itself is only written by the ipa command line.
+	optArgs := &freeipa.CertprofileShowOptionalArgs{
+		Out: freeipa.String(state.Name.ValueString() + ".cfg"),
+		All: freeipa.Bool(true),
+	}
+
+	tflog.Trace(ctx, "Calling CertprofileShow", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	res, err := r.provider.Client().CertprofileShow(args, optArgs)
+
+	tflog.Trace(ctx, "Called CertprofileShow", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if errors.As(err, &freeipaErr) && freeipaErr.Code == freeipa.NotFoundCode {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Failed to read certificate profile", "Reason: "+err.Error())
+		return
+	}
+
+	state.Description = types.StringValue(res.Result.Description)
+	state.Store = types.BoolPointerValue(res.Result.Ipacertprofilestoreissued)
+
+	// Dogtag normalises the stored configuration, only use it on import.
+	if state.Config.IsNull() {
+		state.Config = types.StringValue(res.Result.Config)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *CertProfile) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state, plan CertProfileModel
+	var hasDiff bool
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.CertprofileModArgs{
+		Cn: plan.Name.ValueString(),
+	}
+
+	optArgs := &freeipa.CertprofileModOptionalArgs{}
+
+	if !plan.Description.Equal(state.Description) {
+		optArgs.Description = plan.Description.ValueStringPointer()
+		hasDiff = true
+	}
+
+	if !plan.Store.Equal(state.Store) {
+		optArgs.Ipacertprofilestoreissued = plan.Store.ValueBoolPointer()
+		hasDiff = true
+	}
+
+	if !plan.Config.Equal(state.Config) {
+		optArgs.File = plan.Config.ValueStringPointer()
+		hasDiff = true
+	}
+
+	if hasDiff {
+		tflog.Trace(ctx, "Calling CertprofileMod", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		res, err := r.provider.Client().CertprofileMod(args, optArgs)
+
+		tflog.Trace(ctx, "Called CertprofileMod", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		if err != nil {
+			var freeipaErr *freeipa.Error
+
+			if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.EmptyModlistCode {
+				resp.Diagnostics.AddError("Failed to update certificate profile", "Reason: "+err.Error())
+				return
+			}
+		}
+	} else {
+		tflog.Debug(ctx, "Updated certificate profile has no effective difference", map[string]any{
+			"cn": plan.Name.ValueString(),
+		})
+	}
+
+	state = plan
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *CertProfile) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state CertProfileModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.CertprofileDelArgs{
+		Cn: []string{state.Name.ValueString()},
+	}
+
+	tflog.Trace(ctx, "Calling CertprofileDel", map[string]any{
+		"args":     args,
+		"opt_args": nil,
+	})
+
+	res, err := r.provider.Client().CertprofileDel(args, nil)
+
+	tflog.Trace(ctx, "Called CertprofileDel", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.NotFoundCode {
+			resp.Diagnostics.AddError("Failed to delete certificate profile", "Reason: "+err.Error())
+			return
+		}
+	}
+}
+
+func (r *CertProfile) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	state := CertProfileModel{
+		Name: types.StringValue(req.ID),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func NewCertProfile(p *provider.Provider) resource.Resource {
+	r := &CertProfile{
+		provider: p,
+	}
+
+	var _ resource.Resource = r
+	var _ resource.ResourceWithImportState = r
+
+	return r
+}
+
+func init() {
+	resources = append(resources, NewCertProfile)
+}
@@ -0,0 +1,142 @@
+package resources
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	defaultRSAKeySize   = 2048
+	defaultECDSAKeySize = 256
+)
+
+type CertificateSubjectModel struct {
+	CommonName         types.String `tfsdk:"common_name"`
+	Organization       types.String `tfsdk:"organization"`
+	OrganizationalUnit types.String `tfsdk:"organizational_unit"`
+	Locality           types.String `tfsdk:"locality"`
+	Province           types.String `tfsdk:"province"`
+	Country            types.String `tfsdk:"country"`
+}
+
+// generatePrivateKey generates a private key of the given algorithm and
+// size, returning it along with its PKCS #8 PEM encoding.
+func generatePrivateKey(algorithm string, size int64) (crypto.Signer, string, error) {
+	var key crypto.Signer
+	var err error
+
+	switch algorithm {
+	case "RSA":
+		if size == 0 {
+			size = defaultRSAKeySize
+		}
+
+		key, err = rsa.GenerateKey(rand.Reader, int(size))
+	case "ECDSA":
+		var curve elliptic.Curve
+
+		if size == 0 {
+			size = defaultECDSAKeySize
+		}
+
+		switch size {
+		case 256:
+			curve = elliptic.P256()
+		case 384:
+			curve = elliptic.P384()
+		case 521:
+			curve = elliptic.P521()
+		default:
+			return nil, "", fmt.Errorf("unsupported ECDSA key size %d", size)
+		}
+
+		key, err = ecdsa.GenerateKey(curve, rand.Reader)
+	default:
+		return nil, "", fmt.Errorf("unsupported key algorithm “%s”", algorithm)
+	}
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return key, string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), nil
+}
+
+// generateCSR returns a PEM encoded certificate signing request for the
+// given principal. The subject common name and the DNS names default to the
+// hostname of the principal.
+func generateCSR(key crypto.Signer, principal string, subject *CertificateSubjectModel, dnsNames []string) (string, error) {
+	hostname := principalHostname(principal)
+
+	var name pkix.Name
+
+	if subject != nil {
+		name.CommonName = subject.CommonName.ValueString()
+
+		if v := subject.Organization.ValueString(); v != "" {
+			name.Organization = []string{v}
+		}
+
+		if v := subject.OrganizationalUnit.ValueString(); v != "" {
+			name.OrganizationalUnit = []string{v}
+		}
+
+		if v := subject.Locality.ValueString(); v != "" {
+			name.Locality = []string{v}
+		}
+
+		if v := subject.Province.ValueString(); v != "" {
+			name.Province = []string{v}
+		}
+
+		if v := subject.Country.ValueString(); v != "" {
+			name.Country = []string{v}
+		}
+	}
+
+	if name.CommonName == "" {
+		name.CommonName = hostname
+	}
+
+	if dnsNames == nil && strings.Contains(principal, "/") {
+		dnsNames = []string{hostname}
+	}
+
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  name,
+		DNSNames: dnsNames,
+	}, key)
+	if err != nil {
+		return "", err
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der})), nil
+}
+
+// principalHostname returns the hostname part of a service or host principal
+// (e.g. “test.example.com” for “HTTP/test.example.com@EXAMPLE.COM”), or the
+// principal name for user principals.
+func principalHostname(principal string) string {
+	name, _, _ := strings.Cut(principal, "@")
+
+	if _, hostname, ok := strings.Cut(name, "/"); ok {
+		return hostname
+	}
+
+	return name
+}
@@ -2,27 +2,76 @@ package resources
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
 	"errors"
-	"strconv"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/camptocamp/go-freeipa/freeipa"
 	"github.com/camptocamp/terraform-provider-freeipa/internal/provider"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// Revocation reasons as defined by RFC 5280 section 5.3.1, “removeFromCRL”
+// (8) is only meaningful in CRLs and cannot be used to revoke a certificate.
+var certificateRevocationReasons = map[string]int{
+	"unspecified":            0,
+	"key_compromise":         1,
+	"ca_compromise":          2,
+	"affiliation_changed":    3,
+	"superseded":             4,
+	"cessation_of_operation": 5,
+	"certificate_hold":       6,
+	"privilege_withdrawn":    9,
+	"aa_compromise":          10,
+}
+
+// certificateNow returns the current time, it is overridden by tests.
+var certificateNow = time.Now
+
 type Certificate struct {
 	provider *provider.Provider
 }
 
 type CertificateModel struct {
-	Principal    types.String `tfsdk:"principal"`
-	CSR          types.String `tfsdk:"csr"`
-	SerialNumber types.Int64  `tfsdk:"serial_number"`
+	Principal         types.String             `tfsdk:"principal"`
+	CSR               types.String             `tfsdk:"csr"`
+	KeyAlgorithm      types.String             `tfsdk:"key_algorithm"`
+	KeySize           types.Int64              `tfsdk:"key_size"`
+	Subject           *CertificateSubjectModel `tfsdk:"subject"`
+	DNSNames          types.Set                `tfsdk:"dns_names"`
+	PrivateKeyPEM     types.String             `tfsdk:"private_key_pem"`
+	ProfileID         types.String             `tfsdk:"profile_id"`
+	CACN              types.String             `tfsdk:"cacn"`
+	AddPrincipal      types.Bool               `tfsdk:"add_principal"`
+	RevocationReason  types.String             `tfsdk:"revocation_reason"`
+	RevokeOnDestroy   types.Bool               `tfsdk:"revoke_on_destroy"`
+	EarlyRenewalHours types.Int64              `tfsdk:"early_renewal_hours"`
+	SerialNumber      types.String             `tfsdk:"serial_number"`
+	Certificate       types.String             `tfsdk:"certificate"`
+	Chain             types.String             `tfsdk:"chain"`
+	NotBefore         types.String             `tfsdk:"not_before"`
+	NotAfter          types.String             `tfsdk:"not_after"`
+	Fingerprint       types.String             `tfsdk:"fingerprint"`
+	ReadyForRenewal   types.Bool               `tfsdk:"ready_for_renewal"`
 }
 
 func (r *Certificate) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -41,18 +90,252 @@ func (r *Certificate) Schema(ctx context.Context, req resource.SchemaRequest, re
 				},
 			},
 			"csr": schema.StringAttribute{
-				Required: true,
+				Description: "Certificate signing request in PEM format, generated along with a private key when “key_algorithm” is set",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key_algorithm": schema.StringAttribute{
+				Description: "Algorithm of the private key to generate along with the certificate signing request, one of: RSA, ECDSA",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key_size": schema.Int64Attribute{
+				Description: "Size of the private key to generate: number of bits for RSA keys (defaults to 2048), curve size for ECDSA keys (256, 384 or 521, defaults to 256)",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"dns_names": schema.SetAttribute{
+				Description: "DNS names of the generated certificate signing request (defaults to the hostname of the principal)",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"private_key_pem": schema.StringAttribute{
+				Description: "Generated private key in PKCS #8 PEM format",
+				Computed:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"profile_id": schema.StringAttribute{
+				Description: "Certificate profile to use (defaults to the server default profile, usually “caIPAserviceCert”)",
+				Optional:    true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"cacn": schema.StringAttribute{
+				Description: "Name of the issuing CA (defaults to the IPA CA)",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"add_principal": schema.BoolAttribute{
+				Description: "Automatically add the principal if it does not exist (service principals only)",
+				Optional:    true,
+			},
+			"revocation_reason": schema.StringAttribute{
+				Description: "Reason used to revoke the certificate on destroy, one of: " + strings.Join(certificateRevocationReasonNames(), ", ") + " (defaults to “unspecified”)",
+				Optional:    true,
+			},
+			"revoke_on_destroy": schema.BoolAttribute{
+				Description: "Revoke the certificate when the resource is destroyed",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"early_renewal_hours": schema.Int64Attribute{
+				Description: "Number of hours before the certificate expiry from which the certificate is replaced by a new one",
+				Optional:    true,
+			},
 			"serial_number": schema.StringAttribute{
 				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"certificate": schema.StringAttribute{
+				Description: "Certificate in PEM format",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"chain": schema.StringAttribute{
+				Description: "Certificate chain of the issuing CA in PEM format",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"not_before": schema.StringAttribute{
+				Description: "Start of the certificate validity period (RFC3339 format)",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"not_after": schema.StringAttribute{
+				Description: "End of the certificate validity period (RFC3339 format)",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"fingerprint": schema.StringAttribute{
+				Description: "SHA-256 fingerprint of the certificate",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ready_for_renewal": schema.BoolAttribute{
+				Description: "Whether the certificate has expired or is within its early renewal period, and will be replaced",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"subject": schema.SingleNestedBlock{
+				Description: "Subject of the generated certificate signing request, the common name defaults to the hostname of the principal",
+				Attributes: map[string]schema.Attribute{
+					"common_name": schema.StringAttribute{
+						Optional: true,
+					},
+					"organization": schema.StringAttribute{
+						Optional: true,
+					},
+					"organizational_unit": schema.StringAttribute{
+						Optional: true,
+					},
+					"locality": schema.StringAttribute{
+						Optional: true,
+					},
+					"province": schema.StringAttribute{
+						Optional: true,
+					},
+					"country": schema.StringAttribute{
+						Optional: true,
+					},
+				},
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
 			},
 		},
 	}
 }
 
+func (r *Certificate) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config CertificateModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.KeyAlgorithm.IsNull() {
+		if config.CSR.IsNull() {
+			resp.Diagnostics.AddError(
+				"Invalid configuration",
+				`Either “csr” or “key_algorithm” must be set.`,
+			)
+		}
+
+		if !config.KeySize.IsNull() || config.Subject != nil || !config.DNSNames.IsNull() {
+			resp.Diagnostics.AddError(
+				"Invalid configuration",
+				`“key_size”, “subject” and “dns_names” must not be set when “key_algorithm” is not set.`,
+			)
+		}
+	} else {
+		if !config.CSR.IsNull() {
+			resp.Diagnostics.AddError(
+				"Invalid configuration",
+				`“csr” must not be set when “key_algorithm” is set.`,
+			)
+		}
+
+		if v := config.KeyAlgorithm.ValueString(); !config.KeyAlgorithm.IsUnknown() && v != "RSA" && v != "ECDSA" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("key_algorithm"),
+				"Invalid configuration",
+				`“key_algorithm” must be one of: RSA, ECDSA.`,
+			)
+		}
+	}
+
+	if config.RevocationReason.IsNull() || config.RevocationReason.IsUnknown() {
+		return
+	}
+
+	if _, ok := certificateRevocationReasons[config.RevocationReason.ValueString()]; !ok {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("revocation_reason"),
+			"Invalid configuration",
+			fmt.Sprintf("“revocation_reason” must be one of: %s.", strings.Join(certificateRevocationReasonNames(), ", ")),
+		)
+	}
+}
+
+func (r *Certificate) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var state, plan CertificateModel
+
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Expired certificates are replaced even without early renewal
+	if plan.EarlyRenewalHours.IsUnknown() || state.NotAfter.IsNull() {
+		return
+	}
+
+	notAfter, err := time.Parse(time.RFC3339, state.NotAfter.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid certificate state", "Reason: "+err.Error())
+
+		return
+	}
+
+	renewalTime := notAfter.Add(-time.Duration(plan.EarlyRenewalHours.ValueInt64()) * time.Hour)
+
+	if certificateNow().Before(renewalTime) {
+		return
+	}
+
+	tflog.Info(ctx, "Certificate is ready for early renewal", map[string]any{
+		"serial_number": state.SerialNumber.ValueString(),
+		"not_after":     state.NotAfter.ValueString(),
+	})
+
+	readyForRenewal := path.Root("ready_for_renewal")
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, readyForRenewal, types.BoolUnknown())...)
+	resp.RequiresReplace = append(resp.RequiresReplace, readyForRenewal)
+}
+
 func (r *Certificate) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan, state CertificateModel
 
@@ -61,13 +344,45 @@ func (r *Certificate) Create(ctx context.Context, req resource.CreateRequest, re
 		return
 	}
 
+	if !plan.KeyAlgorithm.IsNull() {
+		var dnsNames []string
+
+		if !plan.DNSNames.IsNull() {
+			resp.Diagnostics.Append(plan.DNSNames.ElementsAs(ctx, &dnsNames, false)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+
+		key, keyPEM, err := generatePrivateKey(plan.KeyAlgorithm.ValueString(), plan.KeySize.ValueInt64())
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to generate private key", "Reason: "+err.Error())
+			return
+		}
+
+		csr, err := generateCSR(key, plan.Principal.ValueString(), plan.Subject, dnsNames)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to generate certificate signing request", "Reason: "+err.Error())
+			return
+		}
+
+		plan.CSR = types.StringValue(csr)
+		plan.PrivateKeyPEM = types.StringValue(keyPEM)
+	} else {
+		plan.PrivateKeyPEM = types.StringNull()
+	}
+
 	args := &freeipa.CertRequestArgs{
 		Principal: plan.Principal.ValueString(),
 		Csr:       plan.CSR.ValueString(),
 	}
 	optArgs := &freeipa.CertRequestOptionalArgs{
-		All: freeipa.Bool(true),
-		Raw: freeipa.Bool(true),
+		ProfileID: plan.ProfileID.ValueStringPointer(),
+		Cacn:      plan.CACN.ValueStringPointer(),
+		Add:       plan.AddPrincipal.ValueBoolPointer(),
+		Chain:     freeipa.Bool(true),
+		All:       freeipa.Bool(true),
+		Raw:       freeipa.Bool(true),
 	}
 
 	tflog.Trace(ctx, "Calling CertRequest", map[string]any{
@@ -86,8 +401,26 @@ func (r *Certificate) Create(ctx context.Context, req resource.CreateRequest, re
 		return
 	}
 
+	result, ok := res.Result.(map[string]any)
+	if !ok {
+		resp.Diagnostics.AddError("Failed to create Certificate", fmt.Sprintf("Reason: unexpected result %v", res.Result))
+		return
+	}
+
 	state = plan
-	state.SerialNumber = types.Int64Value(res.Result.(int64))
+
+	if err := state.setCertificate(result["certificate"]); err != nil {
+		resp.Diagnostics.AddError("Failed to create Certificate", "Reason: "+err.Error())
+		return
+	}
+
+	if err := state.setChain(result["certificate_chain"]); err != nil {
+		resp.Diagnostics.AddError("Failed to create Certificate", "Reason: "+err.Error())
+		return
+	}
+
+	state.ReadyForRenewal = types.BoolValue(false)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 }
 
@@ -99,12 +432,19 @@ func (r *Certificate) Read(ctx context.Context, req resource.ReadRequest, resp *
 		return
 	}
 
-	args := &freeipa.CertShowArgs{
-		SerialNumber: int(state.SerialNumber.ValueInt64()),
+	serialNumber, err := parseCertificateSerialNumber(state.SerialNumber.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read Certificate", "Reason: "+err.Error())
+		return
+	}
+
+	args := &certificateSerialNumberArgs{
+		SerialNumber: serialNumber.String(),
 	}
 	optArgs := &freeipa.CertShowOptionalArgs{
-		All: freeipa.Bool(true),
-		Raw: freeipa.Bool(true),
+		Cacn: state.CACN.ValueStringPointer(),
+		All:  freeipa.Bool(true),
+		Raw:  freeipa.Bool(true),
 	}
 
 	tflog.Trace(ctx, "Calling CertShow", map[string]any{
@@ -112,7 +452,12 @@ func (r *Certificate) Read(ctx context.Context, req resource.ReadRequest, resp *
 		"opt_args": optArgs,
 	})
 
-	res, err := r.provider.Client().CertShow(args, optArgs)
+	var res rpcEntryResult
+
+	err = r.provider.Call("cert_show", nil, struct {
+		*certificateSerialNumberArgs
+		*freeipa.CertShowOptionalArgs
+	}{args, optArgs}, &res)
 	tflog.Trace(ctx, "Called CertShow", map[string]any{
 		"res": res,
 		"err": err,
@@ -129,6 +474,26 @@ func (r *Certificate) Read(ctx context.Context, req resource.ReadRequest, resp *
 		return
 	}
 
+	if isCertificateRevoked(res.Result) {
+		tflog.Warn(ctx, "Certificate has been revoked, removing it from state", map[string]any{
+			"serial_number":     state.SerialNumber.ValueString(),
+			"status":            entryString(res.Result, "status"),
+			"revocation_reason": entryString(res.Result, "revocation_reason"),
+		})
+
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err := state.setCertificate(res.Result["certificate"]); err != nil {
+		resp.Diagnostics.AddError("Failed to read Certificate", "Reason: "+err.Error())
+		return
+	}
+
+	if state.ReadyForRenewal.IsNull() {
+		state.ReadyForRenewal = types.BoolValue(false)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 }
 
@@ -142,7 +507,9 @@ func (r *Certificate) Update(ctx context.Context, req resource.UpdateRequest, re
 		return
 	}
 
-	hasDiff = !plan.Principal.Equal(state.Principal) || !plan.CSR.Equal(state.CSR)
+	hasDiff = !plan.Principal.Equal(state.Principal) || !plan.CSR.Equal(state.CSR) ||
+		!plan.AddPrincipal.Equal(state.AddPrincipal) || !plan.RevocationReason.Equal(state.RevocationReason) ||
+		!plan.RevokeOnDestroy.Equal(state.RevokeOnDestroy) || !plan.EarlyRenewalHours.Equal(state.EarlyRenewalHours)
 	if !hasDiff {
 		tflog.Debug(ctx, "Updated certificate has no effective difference", map[string]any{
 			"principal": plan.Principal.ValueString(),
@@ -163,16 +530,42 @@ func (r *Certificate) Delete(ctx context.Context, req resource.DeleteRequest, re
 		return
 	}
 
-	args := &freeipa.CertRevokeArgs{
-		SerialNumber: int(state.SerialNumber.ValueInt64()),
+	if !state.RevokeOnDestroy.IsNull() && !state.RevokeOnDestroy.ValueBool() {
+		tflog.Debug(ctx, "Certificate revocation on destroy is disabled", map[string]any{
+			"serial_number": state.SerialNumber.ValueString(),
+		})
+		return
+	}
+
+	serialNumber, err := parseCertificateSerialNumber(state.SerialNumber.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to delete certificate", "Reason: "+err.Error())
+		return
+	}
+
+	args := &certificateSerialNumberArgs{
+		SerialNumber: serialNumber.String(),
+	}
+	optArgs := &freeipa.CertRevokeOptionalArgs{
+		Cacn: state.CACN.ValueStringPointer(),
+	}
+
+	if !state.RevocationReason.IsNull() {
+		reason := certificateRevocationReasons[state.RevocationReason.ValueString()]
+		optArgs.RevocationReason = &reason
 	}
 
 	tflog.Trace(ctx, "Calling CertRevoke", map[string]any{
 		"args":     args,
-		"opt_args": nil,
+		"opt_args": optArgs,
 	})
 
-	res, err := r.provider.Client().CertRevoke(args, nil)
+	var res rpcEntryResult
+
+	err = r.provider.Call("cert_revoke", nil, struct {
+		*certificateSerialNumberArgs
+		*freeipa.CertRevokeOptionalArgs
+	}{args, optArgs}, &res)
 
 	tflog.Trace(ctx, "Called CertRevoke", map[string]any{
 		"res": res,
@@ -191,14 +584,16 @@ func (r *Certificate) Delete(ctx context.Context, req resource.DeleteRequest, re
 }
 
 func (r *Certificate) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	id, err := strconv.Atoi(req.ID)
+	serialNumber, err := parseCertificateSerialNumber(req.ID)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to import state", err.Error())
 		return
 	}
 
 	state := CertificateModel{
-		SerialNumber: types.Int64Value(int64(id)),
+		SerialNumber:    types.StringValue(serialNumber.String()),
+		RevokeOnDestroy: types.BoolValue(true),
+		DNSNames:        types.SetNull(types.StringType),
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
@@ -210,6 +605,8 @@ func NewCertificate(p *provider.Provider) resource.Resource {
 	}
 
 	var _ resource.Resource = r
+	var _ resource.ResourceWithValidateConfig = r
+	var _ resource.ResourceWithModifyPlan = r
 	var _ resource.ResourceWithImportState = r
 
 	return r
@@ -218,3 +615,130 @@ func NewCertificate(p *provider.Provider) resource.Resource {
 func init() {
 	resources = append(resources, NewCertificate)
 }
+
+func certificateRevocationReasonNames() []string {
+	names := make([]string, 0, len(certificateRevocationReasons))
+
+	for name := range certificateRevocationReasons {
+		names = append(names, name)
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		return certificateRevocationReasons[names[i]] < certificateRevocationReasons[names[j]]
+	})
+
+	return names
+}
+
+// certificateSerialNumberArgs are the arguments of the certificate commands.
+// The serial numbers of FreeIPA are random 128-bit integers, which the
+// client cannot send as it holds them in an int.
+type certificateSerialNumberArgs struct {
+	SerialNumber string `json:"serial_number"`
+}
+
+// parseCertificateSerialNumber parses a decimal certificate serial number.
+func parseCertificateSerialNumber(s string) (*big.Int, error) {
+	serialNumber, ok := new(big.Int).SetString(s, 10)
+	if !ok || serialNumber.Sign() < 0 {
+		return nil, fmt.Errorf("invalid serial number %q", s)
+	}
+
+	return serialNumber, nil
+}
+
+// isCertificateRevoked reports whether a certificate entry returned by
+// cert_show has been revoked, including certificates on hold.
+func isCertificateRevoked(entry map[string]any) bool {
+	if revoked := entryBool(entry, "revoked"); revoked != nil && *revoked {
+		return true
+	}
+
+	status := entryString(entry, "status")
+
+	return status != nil && strings.HasPrefix(*status, "REVOKED")
+}
+
+// setCertificate fills the attributes derived from the certificate, given
+// as returned by FreeIPA.
+func (m *CertificateModel) setCertificate(v any) error {
+	der, err := decodeCertificate(v)
+	if err != nil {
+		return err
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return fmt.Errorf("invalid certificate: %w", err)
+	}
+
+	fingerprint := sha256.Sum256(der)
+
+	m.SerialNumber = types.StringValue(cert.SerialNumber.String())
+	m.Certificate = types.StringValue(string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})))
+	m.NotBefore = types.StringValue(cert.NotBefore.UTC().Format(time.RFC3339))
+	m.NotAfter = types.StringValue(cert.NotAfter.UTC().Format(time.RFC3339))
+	m.Fingerprint = types.StringValue(colonHex(fingerprint[:]))
+
+	return nil
+}
+
+// setChain fills the certificate chain, given as returned by FreeIPA.
+func (m *CertificateModel) setChain(v any) error {
+	items, ok := v.([]any)
+	if !ok {
+		m.Chain = types.StringNull()
+
+		return nil
+	}
+
+	var chain strings.Builder
+
+	for _, item := range items {
+		der, err := decodeCertificate(item)
+		if err != nil {
+			return err
+		}
+
+		chain.Write(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	}
+
+	m.Chain = types.StringValue(chain.String())
+
+	return nil
+}
+
+// decodeCertificate returns the DER encoding of a certificate which FreeIPA
+// returns either as a base64 string or as a “__base64__” object.
+func decodeCertificate(v any) ([]byte, error) {
+	switch v := v.(type) {
+	case string:
+		return base64.StdEncoding.DecodeString(v)
+	case []any:
+		if len(v) == 1 {
+			return decodeCertificate(v[0])
+		}
+	case map[string]any:
+		if s, ok := v["__base64__"].(string); ok {
+			return base64.StdEncoding.DecodeString(s)
+		}
+	}
+
+	return nil, fmt.Errorf("unexpected certificate value %v", v)
+}
+
+func colonHex(b []byte) string {
+	s := hex.EncodeToString(b)
+
+	var out strings.Builder
+
+	for i := 0; i < len(s); i += 2 {
+		if i > 0 {
+			out.WriteByte(':')
+		}
+
+		out.WriteString(s[i : i+2])
+	}
+
+	return out.String()
+}
@@ -0,0 +1,279 @@
+package resources
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testCertificateSerialNumber is a random 128-bit serial number, as FreeIPA
+// issues them.
+const testCertificateSerialNumber = "260942876125473459137209463834541373226"
+
+func testCertificateDER(t *testing.T, notBefore, notAfter time.Time) []byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serialNumber, _ := new(big.Int).SetString(testCertificateSerialNumber, 10)
+
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject:      pkix.Name{CommonName: "test.example.test"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return der
+}
+
+// certShowResponse returns a canned raw cert_show response.
+func certShowResponse(der []byte, status string, revoked bool, revocationReason string) string {
+	return rpcResult(map[string]any{
+		"summary": nil,
+		"value":   testCertificateSerialNumber,
+		"result": map[string]any{
+			"certificate":        []string{base64.StdEncoding.EncodeToString(der)},
+			"subject":            []string{"CN=test.example.test,O=EXAMPLE.TEST"},
+			"issuer":             []string{"CN=Certificate Authority,O=EXAMPLE.TEST"},
+			"valid_not_before":   []map[string]string{{"__datetime__": "20240101000000Z"}},
+			"valid_not_after":    []map[string]string{{"__datetime__": "20260101000000Z"}},
+			"sha1_fingerprint":   []string{"00:11"},
+			"sha256_fingerprint": []string{"00:11"},
+			"serial_number":      []string{testCertificateSerialNumber},
+			"serial_number_hex":  []string{"0xC44FC5178A81F4EF53146B94E985372A"},
+			"status":             []string{status},
+			"revoked":            []bool{revoked},
+			"revocation_reason":  []string{revocationReason},
+		},
+	})
+}
+
+func testCertificateModel() CertificateModel {
+	return CertificateModel{
+		Principal:       types.StringValue("HTTP/test.example.test"),
+		CSR:             types.StringValue("csr"),
+		DNSNames:        types.SetNull(types.StringType),
+		RevokeOnDestroy: types.BoolValue(true),
+		SerialNumber:    types.StringValue(testCertificateSerialNumber),
+		ReadyForRenewal: types.BoolValue(false),
+	}
+}
+
+func TestCertificateRead(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	der := testCertificateDER(t, now.Add(-time.Hour), now.Add(24*time.Hour))
+
+	tests := map[string]struct {
+		response string
+		removed  bool
+	}{
+		"valid": {
+			response: certShowResponse(der, "VALID", false, "0"),
+		},
+		"revoked": {
+			response: certShowResponse(der, "REVOKED", true, "1"),
+			removed:  true,
+		},
+		"on hold": {
+			response: certShowResponse(der, "REVOKED", false, "6"),
+			removed:  true,
+		},
+		"not found": {
+			response: rpcError(4001, "NotFound", "Certificate serial number 0xc44fc5178a81f4ef53146b94e985372a not found"),
+			removed:  true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := newTestProvider(t, map[string]rpcHandler{
+				"cert_show": func(t *testing.T, params map[string]any) string {
+					if params["serial_number"] != testCertificateSerialNumber {
+						t.Errorf("unexpected serial number %v", params["serial_number"])
+					}
+
+					return test.response
+				},
+			})
+
+			model := testRead(t, NewCertificate(p), testCertificateModel())
+
+			if (model == nil) != test.removed {
+				t.Fatalf("expected resource removal to be %t", test.removed)
+			}
+
+			if test.removed {
+				return
+			}
+
+			if expected := now.Add(24 * time.Hour).Format(time.RFC3339); model.NotAfter.ValueString() != expected {
+				t.Errorf("unexpected not_after %s, expected %s", model.NotAfter.ValueString(), expected)
+			}
+
+			if model.Certificate.IsNull() || model.Fingerprint.IsNull() {
+				t.Errorf("certificate attributes have not been set")
+			}
+		})
+	}
+}
+
+func TestCertificateModifyPlan(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	certificateNow = func() time.Time { return now }
+	t.Cleanup(func() { certificateNow = time.Now })
+
+	tests := map[string]struct {
+		notAfter          time.Time
+		earlyRenewalHours types.Int64
+		replace           bool
+	}{
+		"valid": {
+			notAfter:          now.Add(48 * time.Hour),
+			earlyRenewalHours: types.Int64Null(),
+		},
+		"expired": {
+			notAfter:          now.Add(-time.Hour),
+			earlyRenewalHours: types.Int64Null(),
+			replace:           true,
+		},
+		"before renewal window": {
+			notAfter:          now.Add(48 * time.Hour),
+			earlyRenewalHours: types.Int64Value(24),
+		},
+		"within renewal window": {
+			notAfter:          now.Add(12 * time.Hour),
+			earlyRenewalHours: types.Int64Value(24),
+			replace:           true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := NewCertificate(nil).(*Certificate)
+
+			model := testCertificateModel()
+			model.EarlyRenewalHours = test.earlyRenewalHours
+			model.NotAfter = types.StringValue(test.notAfter.Format(time.RFC3339))
+
+			state := testState(t, r, model)
+			plan := tfsdk.Plan{Schema: state.Schema, Raw: state.Raw}
+			resp := resource.ModifyPlanResponse{Plan: plan}
+
+			r.ModifyPlan(context.Background(), resource.ModifyPlanRequest{State: state, Plan: plan}, &resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			replace := len(resp.RequiresReplace) == 1 && resp.RequiresReplace[0].Equal(path.Root("ready_for_renewal"))
+
+			if replace != test.replace {
+				t.Errorf("expected replacement to be %t, got %v", test.replace, resp.RequiresReplace)
+			}
+		})
+	}
+}
+
+func TestGenerateCSR(t *testing.T) {
+	tests := map[string]struct {
+		algorithm  string
+		size       int64
+		principal  string
+		subject    *CertificateSubjectModel
+		dnsNames   []string
+		commonName string
+		sans       []string
+	}{
+		"rsa service": {
+			algorithm:  "RSA",
+			principal:  "HTTP/test.example.test@EXAMPLE.TEST",
+			commonName: "test.example.test",
+			sans:       []string{"test.example.test"},
+		},
+		"ecdsa host with subject": {
+			algorithm: "ECDSA",
+			size:      384,
+			principal: "host/test.example.test",
+			subject: &CertificateSubjectModel{
+				CommonName:         types.StringValue("www.example.test"),
+				Organization:       types.StringValue("EXAMPLE.TEST"),
+				OrganizationalUnit: types.StringNull(),
+				Locality:           types.StringNull(),
+				Province:           types.StringNull(),
+				Country:            types.StringNull(),
+			},
+			dnsNames:   []string{"www.example.test", "test.example.test"},
+			commonName: "www.example.test",
+			sans:       []string{"www.example.test", "test.example.test"},
+		},
+		"user": {
+			algorithm:  "ECDSA",
+			principal:  "admin@EXAMPLE.TEST",
+			commonName: "admin",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			key, keyPEM, err := generatePrivateKey(test.algorithm, test.size)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if block, _ := pem.Decode([]byte(keyPEM)); block == nil || block.Type != "PRIVATE KEY" {
+				t.Fatalf("invalid private key PEM %q", keyPEM)
+			}
+
+			csrPEM, err := generateCSR(key, test.principal, test.subject, test.dnsNames)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			block, _ := pem.Decode([]byte(csrPEM))
+			if block == nil || block.Type != "CERTIFICATE REQUEST" {
+				t.Fatalf("invalid certificate signing request PEM %q", csrPEM)
+			}
+
+			csr, err := x509.ParseCertificateRequest(block.Bytes)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := csr.CheckSignature(); err != nil {
+				t.Errorf("invalid signature: %s", err)
+			}
+
+			if csr.Subject.CommonName != test.commonName {
+				t.Errorf("unexpected common name %q, expected %q", csr.Subject.CommonName, test.commonName)
+			}
+
+			if !slices.Equal(csr.DNSNames, test.sans) {
+				t.Errorf("unexpected DNS names %v, expected %v", csr.DNSNames, test.sans)
+			}
+		})
+	}
+}
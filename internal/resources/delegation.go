@@ -0,0 +1,355 @@
+package resources
+
+import (
+	"context"
+	"errors"
+
+	"github.com/camptocamp/go-freeipa/freeipa"
+	"github.com/camptocamp/terraform-provider-freeipa/internal/provider"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type Delegation struct {
+	provider *provider.Provider
+}
+
+type DelegationModel struct {
+	Name        types.String `tfsdk:"name"`
+	Permissions types.Set    `tfsdk:"permissions"`
+	Attrs       types.Set    `tfsdk:"attrs"`
+	Group       types.String `tfsdk:"group"`
+	MemberOf    types.String `tfsdk:"member_of"`
+}
+
+func (r *Delegation) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_delegation"
+}
+
+func (r *Delegation) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version:     0,
+		Description: "Manages a delegation rule, the attributes the members of a group may edit on the members of another group",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Description: "Delegation name",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"permissions": schema.SetAttribute{
+				Description: "Permissions to grant (read, write), write by default",
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"attrs": schema.SetAttribute{
+				Description: "Attributes to which the delegation rule applies, which must exist in the server schema",
+				ElementType: types.StringType,
+				Required:    true,
+			},
+			"group": schema.StringAttribute{
+				Description: "User group granted the delegation rule",
+				Required:    true,
+			},
+			"member_of": schema.StringAttribute{
+				Description: "User group whose members' attributes may be edited",
+				Required:    true,
+			},
+		},
+	}
+}
+
+func (r *Delegation) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config DelegationModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateACIConfig(config.Permissions, config.Attrs)...)
+}
+
+func (r *Delegation) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var plan DelegationModel
+
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateACIAttrs(ctx, r.provider, plan.Attrs)...)
+}
+
+func (r *Delegation) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan, state DelegationModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var permissions, attrs []string
+
+	if !plan.Permissions.IsUnknown() {
+		resp.Diagnostics.Append(plan.Permissions.ElementsAs(ctx, &permissions, false)...)
+	}
+
+	resp.Diagnostics.Append(plan.Attrs.ElementsAs(ctx, &attrs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.DelegationAddArgs{
+		Aciname:  plan.Name.ValueString(),
+		Attrs:    attrs,
+		Memberof: plan.MemberOf.ValueString(),
+		Group:    plan.Group.ValueString(),
+	}
+
+	optArgs := &freeipa.DelegationAddOptionalArgs{
+		Permissions: membersPointer(permissions),
+	}
+
+	tflog.Trace(ctx, "Calling DelegationAdd", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	var res rpcEntryResult
+
+	err := r.provider.Call("delegation_add", nil, struct {
+		*freeipa.DelegationAddArgs
+		*freeipa.DelegationAddOptionalArgs
+	}{args, optArgs}, &res)
+	tflog.Trace(ctx, "Called DelegationAdd", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create delegation rule", "Reason: "+err.Error())
+		return
+	}
+
+	state = plan
+	resp.Diagnostics.Append(state.readEntry(ctx, res.Result)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *Delegation) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state DelegationModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.DelegationShowArgs{
+		Aciname: state.Name.ValueString(),
+	}
+
+	tflog.Trace(ctx, "Calling DelegationShow", map[string]any{
+		"args":     args,
+		"opt_args": nil,
+	})
+
+	var res rpcEntryResult
+
+	err := r.provider.Call("delegation_show", []any{args.Aciname}, nil, &res)
+	tflog.Trace(ctx, "Called DelegationShow", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if errors.As(err, &freeipaErr) && freeipaErr.Code == freeipa.NotFoundCode {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Failed to read delegation rule", "Reason: "+err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(state.readEntry(ctx, res.Result)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *Delegation) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state, plan DelegationModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var hasDiff bool
+
+	args := &freeipa.DelegationModArgs{
+		Aciname: state.Name.ValueString(),
+	}
+
+	optArgs := &freeipa.DelegationModOptionalArgs{}
+
+	for _, attr := range []struct {
+		plan, state types.Set
+		values      **[]string
+	}{
+		{plan.Permissions, state.Permissions, &optArgs.Permissions},
+		{plan.Attrs, state.Attrs, &optArgs.Attrs},
+	} {
+		if !attr.plan.Equal(attr.state) {
+			values := []string{}
+
+			resp.Diagnostics.Append(attr.plan.ElementsAs(ctx, &values, false)...)
+
+			*attr.values = &values
+			hasDiff = true
+		}
+	}
+
+	for _, attr := range []struct {
+		plan, state types.String
+		value       **string
+	}{
+		{plan.Group, state.Group, &optArgs.Group},
+		{plan.MemberOf, state.MemberOf, &optArgs.Memberof},
+	} {
+		if !attr.plan.Equal(attr.state) {
+			*attr.value = freeipa.String(attr.plan.ValueString())
+			hasDiff = true
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if hasDiff {
+		tflog.Trace(ctx, "Calling DelegationMod", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		err := r.provider.Call("delegation_mod", []any{args.Aciname}, optArgs, nil)
+		tflog.Trace(ctx, "Called DelegationMod", map[string]any{
+			"err": err,
+		})
+
+		if err != nil {
+			var freeipaErr *freeipa.Error
+
+			if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.EmptyModlistCode {
+				resp.Diagnostics.AddError("Failed to update delegation rule", "Reason: "+err.Error())
+				return
+			}
+
+			tflog.Debug(ctx, "Updated delegation rule has no effective difference", map[string]any{
+				"args":     args,
+				"opt_args": optArgs,
+			})
+		}
+	}
+
+	state = plan
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *Delegation) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state DelegationModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.DelegationDelArgs{
+		Aciname: state.Name.ValueString(),
+	}
+
+	tflog.Trace(ctx, "Calling DelegationDel", map[string]any{
+		"args":     args,
+		"opt_args": nil,
+	})
+
+	res, err := r.provider.Client().DelegationDel(args, nil)
+
+	tflog.Trace(ctx, "Called DelegationDel", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.NotFoundCode {
+			resp.Diagnostics.AddError("Failed to delete delegation rule", "Reason: "+err.Error())
+			return
+		}
+	}
+}
+
+func (r *Delegation) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	state := DelegationModel{
+		Name:        types.StringValue(req.ID),
+		Permissions: types.SetNull(types.StringType),
+		Attrs:       types.SetNull(types.StringType),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func NewDelegation(p *provider.Provider) resource.Resource {
+	r := &Delegation{
+		provider: p,
+	}
+
+	var _ resource.Resource = r
+	var _ resource.ResourceWithImportState = r
+	var _ resource.ResourceWithValidateConfig = r
+	var _ resource.ResourceWithModifyPlan = r
+
+	return r
+}
+
+func init() {
+	resources = append(resources, NewDelegation)
+}
+
+func (m *DelegationModel) readEntry(ctx context.Context, entry map[string]any) (diags diag.Diagnostics) {
+	m.Permissions, m.Attrs, diags = aciSetValues(ctx, m.Permissions, m.Attrs, entry)
+	m.Group = types.StringPointerValue(entryString(entry, "group"))
+	m.MemberOf = types.StringPointerValue(entryString(entry, "memberof"))
+
+	return
+}
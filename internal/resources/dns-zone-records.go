@@ -0,0 +1,879 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/camptocamp/go-freeipa/freeipa"
+	"github.com/camptocamp/terraform-provider-freeipa/internal/provider"
+	"github.com/camptocamp/terraform-provider-freeipa/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Record types reconciled by freeipa_dns_zone_records, same as those
+// supported by freeipa_dns_record.
+var dnsZoneRecordTypes = []string{"A", "AAAA", "CNAME", "MX", "NS", "PTR", "SRV", "TXT", "SSHFP"}
+
+var dnsZoneRecordAttrTypes = map[string]attr.Type{
+	"name":  types.StringType,
+	"type":  types.StringType,
+	"ttl":   types.Int64Type,
+	"value": types.StringType,
+}
+
+type DnsZoneRecords struct {
+	provider *provider.Provider
+}
+
+type DnsZoneRecordsModel struct {
+	ZoneName       types.String `tfsdk:"dnszoneidnsname"`
+	ZoneFile       types.String `tfsdk:"zone_file"`
+	Records        types.Set    `tfsdk:"record"`
+	ManagedRecords types.Set    `tfsdk:"managed_records"`
+}
+
+type DnsZoneRecordModel struct {
+	Name  types.String `tfsdk:"name"`
+	Type  types.String `tfsdk:"type"`
+	TTL   types.Int64  `tfsdk:"ttl"`
+	Value types.String `tfsdk:"value"`
+}
+
+// dnsZoneRecordEntry holds all the records of a single DNS name, as stored
+// by FreeIPA: the TTL is shared by all the records of the entry.
+type dnsZoneRecordEntry struct {
+	ttl     *int64
+	records map[string][]string
+}
+
+func (r *DnsZoneRecords) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_zone_records"
+}
+
+func (r *DnsZoneRecords) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version:     0,
+		Description: "Authoritatively manages all the records of a DNS zone, except the SOA and NS records of the zone apex",
+		Attributes: map[string]schema.Attribute{
+			"dnszoneidnsname": schema.StringAttribute{
+				Description: "Zone name (FQDN)",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"zone_file": schema.StringAttribute{
+				Description: "RFC 1035 zone file fragment describing the records of the zone",
+				Optional:    true,
+			},
+			"managed_records": schema.SetAttribute{
+				Description: "Effective records of the zone managed by this resource",
+				ElementType: types.ObjectType{AttrTypes: dnsZoneRecordAttrTypes},
+				Computed:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"record": schema.SetNestedBlock{
+				Description: "Record of the zone, in addition to those described by “zone_file”",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "Record name relative to the zone (“@” for the zone apex)",
+							Required:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "Record type, one of: " + strings.Join(dnsZoneRecordTypes, ", "),
+							Required:    true,
+						},
+						"ttl": schema.Int64Attribute{
+							Description: "Time to live, shared by all the records of the same name",
+							Optional:    true,
+						},
+						"value": schema.StringAttribute{
+							Description: "Record data, as written in a zone file",
+							Required:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *DnsZoneRecords) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config DnsZoneRecordsModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !isDnsZoneRecordsKnown(ctx, config) {
+		return
+	}
+
+	_, diags := r.desiredRecords(ctx, config)
+
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *DnsZoneRecords) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var plan DnsZoneRecordsModel
+
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !isDnsZoneRecordsKnown(ctx, plan) {
+		plan.ManagedRecords = types.SetUnknown(types.ObjectType{AttrTypes: dnsZoneRecordAttrTypes})
+	} else {
+		desired, diags := r.desiredRecords(ctx, plan)
+
+		resp.Diagnostics.Append(diags...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		plan.ManagedRecords, diags = types.SetValueFrom(ctx, types.ObjectType{AttrTypes: dnsZoneRecordAttrTypes}, desired)
+
+		resp.Diagnostics.Append(diags...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
+func (r *DnsZoneRecords) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan, state DnsZoneRecordsModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.reconcile(ctx, plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state = plan
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *DnsZoneRecords) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state DnsZoneRecordsModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	actual, err := r.findRecords(ctx, state.ZoneName.ValueString())
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if errors.As(err, &freeipaErr) && freeipaErr.Code == freeipa.NotFoundCode {
+			resp.State.RemoveResource(ctx)
+
+			return
+		}
+
+		resp.Diagnostics.AddError("Failed to read DNS zone records", "Reason: "+err.Error())
+
+		return
+	}
+
+	var diags diag.Diagnostics
+
+	state.ManagedRecords, diags = types.SetValueFrom(ctx, types.ObjectType{AttrTypes: dnsZoneRecordAttrTypes}, actual)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *DnsZoneRecords) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state, plan DnsZoneRecordsModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.ManagedRecords.Equal(state.ManagedRecords) {
+		tflog.Debug(ctx, "Updated DNS zone records have no effective difference", map[string]any{
+			"zone_name": plan.ZoneName.ValueString(),
+		})
+	} else {
+		resp.Diagnostics.Append(r.reconcile(ctx, plan)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state = plan
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *DnsZoneRecords) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state DnsZoneRecordsModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zone := state.ZoneName.ValueString()
+
+	actual, err := r.findRecords(ctx, zone)
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if errors.As(err, &freeipaErr) && freeipaErr.Code == freeipa.NotFoundCode {
+			return
+		}
+
+		resp.Diagnostics.AddError("Failed to read DNS zone records", "Reason: "+err.Error())
+
+		return
+	}
+
+	entries := groupDnsZoneRecords(actual)
+	names := make([]string, 0, len(entries))
+
+	for name := range entries {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	calls := make([]dnsZoneRecordsCall, 0, len(names))
+
+	for _, name := range names {
+		calls = append(calls, removeDnsZoneRecordsCall(zone, name, entries[name].records))
+	}
+
+	resp.Diagnostics.Append(r.batch(ctx, calls)...)
+}
+
+func (r *DnsZoneRecords) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	state := DnsZoneRecordsModel{
+		ZoneName:       types.StringValue(req.ID),
+		ZoneFile:       types.StringNull(),
+		Records:        types.SetValueMust(types.ObjectType{AttrTypes: dnsZoneRecordAttrTypes}, []attr.Value{}),
+		ManagedRecords: types.SetNull(types.ObjectType{AttrTypes: dnsZoneRecordAttrTypes}),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func NewDnsZoneRecords(p *provider.Provider) resource.Resource {
+	r := &DnsZoneRecords{
+		provider: p,
+	}
+
+	var _ resource.Resource = r
+	var _ resource.ResourceWithValidateConfig = r
+	var _ resource.ResourceWithModifyPlan = r
+	var _ resource.ResourceWithImportState = r
+
+	return r
+}
+
+func init() {
+	resources = append(resources, NewDnsZoneRecords)
+}
+
+// desiredRecords merges the records described by the zone file with the
+// explicitly listed ones, dropping the apex SOA and NS records which are
+// managed by FreeIPA itself.
+func (r *DnsZoneRecords) desiredRecords(ctx context.Context, model DnsZoneRecordsModel) (records []DnsZoneRecordModel, diags diag.Diagnostics) {
+	if !model.ZoneFile.IsNull() {
+		parsed, err := utils.ParseZoneFile(model.ZoneFile.ValueString(), model.ZoneName.ValueString())
+
+		if err != nil {
+			diags.AddError("Invalid zone file", err.Error())
+
+			return
+		}
+
+		for _, record := range parsed {
+			records = append(records, DnsZoneRecordModel{
+				Name:  types.StringValue(record.Name),
+				Type:  types.StringValue(record.Type),
+				TTL:   types.Int64PointerValue(record.TTL),
+				Value: types.StringValue(record.Value),
+			})
+		}
+	}
+
+	if !model.Records.IsNull() {
+		var explicit []DnsZoneRecordModel
+
+		diags.Append(model.Records.ElementsAs(ctx, &explicit, false)...)
+
+		if diags.HasError() {
+			return
+		}
+
+		for _, record := range explicit {
+			record.Type = types.StringValue(strings.ToUpper(record.Type.ValueString()))
+			records = append(records, record)
+		}
+	}
+
+	filtered := records[:0]
+
+	for _, record := range records {
+		recordType := record.Type.ValueString()
+
+		if record.Name.ValueString() == "@" && (recordType == "SOA" || recordType == "NS") {
+			continue
+		}
+
+		if !isDnsZoneRecordType(recordType) {
+			diags.AddError(
+				"Unsupported record type",
+				fmt.Sprintf("Record “%s” has unsupported type “%s”. Supported types are: %s.", record.Name.ValueString(), recordType, strings.Join(dnsZoneRecordTypes, ", ")),
+			)
+
+			continue
+		}
+
+		filtered = append(filtered, record)
+	}
+
+	if diags.HasError() {
+		return
+	}
+
+	// FreeIPA stores a single TTL per name, spread it to all the records of
+	// the name so the plan matches what is read back
+	ttls := map[string]types.Int64{}
+
+	for _, record := range filtered {
+		name := record.Name.ValueString()
+
+		if record.TTL.IsNull() {
+			continue
+		}
+
+		if ttl, ok := ttls[name]; ok && !ttl.Equal(record.TTL) {
+			diags.AddError(
+				"Conflicting TTL",
+				fmt.Sprintf("Records of “%s” have different TTLs (%d and %d). FreeIPA only supports a single TTL per name.", name, ttl.ValueInt64(), record.TTL.ValueInt64()),
+			)
+
+			return
+		}
+
+		ttls[name] = record.TTL
+	}
+
+	seen := map[DnsZoneRecordModel]bool{}
+	records = []DnsZoneRecordModel{}
+
+	for _, record := range filtered {
+		if ttl, ok := ttls[record.Name.ValueString()]; ok {
+			record.TTL = ttl
+		} else {
+			record.TTL = types.Int64Null()
+		}
+
+		if seen[record] {
+			continue
+		}
+
+		seen[record] = true
+		records = append(records, record)
+	}
+
+	return
+}
+
+func (r *DnsZoneRecords) findRecords(ctx context.Context, zone string) (records []DnsZoneRecordModel, err error) {
+	var zoneName any = zone
+
+	args := &freeipa.DnsrecordFindArgs{}
+
+	optArgs := &freeipa.DnsrecordFindOptionalArgs{
+		Dnszoneidnsname: &zoneName,
+		Sizelimit:       freeipa.Int(0),
+		All:             freeipa.Bool(true),
+	}
+
+	tflog.Trace(ctx, "Calling DnsrecordFind", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	res, err := r.provider.Client().DnsrecordFind("", args, optArgs)
+
+	tflog.Trace(ctx, "Called DnsrecordFind", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	records = []DnsZoneRecordModel{}
+
+	if res.Truncated {
+		return nil, fmt.Errorf("the list of records of zone “%s” has been truncated by the server", zone)
+	}
+
+	for _, entry := range res.Result {
+		name := dnsRecordName(entry.Idnsname)
+
+		var ttl *int64
+
+		if entry.Dnsttl != nil {
+			ttl = new(int64)
+			*ttl = int64(*entry.Dnsttl)
+		}
+
+		for _, recordType := range dnsZoneRecordTypes {
+			if name == "@" && recordType == "NS" {
+				continue
+			}
+
+			values := dnsrecordValues(&entry, recordType)
+
+			if values == nil {
+				continue
+			}
+
+			for _, value := range *values {
+				records = append(records, DnsZoneRecordModel{
+					Name:  types.StringValue(name),
+					Type:  types.StringValue(recordType),
+					TTL:   types.Int64PointerValue(ttl),
+					Value: types.StringValue(value),
+				})
+			}
+		}
+	}
+
+	return
+}
+
+// dnsZoneRecordsBatchSize is the maximum number of commands sent in a single
+// batch request, to keep the requests of large zones reasonably sized.
+const dnsZoneRecordsBatchSize = 100
+
+// dnsZoneRecordsCall is a command on the records of a name, run as part of a
+// batch.
+type dnsZoneRecordsCall struct {
+	provider.BatchCall
+
+	name string
+	// summary and action describe the command when it fails.
+	summary string
+	action  string
+	// ignoredCode is an error code which does not make the command fail.
+	ignoredCode int
+}
+
+// reconcile brings the records of the zone to the planned state, issuing at
+// most one removal, one addition and one modification per name, in batches.
+func (r *DnsZoneRecords) reconcile(ctx context.Context, plan DnsZoneRecordsModel) (diags diag.Diagnostics) {
+	zone := plan.ZoneName.ValueString()
+
+	var desired []DnsZoneRecordModel
+
+	diags.Append(plan.ManagedRecords.ElementsAs(ctx, &desired, false)...)
+
+	if diags.HasError() {
+		return
+	}
+
+	actual, err := r.findRecords(ctx, zone)
+
+	if err != nil {
+		diags.AddError("Failed to read DNS zone records", "Reason: "+err.Error())
+
+		return
+	}
+
+	actualEntries := groupDnsZoneRecords(actual)
+	desiredEntries := groupDnsZoneRecords(desired)
+
+	var names []string
+
+	for name := range actualEntries {
+		names = append(names, name)
+	}
+
+	for name := range desiredEntries {
+		if _, ok := actualEntries[name]; !ok {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	var calls []dnsZoneRecordsCall
+
+	for _, name := range names {
+		actualEntry, exists := actualEntries[name]
+		if !exists {
+			actualEntry = &dnsZoneRecordEntry{records: map[string][]string{}}
+		}
+
+		desiredEntry, ok := desiredEntries[name]
+		if !ok {
+			desiredEntry = &dnsZoneRecordEntry{records: map[string][]string{}}
+		}
+
+		toAdd := map[string][]string{}
+		toRemove := map[string][]string{}
+
+		for _, recordType := range dnsZoneRecordTypes {
+			add, remove := utils.SetDiff(actualEntry.records[recordType], desiredEntry.records[recordType])
+
+			if len(add) > 0 {
+				toAdd[recordType] = add
+			}
+
+			if len(remove) > 0 {
+				toRemove[recordType] = remove
+			}
+		}
+
+		// Remove first so that a CNAME can replace other records of a name,
+		// FreeIPA runs the commands of a batch in order
+		if len(toRemove) > 0 {
+			calls = append(calls, removeDnsZoneRecordsCall(zone, name, toRemove))
+		}
+
+		if len(toAdd) > 0 {
+			calls = append(calls, addDnsZoneRecordsCall(zone, name, desiredEntry.ttl, toAdd))
+		}
+
+		ttlChanged := !types.Int64PointerValue(actualEntry.ttl).Equal(types.Int64PointerValue(desiredEntry.ttl))
+
+		// New names already got their TTL when added
+		if exists && len(desiredEntry.records) > 0 && ttlChanged {
+			calls = append(calls, updateDnsZoneRecordsTTLCall(zone, name, desiredEntry.ttl))
+		}
+	}
+
+	diags.Append(r.batch(ctx, calls)...)
+
+	return
+}
+
+// batch runs the given commands, stopping after the first batch request
+// where some of them failed.
+func (r *DnsZoneRecords) batch(ctx context.Context, calls []dnsZoneRecordsCall) (diags diag.Diagnostics) {
+	for len(calls) > 0 {
+		chunk := calls[:min(len(calls), dnsZoneRecordsBatchSize)]
+		calls = calls[len(chunk):]
+
+		batchCalls := make([]provider.BatchCall, len(chunk))
+
+		for i, call := range chunk {
+			batchCalls[i] = call.BatchCall
+		}
+
+		tflog.Trace(ctx, "Calling Batch", map[string]any{
+			"calls": batchCalls,
+		})
+
+		errs, err := r.provider.Batch(batchCalls)
+
+		tflog.Trace(ctx, "Called Batch", map[string]any{
+			"errs": errs,
+			"err":  err,
+		})
+
+		if err != nil {
+			diags.AddError("Failed to update DNS zone records", "Reason: "+err.Error())
+
+			return
+		}
+
+		for i, err := range errs {
+			if err == nil {
+				continue
+			}
+
+			var freeipaErr *freeipa.Error
+
+			if errors.As(err, &freeipaErr) && freeipaErr.Code == chunk[i].ignoredCode {
+				continue
+			}
+
+			diags.AddError(chunk[i].summary, fmt.Sprintf("Reason: failed to %s “%s”: %s", chunk[i].action, chunk[i].name, err.Error()))
+		}
+
+		if diags.HasError() {
+			return
+		}
+	}
+
+	return
+}
+
+func addDnsZoneRecordsCall(zone, name string, ttl *int64, records map[string][]string) dnsZoneRecordsCall {
+	var zoneName any = zone
+
+	args := &freeipa.DnsrecordAddArgs{
+		Idnsname: name,
+	}
+
+	optArgs := &freeipa.DnsrecordAddOptionalArgs{
+		Dnszoneidnsname: &zoneName,
+	}
+
+	if ttl != nil {
+		optArgs.Dnsttl = freeipa.Int(int(*ttl))
+	}
+
+	for recordType, values := range records {
+		values := values
+
+		switch recordType {
+		case "A":
+			optArgs.Arecord = &values
+		case "AAAA":
+			optArgs.Aaaarecord = &values
+		case "CNAME":
+			optArgs.Cnamerecord = &values
+		case "MX":
+			optArgs.Mxrecord = &values
+		case "NS":
+			optArgs.Nsrecord = &values
+		case "PTR":
+			optArgs.Ptrrecord = &values
+		case "SRV":
+			optArgs.Srvrecord = &values
+		case "TXT":
+			optArgs.Txtrecord = &values
+		case "SSHFP":
+			optArgs.Sshfprecord = &values
+		}
+	}
+
+	// Like the client does, the required arguments are passed as options.
+	return dnsZoneRecordsCall{
+		BatchCall: provider.BatchCall{
+			Method: "dnsrecord_add",
+			Options: struct {
+				*freeipa.DnsrecordAddArgs
+				*freeipa.DnsrecordAddOptionalArgs
+			}{args, optArgs},
+		},
+		name:    name,
+		summary: "Failed to add DNS zone records",
+		action:  "add records of",
+	}
+}
+
+func removeDnsZoneRecordsCall(zone, name string, records map[string][]string) dnsZoneRecordsCall {
+	var zoneName any = zone
+
+	args := &freeipa.DnsrecordDelArgs{
+		Idnsname: name,
+	}
+
+	optArgs := &freeipa.DnsrecordDelOptionalArgs{
+		Dnszoneidnsname: &zoneName,
+	}
+
+	for recordType, values := range records {
+		values := values
+
+		switch recordType {
+		case "A":
+			optArgs.Arecord = &values
+		case "AAAA":
+			optArgs.Aaaarecord = &values
+		case "CNAME":
+			optArgs.Cnamerecord = &values
+		case "MX":
+			optArgs.Mxrecord = &values
+		case "NS":
+			optArgs.Nsrecord = &values
+		case "PTR":
+			optArgs.Ptrrecord = &values
+		case "SRV":
+			optArgs.Srvrecord = &values
+		case "TXT":
+			optArgs.Txtrecord = &values
+		case "SSHFP":
+			optArgs.Sshfprecord = &values
+		}
+	}
+
+	return dnsZoneRecordsCall{
+		BatchCall: provider.BatchCall{
+			Method: "dnsrecord_del",
+			Options: struct {
+				*freeipa.DnsrecordDelArgs
+				*freeipa.DnsrecordDelOptionalArgs
+			}{args, optArgs},
+		},
+		name:        name,
+		summary:     "Failed to remove DNS zone records",
+		action:      "remove records of",
+		ignoredCode: freeipa.NotFoundCode,
+	}
+}
+
+func updateDnsZoneRecordsTTLCall(zone, name string, ttl *int64) dnsZoneRecordsCall {
+	var zoneName any = zone
+
+	args := &freeipa.DnsrecordModArgs{
+		Idnsname: name,
+	}
+
+	optArgs := &freeipa.DnsrecordModOptionalArgs{
+		Dnszoneidnsname: &zoneName,
+	}
+
+	if ttl != nil {
+		optArgs.Dnsttl = freeipa.Int(int(*ttl))
+	} else {
+		optArgs.Setattr = &[]string{"dnsttl="}
+	}
+
+	return dnsZoneRecordsCall{
+		BatchCall: provider.BatchCall{
+			Method: "dnsrecord_mod",
+			Options: struct {
+				*freeipa.DnsrecordModArgs
+				*freeipa.DnsrecordModOptionalArgs
+			}{args, optArgs},
+		},
+		name:        name,
+		summary:     "Failed to update DNS zone records",
+		action:      "update TTL of",
+		ignoredCode: freeipa.EmptyModlistCode,
+	}
+}
+
+// isDnsZoneRecordsKnown reports whether the desired records can be computed,
+// that is whether the zone file and all the record blocks are known.
+func isDnsZoneRecordsKnown(ctx context.Context, model DnsZoneRecordsModel) bool {
+	if model.ZoneName.IsUnknown() || model.ZoneFile.IsUnknown() {
+		return false
+	}
+
+	records, err := model.Records.ToTerraformValue(ctx)
+
+	return err == nil && records.IsFullyKnown()
+}
+
+func groupDnsZoneRecords(records []DnsZoneRecordModel) map[string]*dnsZoneRecordEntry {
+	entries := map[string]*dnsZoneRecordEntry{}
+
+	for _, record := range records {
+		name := record.Name.ValueString()
+
+		entry, ok := entries[name]
+		if !ok {
+			entry = &dnsZoneRecordEntry{
+				ttl:     record.TTL.ValueInt64Pointer(),
+				records: map[string][]string{},
+			}
+			entries[name] = entry
+		}
+
+		entry.records[record.Type.ValueString()] = append(entry.records[record.Type.ValueString()], record.Value.ValueString())
+	}
+
+	return entries
+}
+
+func isDnsZoneRecordType(recordType string) bool {
+	for _, t := range dnsZoneRecordTypes {
+		if t == recordType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func dnsrecordValues(entry *freeipa.Dnsrecord, recordType string) *[]string {
+	switch recordType {
+	case "A":
+		return entry.Arecord
+	case "AAAA":
+		return entry.Aaaarecord
+	case "CNAME":
+		return entry.Cnamerecord
+	case "MX":
+		return entry.Mxrecord
+	case "NS":
+		return entry.Nsrecord
+	case "PTR":
+		return entry.Ptrrecord
+	case "SRV":
+		return entry.Srvrecord
+	case "TXT":
+		return entry.Txtrecord
+	case "SSHFP":
+		return entry.Sshfprecord
+	}
+
+	return nil
+}
+
+// dnsRecordName returns the name of a DNS record entry, which FreeIPA
+// returns either as a plain string or as a “__dns_name__” object.
+func dnsRecordName(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []any:
+		if len(v) > 0 {
+			return dnsRecordName(v[0])
+		}
+	case map[string]any:
+		if name, ok := v["__dns_name__"].(string); ok {
+			return name
+		}
+	}
+
+	return fmt.Sprint(v)
+}
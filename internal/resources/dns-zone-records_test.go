@@ -0,0 +1,134 @@
+package resources
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDnsZoneRecordsReconcile(t *testing.T) {
+	var calls []string
+
+	p := newTestProvider(t, map[string]rpcHandler{
+		"dnsrecord_find": func(t *testing.T, params map[string]any) string {
+			return rpcResult(map[string]any{
+				"count":     5,
+				"truncated": false,
+				"summary":   "5 DNS resource records matched",
+				"result": []map[string]any{
+					{
+						"idnsname":     []map[string]any{{"__dns_name__": "@"}},
+						"nsrecord":     []string{"ns1.example.test."},
+						"idnssoamname": []string{"ns1.example.test."},
+					},
+					{
+						"idnsname": []map[string]any{{"__dns_name__": "www"}},
+						"dnsttl":   []string{"300"},
+						"arecord":  []string{"192.0.2.1"},
+					},
+					{
+						"idnsname": []map[string]any{{"__dns_name__": "old"}},
+						"arecord":  []string{"192.0.2.9"},
+					},
+					{
+						"idnsname": []map[string]any{{"__dns_name__": "mail"}},
+						"mxrecord": []string{"10 mx.example.test."},
+					},
+					{
+						"idnsname": []map[string]any{{"__dns_name__": "alias"}},
+						"arecord":  []string{"192.0.2.5"},
+					},
+				},
+			})
+		},
+		"dnsrecord_del": func(t *testing.T, params map[string]any) string {
+			calls = append(calls, "del "+params["idnsname"].(string))
+
+			expected := map[string][]any{
+				"old":   {"192.0.2.9"},
+				"alias": {"192.0.2.5"},
+			}
+
+			if !reflect.DeepEqual(params["arecord"], expected[params["idnsname"].(string)]) {
+				t.Errorf("unexpected removed records %v of %v", params["arecord"], params["idnsname"])
+			}
+
+			// Already removed records must not fail the reconciliation.
+			if params["idnsname"] == "old" {
+				return rpcError(4001, "NotFound", "old: DNS resource record not found")
+			}
+
+			return rpcResult(map[string]any{"result": map[string]any{}, "value": params["idnsname"]})
+		},
+		"dnsrecord_add": func(t *testing.T, params map[string]any) string {
+			calls = append(calls, "add "+params["idnsname"].(string))
+
+			switch params["idnsname"] {
+			case "new":
+				if !reflect.DeepEqual(params["arecord"], []any{"192.0.2.2"}) || params["dnsttl"] != float64(60) {
+					t.Errorf("unexpected added records %v", params)
+				}
+			case "alias":
+				if !reflect.DeepEqual(params["cnamerecord"], []any{"www"}) {
+					t.Errorf("unexpected added records %v", params)
+				}
+			}
+
+			return rpcResult(map[string]any{"result": map[string]any{}, "value": params["idnsname"]})
+		},
+		"dnsrecord_mod": func(t *testing.T, params map[string]any) string {
+			calls = append(calls, "mod "+params["idnsname"].(string))
+
+			if params["dnsttl"] != float64(600) {
+				t.Errorf("unexpected TTL %v", params["dnsttl"])
+			}
+
+			return rpcResult(map[string]any{"result": map[string]any{}, "value": params["idnsname"]})
+		},
+	})
+
+	ctx := context.Background()
+
+	record := func(name, recordType string, ttl *int64, value string) DnsZoneRecordModel {
+		return DnsZoneRecordModel{
+			Name:  types.StringValue(name),
+			Type:  types.StringValue(recordType),
+			TTL:   types.Int64PointerValue(ttl),
+			Value: types.StringValue(value),
+		}
+	}
+
+	ttl := func(v int64) *int64 {
+		return &v
+	}
+
+	managed, diags := types.SetValueFrom(ctx, types.ObjectType{AttrTypes: dnsZoneRecordAttrTypes}, []DnsZoneRecordModel{
+		record("www", "A", ttl(600), "192.0.2.1"),
+		record("mail", "MX", nil, "10 mx.example.test."),
+		record("new", "A", ttl(60), "192.0.2.2"),
+		record("alias", "CNAME", nil, "www"),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	r := NewDnsZoneRecords(p).(*DnsZoneRecords)
+
+	diags = r.reconcile(ctx, DnsZoneRecordsModel{
+		ZoneName:       types.StringValue("example.test."),
+		ManagedRecords: managed,
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	// The apex NS and SOA records are left alone and the records replaced
+	// by a CNAME are removed first.
+	expected := []string{"del alias", "add alias", "add new", "del old", "mod www"}
+
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("unexpected calls %v, expected %v", calls, expected)
+	}
+}
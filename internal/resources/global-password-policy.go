@@ -0,0 +1,187 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/camptocamp/terraform-provider-freeipa/internal/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// globalPasswordPolicyName is the name of the global password policy, which
+// applies to the users without a group password policy.
+const globalPasswordPolicyName = "global_policy"
+
+type GlobalPasswordPolicy struct {
+	provider *provider.Provider
+}
+
+type GlobalPasswordPolicyModel struct {
+	Name         types.String `tfsdk:"cn"`
+	MaxLife      types.Int64  `tfsdk:"maxlife"`
+	MinLife      types.Int64  `tfsdk:"minlife"`
+	History      types.Int64  `tfsdk:"history"`
+	MinClasses   types.Int64  `tfsdk:"minclasses"`
+	MinLength    types.Int64  `tfsdk:"minlength"`
+	MaxFail      types.Int64  `tfsdk:"maxfail"`
+	FailInterval types.Int64  `tfsdk:"failinterval"`
+	LockoutTime  types.Int64  `tfsdk:"lockouttime"`
+	MaxRepeat    types.Int64  `tfsdk:"maxrepeat"`
+	MaxSequence  types.Int64  `tfsdk:"maxsequence"`
+	DictCheck    types.Bool   `tfsdk:"dictcheck"`
+	UserCheck    types.Bool   `tfsdk:"usercheck"`
+	GraceLimit   types.Int64  `tfsdk:"gracelimit"`
+}
+
+func (m *GlobalPasswordPolicyModel) settings() passwordPolicySettings {
+	return passwordPolicySettings{
+		MaxLife:      m.MaxLife,
+		MinLife:      m.MinLife,
+		History:      m.History,
+		MinClasses:   m.MinClasses,
+		MinLength:    m.MinLength,
+		MaxFail:      m.MaxFail,
+		FailInterval: m.FailInterval,
+		LockoutTime:  m.LockoutTime,
+		MaxRepeat:    m.MaxRepeat,
+		MaxSequence:  m.MaxSequence,
+		DictCheck:    m.DictCheck,
+		UserCheck:    m.UserCheck,
+		GraceLimit:   m.GraceLimit,
+	}
+}
+
+func (m *GlobalPasswordPolicyModel) setSettings(s passwordPolicySettings) {
+	m.MaxLife = s.MaxLife
+	m.MinLife = s.MinLife
+	m.History = s.History
+	m.MinClasses = s.MinClasses
+	m.MinLength = s.MinLength
+	m.MaxFail = s.MaxFail
+	m.FailInterval = s.FailInterval
+	m.LockoutTime = s.LockoutTime
+	m.MaxRepeat = s.MaxRepeat
+	m.MaxSequence = s.MaxSequence
+	m.DictCheck = s.DictCheck
+	m.UserCheck = s.UserCheck
+	m.GraceLimit = s.GraceLimit
+}
+
+func (r *GlobalPasswordPolicy) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_global_password_policy"
+}
+
+func (r *GlobalPasswordPolicy) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := passwordPolicyAttributes()
+
+	attributes["cn"] = schema.StringAttribute{
+		Description: "Name of the global password policy",
+		Computed:    true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		Version:     0,
+		Description: "Manages the global password policy, which applies to the users without a group password policy. Destroying the resource leaves the policy unchanged",
+		Attributes:  attributes,
+	}
+}
+
+func (r *GlobalPasswordPolicy) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan, state GlobalPasswordPolicyModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(modPasswordPolicy(ctx, r.provider, nil, plan.settings().optArgs())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entry, err := showPasswordPolicy(ctx, r.provider, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read global password policy", "Reason: "+err.Error())
+		return
+	}
+
+	state.Name = types.StringValue(globalPasswordPolicyName)
+	state.setSettings(passwordPolicySettingsFromEntry(entry))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *GlobalPasswordPolicy) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state GlobalPasswordPolicyModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entry, err := showPasswordPolicy(ctx, r.provider, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read global password policy", "Reason: "+err.Error())
+		return
+	}
+
+	state.Name = types.StringValue(globalPasswordPolicyName)
+	state.setSettings(passwordPolicySettingsFromEntry(entry))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *GlobalPasswordPolicy) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state, plan GlobalPasswordPolicyModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(modPasswordPolicy(ctx, r.provider, nil, plan.settings().optArgs())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state = plan
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *GlobalPasswordPolicy) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// The global password policy cannot be deleted, it is only removed from
+	// the state.
+}
+
+func (r *GlobalPasswordPolicy) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	state := GlobalPasswordPolicyModel{
+		Name: types.StringValue(globalPasswordPolicyName),
+	}
+
+	state.setSettings(passwordPolicySettingsFromEntry(nil))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func NewGlobalPasswordPolicy(p *provider.Provider) resource.Resource {
+	r := &GlobalPasswordPolicy{
+		provider: p,
+	}
+
+	var _ resource.Resource = r
+	var _ resource.ResourceWithImportState = r
+
+	return r
+}
+
+func init() {
+	resources = append(resources, NewGlobalPasswordPolicy)
+}
@@ -3,12 +3,15 @@ package resources
 import (
 	"context"
 	"errors"
+	"slices"
+	"strings"
 
 	"github.com/camptocamp/go-freeipa/freeipa"
 	"github.com/camptocamp/terraform-provider-freeipa/internal/provider"
 	"github.com/camptocamp/terraform-provider-freeipa/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -17,6 +20,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+var hostDestroyModes = []string{"delete", "disable"}
+
 type Host struct {
 	provider *provider.Provider
 }
@@ -29,6 +34,17 @@ type HostModel struct {
 	RandomPassword types.String `tfsdk:"randompassword"`
 	ManagedByHosts types.Set    `tfsdk:"managedby_hosts"`
 	Force          types.Bool   `tfsdk:"force"`
+	IPAddress      types.String `tfsdk:"ip_address"`
+	SSHPubKeys     types.Set    `tfsdk:"sshpubkeys"`
+	Locality       types.String `tfsdk:"locality"`
+	Location       types.String `tfsdk:"location"`
+	Platform       types.String `tfsdk:"platform"`
+	OS             types.String `tfsdk:"os"`
+	MACAddresses   types.Set    `tfsdk:"mac_addresses"`
+	AuthIndicators types.Set    `tfsdk:"auth_indicators"`
+	UserClass      types.Set    `tfsdk:"userclass"`
+	DestroyMode    types.String `tfsdk:"destroy_mode"`
+	UpdateDNS      types.Bool   `tfsdk:"update_dns"`
 }
 
 func (r *Host) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -67,6 +83,54 @@ func (r *Host) Schema(ctx context.Context, req resource.SchemaRequest, resp *res
 			"force": schema.BoolAttribute{
 				Optional: true,
 			},
+			"ip_address": schema.StringAttribute{
+				Description: "IP address of the host, only used to add its DNS records on creation. Changing it afterwards neither updates the DNS records nor replaces the host",
+				Optional:    true,
+			},
+			"sshpubkeys": schema.SetAttribute{
+				Description: "SSH public keys of the host",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"locality": schema.StringAttribute{
+				Description: "Host locality (e.g. “Baltimore, MD”)",
+				Optional:    true,
+			},
+			"location": schema.StringAttribute{
+				Description: "Host location (e.g. “Lab 2”)",
+				Optional:    true,
+			},
+			"platform": schema.StringAttribute{
+				Description: "Host hardware platform (e.g. “Lenovo T61”)",
+				Optional:    true,
+			},
+			"os": schema.StringAttribute{
+				Description: "Host operating system and version (e.g. “Fedora 40”)",
+				Optional:    true,
+			},
+			"mac_addresses": schema.SetAttribute{
+				Description: "Hardware MAC addresses of the host",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"auth_indicators": schema.SetAttribute{
+				Description: "Authentication indicators required to obtain a ticket for the host, one of: " + strings.Join(kerberosAuthIndicators, ", "),
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"userclass": schema.SetAttribute{
+				Description: "Host categories (semantics placed on this attribute are for local interpretation)",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"destroy_mode": schema.StringAttribute{
+				Description: "How the host is destroyed, one of: " + strings.Join(hostDestroyModes, ", ") + " (defaults to “delete”). Disabling the host revokes its certificates and removes its keytab but keeps its entry",
+				Optional:    true,
+			},
+			"update_dns": schema.BoolAttribute{
+				Description: "Remove the DNS records of the host when it is deleted",
+				Optional:    true,
+			},
 		},
 	}
 }
@@ -88,6 +152,32 @@ func (r *Host) ValidateConfig(ctx context.Context, req resource.ValidateConfigRe
 			)
 		}
 	}
+
+	if !config.DestroyMode.IsNull() && !config.DestroyMode.IsUnknown() {
+		if !slices.Contains(hostDestroyModes, config.DestroyMode.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("destroy_mode"),
+				"Invalid configuration",
+				"“destroy_mode” must be one of: "+strings.Join(hostDestroyModes, ", ")+".",
+			)
+		} else if config.DestroyMode.ValueString() == "disable" && config.UpdateDNS.ValueBool() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("update_dns"),
+				"Invalid configuration",
+				"“update_dns” cannot be set when “destroy_mode” is “disable”, as disabling a host keeps its DNS records.",
+			)
+		}
+	}
+
+	for _, authIndicator := range knownSetStrings(config.AuthIndicators) {
+		if !slices.Contains(kerberosAuthIndicators, authIndicator) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("auth_indicators"),
+				"Invalid configuration",
+				"“auth_indicators” values must be one of: "+strings.Join(kerberosAuthIndicators, ", ")+".",
+			)
+		}
+	}
 }
 
 func (r *Host) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
@@ -133,6 +223,13 @@ func (r *Host) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, r
 		}
 	}
 
+	if !isCreation && !plan.IPAddress.Equal(state.IPAddress) {
+		resp.Diagnostics.AddWarning(
+			"Attribute modification considerations",
+			`Changing “ip_address” attribute will not effectively update the DNS records of the host in FreeIPA, as the IP address is only used on creation. It will only be changed in Terraform state.`,
+		)
+	}
+
 	if config.ManagedByHosts.IsNull() {
 		var diags diag.Diagnostics
 
@@ -157,9 +254,13 @@ func (r *Host) Create(ctx context.Context, req resource.CreateRequest, resp *res
 		return
 	}
 
-	var managedByHosts []string
+	var managedByHosts, sshPubKeys, macAddresses, authIndicators, userClasses []string
 
 	resp.Diagnostics.Append(plan.ManagedByHosts.ElementsAs(ctx, &managedByHosts, false)...)
+	resp.Diagnostics.Append(plan.SSHPubKeys.ElementsAs(ctx, &sshPubKeys, false)...)
+	resp.Diagnostics.Append(plan.MACAddresses.ElementsAs(ctx, &macAddresses, false)...)
+	resp.Diagnostics.Append(plan.AuthIndicators.ElementsAs(ctx, &authIndicators, false)...)
+	resp.Diagnostics.Append(plan.UserClass.ElementsAs(ctx, &userClasses, false)...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -170,11 +271,20 @@ func (r *Host) Create(ctx context.Context, req resource.CreateRequest, resp *res
 	}
 
 	optArgs := &freeipa.HostAddOptionalArgs{
-		Description:  plan.Description.ValueStringPointer(),
-		Random:       plan.Random.ValueBoolPointer(),
-		Userpassword: plan.UserPassword.ValueStringPointer(),
-		Force:        plan.Force.ValueBoolPointer(),
-		All:          freeipa.Bool(true),
+		Description:         plan.Description.ValueStringPointer(),
+		Random:              plan.Random.ValueBoolPointer(),
+		Userpassword:        plan.UserPassword.ValueStringPointer(),
+		Force:               plan.Force.ValueBoolPointer(),
+		IPAddress:           plan.IPAddress.ValueStringPointer(),
+		L:                   plan.Locality.ValueStringPointer(),
+		Nshostlocation:      plan.Location.ValueStringPointer(),
+		Nshardwareplatform:  plan.Platform.ValueStringPointer(),
+		Nsosversion:         plan.OS.ValueStringPointer(),
+		Ipasshpubkey:        membersPointer(sshPubKeys),
+		Macaddress:          membersPointer(macAddresses),
+		Krbprincipalauthind: membersPointer(authIndicators),
+		Userclass:           membersPointer(userClasses),
+		All:                 freeipa.Bool(true),
 	}
 
 	tflog.Trace(ctx, "Calling HostAdd", map[string]any{
@@ -253,6 +363,21 @@ func (r *Host) Read(ctx context.Context, req resource.ReadRequest, resp *resourc
 	var diags diag.Diagnostics
 
 	state.Description = types.StringPointerValue(res.Result.Description)
+	state.Locality = types.StringPointerValue(res.Result.L)
+	state.Location = types.StringPointerValue(res.Result.Nshostlocation)
+	state.Platform = types.StringPointerValue(res.Result.Nshardwareplatform)
+	state.OS = types.StringPointerValue(res.Result.Nsosversion)
+
+	resp.Diagnostics.Append(state.setSSHPubKeys(ctx, res.Result.Ipasshpubkey)...)
+	resp.Diagnostics.Append(state.setMACAddresses(ctx, res.Result.Macaddress)...)
+
+	state.AuthIndicators, diags = membersSetValue(ctx, state.AuthIndicators, res.Result.Krbprincipalauthind)
+
+	resp.Diagnostics.Append(diags...)
+
+	state.UserClass, diags = membersSetValue(ctx, state.UserClass, res.Result.Userclass)
+
+	resp.Diagnostics.Append(diags...)
 
 	if managedByHosts := res.Result.ManagedbyHost; managedByHosts != nil {
 		state.ManagedByHosts, diags = types.SetValueFrom(ctx, types.StringType, *managedByHosts)
@@ -300,6 +425,44 @@ func (r *Host) Update(ctx context.Context, req resource.UpdateRequest, resp *res
 
 	hasDiff = !plan.Description.Equal(state.Description)
 
+	for _, attr := range []struct {
+		plan, state types.String
+		value       **string
+	}{
+		{plan.Locality, state.Locality, &optArgs.L},
+		{plan.Location, state.Location, &optArgs.Nshostlocation},
+		{plan.Platform, state.Platform, &optArgs.Nshardwareplatform},
+		{plan.OS, state.OS, &optArgs.Nsosversion},
+	} {
+		if !attr.plan.Equal(attr.state) {
+			*attr.value = freeipa.String(attr.plan.ValueString())
+			hasDiff = true
+		}
+	}
+
+	for _, attr := range []struct {
+		plan, state types.Set
+		values      **[]string
+	}{
+		{plan.SSHPubKeys, state.SSHPubKeys, &optArgs.Ipasshpubkey},
+		{plan.MACAddresses, state.MACAddresses, &optArgs.Macaddress},
+		{plan.AuthIndicators, state.AuthIndicators, &optArgs.Krbprincipalauthind},
+		{plan.UserClass, state.UserClass, &optArgs.Userclass},
+	} {
+		if !attr.plan.Equal(attr.state) {
+			values := []string{}
+
+			resp.Diagnostics.Append(attr.plan.ElementsAs(ctx, &values, false)...)
+
+			*attr.values = &values
+			hasDiff = true
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Do not regenerate a new enrollment password if not requested
 	if !plan.Random.Equal(state.Random) && plan.Random.ValueBool() {
 		hasDiff = true
@@ -328,12 +491,16 @@ func (r *Host) Update(ctx context.Context, req resource.UpdateRequest, resp *res
 		})
 
 		if err != nil {
-			resp.Diagnostics.AddError("Failed to update host", "Reason: "+err.Error())
+			var freeipaErr *freeipa.Error
 
-			return
+			if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.EmptyModlistCode {
+				resp.Diagnostics.AddError("Failed to update host", "Reason: "+err.Error())
+
+				return
+			}
 		}
 
-		if optArgs.Random != nil && *optArgs.Random {
+		if res != nil && optArgs.Random != nil && *optArgs.Random {
 			randomPassword = types.StringPointerValue(res.Result.Randompassword)
 		}
 	} else {
@@ -365,13 +532,21 @@ func (r *Host) Delete(ctx context.Context, req resource.DeleteRequest, resp *res
 		return
 	}
 
+	if state.DestroyMode.ValueString() == "disable" {
+		resp.Diagnostics.Append(r.disable(ctx, state.Fqdn.ValueString())...)
+
+		return
+	}
+
 	args := &freeipa.HostDelArgs{
 		Fqdn: []string{
 			state.Fqdn.ValueString(),
 		},
 	}
 
-	optArgs := &freeipa.HostDelOptionalArgs{}
+	optArgs := &freeipa.HostDelOptionalArgs{
+		Updatedns: state.UpdateDNS.ValueBoolPointer(),
+	}
 
 	tflog.Trace(ctx, "Calling HostDel", map[string]any{
 		"args":     args,
@@ -400,8 +575,12 @@ func (r *Host) Delete(ctx context.Context, req resource.DeleteRequest, resp *res
 
 func (r *Host) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	state := HostModel{
-		Fqdn:   types.StringValue(req.ID),
-		Random: types.BoolValue(true),
+		Fqdn:           types.StringValue(req.ID),
+		Random:         types.BoolValue(true),
+		SSHPubKeys:     types.SetNull(types.StringType),
+		MACAddresses:   types.SetNull(types.StringType),
+		AuthIndicators: types.SetNull(types.StringType),
+		UserClass:      types.SetNull(types.StringType),
 	}
 
 	resp.Diagnostics.AddWarning(
@@ -451,6 +630,10 @@ func (r *Host) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrade
 					RandomPassword: oldState.RandomPassword,
 					ManagedByHosts: types.SetNull(types.StringType),
 					Force:          oldState.Force,
+					SSHPubKeys:     types.SetNull(types.StringType),
+					MACAddresses:   types.SetNull(types.StringType),
+					AuthIndicators: types.SetNull(types.StringType),
+					UserClass:      types.SetNull(types.StringType),
 				}
 
 				resp.Diagnostics.Append(resp.State.Set(ctx, newState)...)
@@ -477,6 +660,36 @@ func init() {
 	resources = append(resources, NewHost)
 }
 
+// disable disables a host, revoking its certificates and removing its keytab
+// while keeping its entry.
+func (r *Host) disable(ctx context.Context, fqdn string) (diags diag.Diagnostics) {
+	args := &freeipa.HostDisableArgs{
+		Fqdn: fqdn,
+	}
+
+	tflog.Trace(ctx, "Calling HostDisable", map[string]any{
+		"args":     args,
+		"opt_args": nil,
+	})
+
+	res, err := r.provider.Client().HostDisable(args, nil)
+
+	tflog.Trace(ctx, "Called HostDisable", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if !errors.As(err, &freeipaErr) || (freeipaErr.Code != freeipa.NotFoundCode && freeipaErr.Code != freeipa.AlreadyInactiveCode) {
+			diags.AddError("Failed to disable host", "Reason: "+err.Error())
+		}
+	}
+
+	return
+}
+
 func (r *Host) updateManagedByHosts(ctx context.Context, fqdn string, actualHosts, desiredHosts []string) (diags diag.Diagnostics) {
 	hostsToAdd, hostsToRemove := utils.SetDiff(actualHosts, desiredHosts)
 
@@ -548,3 +761,74 @@ func (r *Host) removeManagedByHosts(ctx context.Context, fqdn string, hosts []st
 
 	return
 }
+
+// setSSHPubKeys sets the SSH public keys of a host, keeping them in their
+// current form when FreeIPA only normalised their whitespace or comment.
+func (m *HostModel) setSSHPubKeys(ctx context.Context, keys *[]string) (diags diag.Diagnostics) {
+	var currentKeys []string
+
+	diags.Append(m.SSHPubKeys.ElementsAs(ctx, &currentKeys, false)...)
+
+	if keys != nil {
+		keys = normalizedValues(*keys, currentKeys, sshPublicKeyID)
+	}
+
+	var d diag.Diagnostics
+
+	m.SSHPubKeys, d = membersSetValue(ctx, m.SSHPubKeys, keys)
+	diags.Append(d...)
+
+	return
+}
+
+// setMACAddresses sets the MAC addresses of a host, keeping them in their
+// current form when FreeIPA only changed their case.
+func (m *HostModel) setMACAddresses(ctx context.Context, addresses *[]string) (diags diag.Diagnostics) {
+	var currentAddresses []string
+
+	diags.Append(m.MACAddresses.ElementsAs(ctx, &currentAddresses, false)...)
+
+	if addresses != nil {
+		addresses = normalizedValues(*addresses, currentAddresses, strings.ToUpper)
+	}
+
+	var d diag.Diagnostics
+
+	m.MACAddresses, d = membersSetValue(ctx, m.MACAddresses, addresses)
+	diags.Append(d...)
+
+	return
+}
+
+// normalizedValues returns the given values, replaced by their current form
+// when both have the same key.
+func normalizedValues(values, current []string, key func(string) string) *[]string {
+	normalized := make([]string, 0, len(values))
+
+	for _, value := range values {
+		for _, c := range current {
+			if key(c) == key(value) {
+				value = c
+				break
+			}
+		}
+
+		normalized = append(normalized, value)
+	}
+
+	return &normalized
+}
+
+// sshPublicKeyID returns the type and base64 blob of an SSH public key in the
+// OpenSSH format, ignoring its options and comment.
+func sshPublicKeyID(key string) string {
+	fields := strings.Fields(key)
+
+	for i, field := range fields[:max(len(fields)-1, 0)] {
+		if strings.HasPrefix(field, "ssh-") || strings.HasPrefix(field, "ecdsa-") || strings.HasPrefix(field, "sk-") {
+			return field + " " + fields[i+1]
+		}
+	}
+
+	return strings.Join(fields, " ")
+}
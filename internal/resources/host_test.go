@@ -0,0 +1,59 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestHostRead(t *testing.T) {
+	p := newTestProvider(t, map[string]rpcHandler{
+		"host_show": func(t *testing.T, params map[string]any) string {
+			return rpcResult(map[string]any{
+				"summary": nil,
+				"value":   "test.example.test",
+				"result": map[string]any{
+					"fqdn":           []string{"test.example.test"},
+					"l":              []string{"Baltimore, MD"},
+					"nsosversion":    []string{"Fedora 40"},
+					"ipasshpubkey":   []string{"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIKey1 root@test", "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQKey2"},
+					"macaddress":     []string{"00:1A:2B:3C:4D:5E"},
+					"managedby_host": []string{"test.example.test"},
+				},
+			})
+		},
+	})
+
+	ctx := context.Background()
+
+	sshPubKeys, _ := types.SetValueFrom(ctx, types.StringType, []string{"ssh-ed25519  AAAAC3NzaC1lZDI1NTE5AAAAIKey1"})
+	macAddresses, _ := types.SetValueFrom(ctx, types.StringType, []string{"00:1a:2b:3c:4d:5e"})
+
+	model := testRead(t, NewHost(p), HostModel{
+		Fqdn:           types.StringValue("test.example.test"),
+		ManagedByHosts: types.SetNull(types.StringType),
+		SSHPubKeys:     sshPubKeys,
+		MACAddresses:   macAddresses,
+		AuthIndicators: types.SetNull(types.StringType),
+		UserClass:      types.SetNull(types.StringType),
+	})
+
+	expectedSSHPubKeys, _ := types.SetValueFrom(ctx, types.StringType, []string{"ssh-ed25519  AAAAC3NzaC1lZDI1NTE5AAAAIKey1", "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQKey2"})
+
+	if !model.SSHPubKeys.Equal(expectedSSHPubKeys) {
+		t.Errorf("unexpected sshpubkeys %v, expected %v", model.SSHPubKeys, expectedSSHPubKeys)
+	}
+
+	if !model.MACAddresses.Equal(macAddresses) {
+		t.Errorf("unexpected mac_addresses %v, expected %v", model.MACAddresses, macAddresses)
+	}
+
+	if model.Locality.ValueString() != "Baltimore, MD" || model.OS.ValueString() != "Fedora 40" || !model.Location.IsNull() {
+		t.Errorf("unexpected locality %v, os %v or location %v", model.Locality, model.OS, model.Location)
+	}
+
+	if !model.AuthIndicators.IsNull() || !model.UserClass.IsNull() {
+		t.Errorf("unset attributes without values should stay null")
+	}
+}
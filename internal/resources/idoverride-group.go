@@ -0,0 +1,298 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/camptocamp/go-freeipa/freeipa"
+	"github.com/camptocamp/terraform-provider-freeipa/internal/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type IDOverrideGroup struct {
+	provider *provider.Provider
+}
+
+type IDOverrideGroupModel struct {
+	IDView      types.String `tfsdk:"idview"`
+	Anchor      types.String `tfsdk:"anchor"`
+	Description types.String `tfsdk:"description"`
+	Name        types.String `tfsdk:"name"`
+	GIDNumber   types.Int64  `tfsdk:"gid_number"`
+}
+
+func (r *IDOverrideGroup) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_idoverride_group"
+}
+
+func (r *IDOverrideGroup) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version:     0,
+		Description: "Manages the override of the attributes of a group in an ID view",
+		Attributes: map[string]schema.Attribute{
+			"idview": schema.StringAttribute{
+				Description: "ID view of the override",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"anchor": schema.StringAttribute{
+				Description: "Group to override, a FreeIPA group name or a trusted domain group (e.g. “group@ad.example.test”)",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description: "Override description",
+				Optional:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Group name on the hosts of the view",
+				Optional:    true,
+			},
+			"gid_number": schema.Int64Attribute{
+				Description: "Group ID number on the hosts of the view",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *IDOverrideGroup) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan, state IDOverrideGroupModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.IdoverridegroupAddArgs{
+		Idviewcn:      plan.IDView.ValueString(),
+		Ipaanchoruuid: plan.Anchor.ValueString(),
+	}
+
+	optArgs := &freeipa.IdoverridegroupAddOptionalArgs{
+		Description: plan.Description.ValueStringPointer(),
+		Cn:          plan.Name.ValueStringPointer(),
+		Gidnumber:   knownInt(plan.GIDNumber),
+	}
+
+	tflog.Trace(ctx, "Calling IdoverridegroupAdd", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	res, err := r.provider.Client().IdoverridegroupAdd(args, optArgs)
+
+	tflog.Trace(ctx, "Called IdoverridegroupAdd", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create group ID override", "Reason: "+err.Error())
+		return
+	}
+
+	state = plan
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *IDOverrideGroup) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state IDOverrideGroupModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.IdoverridegroupShowArgs{
+		Idviewcn:      state.IDView.ValueString(),
+		Ipaanchoruuid: state.Anchor.ValueString(),
+	}
+
+	optArgs := &freeipa.IdoverridegroupShowOptionalArgs{
+		All: freeipa.Bool(true),
+	}
+
+	tflog.Trace(ctx, "Calling IdoverridegroupShow", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	res, err := r.provider.Client().IdoverridegroupShow(args, optArgs)
+
+	tflog.Trace(ctx, "Called IdoverridegroupShow", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if errors.As(err, &freeipaErr) && freeipaErr.Code == freeipa.NotFoundCode {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Failed to read group ID override", "Reason: "+err.Error())
+		return
+	}
+
+	// The anchor is returned as the unique ID of the group, keep its name.
+	state.Description = types.StringPointerValue(res.Result.Description)
+	state.Name = types.StringPointerValue(res.Result.Cn)
+	state.GIDNumber = types.Int64PointerValue(int64Pointer(res.Result.Gidnumber))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *IDOverrideGroup) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state, plan IDOverrideGroupModel
+	var hasDiff bool
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.IdoverridegroupModArgs{
+		Idviewcn:      plan.IDView.ValueString(),
+		Ipaanchoruuid: plan.Anchor.ValueString(),
+	}
+
+	optArgs := &freeipa.IdoverridegroupModOptionalArgs{}
+
+	for _, attr := range []struct {
+		plan, state types.String
+		value       **string
+	}{
+		{plan.Description, state.Description, &optArgs.Description},
+		{plan.Name, state.Name, &optArgs.Cn},
+	} {
+		if !attr.plan.Equal(attr.state) {
+			*attr.value = freeipa.String(attr.plan.ValueString())
+			hasDiff = true
+		}
+	}
+
+	if !plan.GIDNumber.Equal(state.GIDNumber) {
+		// Integer options cannot be emptied, remove the attribute instead.
+		if plan.GIDNumber.IsNull() {
+			optArgs.Delattr = &[]string{"gidnumber=" + state.GIDNumber.String()}
+		} else {
+			optArgs.Gidnumber = knownInt(plan.GIDNumber)
+		}
+
+		hasDiff = true
+	}
+
+	if hasDiff {
+		tflog.Trace(ctx, "Calling IdoverridegroupMod", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		res, err := r.provider.Client().IdoverridegroupMod(args, optArgs)
+
+		tflog.Trace(ctx, "Called IdoverridegroupMod", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		if err != nil {
+			var freeipaErr *freeipa.Error
+
+			if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.EmptyModlistCode {
+				resp.Diagnostics.AddError("Failed to update group ID override", "Reason: "+err.Error())
+				return
+			}
+
+			tflog.Debug(ctx, "Updated group ID override has no effective difference", map[string]any{
+				"args":     args,
+				"opt_args": optArgs,
+			})
+		}
+	}
+
+	state = plan
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *IDOverrideGroup) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state IDOverrideGroupModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.IdoverridegroupDelArgs{
+		Idviewcn:      state.IDView.ValueString(),
+		Ipaanchoruuid: []string{state.Anchor.ValueString()},
+	}
+
+	tflog.Trace(ctx, "Calling IdoverridegroupDel", map[string]any{
+		"args":     args,
+		"opt_args": nil,
+	})
+
+	res, err := r.provider.Client().IdoverridegroupDel(args, nil)
+
+	tflog.Trace(ctx, "Called IdoverridegroupDel", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.NotFoundCode {
+			resp.Diagnostics.AddError("Failed to delete group ID override", "Reason: "+err.Error())
+			return
+		}
+	}
+}
+
+func (r *IDOverrideGroup) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := strings.Split(req.ID, "/")
+
+	if len(id) != 2 {
+		resp.Diagnostics.AddError("Invalid ID format", "Expected ID format is “<ID view>/<anchor>”")
+
+		return
+	}
+
+	state := IDOverrideGroupModel{
+		IDView: types.StringValue(id[0]),
+		Anchor: types.StringValue(id[1]),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func NewIDOverrideGroup(p *provider.Provider) resource.Resource {
+	r := &IDOverrideGroup{
+		provider: p,
+	}
+
+	var _ resource.Resource = r
+	var _ resource.ResourceWithImportState = r
+
+	return r
+}
+
+func init() {
+	resources = append(resources, NewIDOverrideGroup)
+}
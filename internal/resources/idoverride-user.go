@@ -0,0 +1,487 @@
+package resources
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+
+	"github.com/camptocamp/go-freeipa/freeipa"
+	"github.com/camptocamp/terraform-provider-freeipa/internal/provider"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type IDOverrideUser struct {
+	provider *provider.Provider
+}
+
+type IDOverrideUserModel struct {
+	IDView        types.String `tfsdk:"idview"`
+	Anchor        types.String `tfsdk:"anchor"`
+	Description   types.String `tfsdk:"description"`
+	Login         types.String `tfsdk:"login"`
+	UIDNumber     types.Int64  `tfsdk:"uid_number"`
+	GIDNumber     types.Int64  `tfsdk:"gid_number"`
+	Gecos         types.String `tfsdk:"gecos"`
+	HomeDirectory types.String `tfsdk:"home_directory"`
+	Shell         types.String `tfsdk:"shell"`
+	SSHPubKeys    types.Set    `tfsdk:"sshpubkeys"`
+	Certificates  types.Set    `tfsdk:"certificates"`
+}
+
+func (r *IDOverrideUser) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_idoverride_user"
+}
+
+func (r *IDOverrideUser) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version:     0,
+		Description: "Manages the override of the attributes of a user in an ID view",
+		Attributes: map[string]schema.Attribute{
+			"idview": schema.StringAttribute{
+				Description: "ID view of the override",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"anchor": schema.StringAttribute{
+				Description: "User to override, a FreeIPA user name or a trusted domain user (e.g. “user@ad.example.test”)",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description: "Override description",
+				Optional:    true,
+			},
+			"login": schema.StringAttribute{
+				Description: "User login on the hosts of the view",
+				Optional:    true,
+			},
+			"uid_number": schema.Int64Attribute{
+				Description: "User ID number on the hosts of the view",
+				Optional:    true,
+			},
+			"gid_number": schema.Int64Attribute{
+				Description: "Primary group ID number on the hosts of the view",
+				Optional:    true,
+			},
+			"gecos": schema.StringAttribute{
+				Description: "GECOS field on the hosts of the view",
+				Optional:    true,
+			},
+			"home_directory": schema.StringAttribute{
+				Description: "Home directory on the hosts of the view",
+				Optional:    true,
+			},
+			"shell": schema.StringAttribute{
+				Description: "Login shell on the hosts of the view",
+				Optional:    true,
+			},
+			"sshpubkeys": schema.SetAttribute{
+				Description: "SSH public keys of the user on the hosts of the view",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"certificates": schema.SetAttribute{
+				Description: "Base64 encoded DER certificates of the user on the hosts of the view",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *IDOverrideUser) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan, state IDOverrideUserModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var sshPubKeys, certificates []string
+
+	resp.Diagnostics.Append(plan.SSHPubKeys.ElementsAs(ctx, &sshPubKeys, false)...)
+	resp.Diagnostics.Append(plan.Certificates.ElementsAs(ctx, &certificates, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.IdoverrideuserAddArgs{
+		Idviewcn:      plan.IDView.ValueString(),
+		Ipaanchoruuid: plan.Anchor.ValueString(),
+	}
+
+	optArgs := &freeipa.IdoverrideuserAddOptionalArgs{
+		Description:     plan.Description.ValueStringPointer(),
+		UID:             plan.Login.ValueStringPointer(),
+		Uidnumber:       knownInt(plan.UIDNumber),
+		Gidnumber:       knownInt(plan.GIDNumber),
+		Gecos:           plan.Gecos.ValueStringPointer(),
+		Homedirectory:   plan.HomeDirectory.ValueStringPointer(),
+		Loginshell:      plan.Shell.ValueStringPointer(),
+		Ipasshpubkey:    membersPointer(sshPubKeys),
+		Usercertificate: certificatesPointer(certificates),
+	}
+
+	tflog.Trace(ctx, "Calling IdoverrideuserAdd", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	res, err := r.provider.Client().IdoverrideuserAdd(args, optArgs)
+
+	tflog.Trace(ctx, "Called IdoverrideuserAdd", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create user ID override", "Reason: "+err.Error())
+		return
+	}
+
+	state = plan
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *IDOverrideUser) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state IDOverrideUserModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.IdoverrideuserShowArgs{
+		Idviewcn:      state.IDView.ValueString(),
+		Ipaanchoruuid: state.Anchor.ValueString(),
+	}
+
+	optArgs := &freeipa.IdoverrideuserShowOptionalArgs{
+		All: freeipa.Bool(true),
+	}
+
+	tflog.Trace(ctx, "Calling IdoverrideuserShow", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	res, err := r.provider.Client().IdoverrideuserShow(args, optArgs)
+
+	tflog.Trace(ctx, "Called IdoverrideuserShow", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if errors.As(err, &freeipaErr) && freeipaErr.Code == freeipa.NotFoundCode {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Failed to read user ID override", "Reason: "+err.Error())
+		return
+	}
+
+	// The anchor is returned as the unique ID of the user, keep its name.
+	state.Description = types.StringPointerValue(res.Result.Description)
+	state.Login = types.StringPointerValue(res.Result.UID)
+	state.UIDNumber = types.Int64PointerValue(int64Pointer(res.Result.Uidnumber))
+	state.GIDNumber = types.Int64PointerValue(int64Pointer(res.Result.Gidnumber))
+	state.Gecos = types.StringPointerValue(res.Result.Gecos)
+	state.HomeDirectory = types.StringPointerValue(res.Result.Homedirectory)
+	state.Shell = types.StringPointerValue(res.Result.Loginshell)
+
+	resp.Diagnostics.Append(state.setSSHPubKeys(ctx, res.Result.Ipasshpubkey)...)
+	resp.Diagnostics.Append(state.setCertificates(ctx, res.Result.Usercertificate)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *IDOverrideUser) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state, plan IDOverrideUserModel
+	var hasDiff bool
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.IdoverrideuserModArgs{
+		Idviewcn:      plan.IDView.ValueString(),
+		Ipaanchoruuid: plan.Anchor.ValueString(),
+	}
+
+	optArgs := &freeipa.IdoverrideuserModOptionalArgs{}
+
+	for _, attr := range []struct {
+		plan, state types.String
+		value       **string
+	}{
+		{plan.Description, state.Description, &optArgs.Description},
+		{plan.Login, state.Login, &optArgs.UID},
+		{plan.Gecos, state.Gecos, &optArgs.Gecos},
+		{plan.HomeDirectory, state.HomeDirectory, &optArgs.Homedirectory},
+		{plan.Shell, state.Shell, &optArgs.Loginshell},
+	} {
+		if !attr.plan.Equal(attr.state) {
+			*attr.value = freeipa.String(attr.plan.ValueString())
+			hasDiff = true
+		}
+	}
+
+	var delAttrs []string
+
+	for _, attr := range []struct {
+		plan, state types.Int64
+		name        string
+		value       **int
+	}{
+		{plan.UIDNumber, state.UIDNumber, "uidnumber", &optArgs.Uidnumber},
+		{plan.GIDNumber, state.GIDNumber, "gidnumber", &optArgs.Gidnumber},
+	} {
+		if attr.plan.Equal(attr.state) {
+			continue
+		}
+
+		// Integer options cannot be emptied, remove the attribute instead.
+		if attr.plan.IsNull() {
+			delAttrs = append(delAttrs, attr.name+"="+attr.state.String())
+		} else {
+			*attr.value = knownInt(attr.plan)
+		}
+
+		hasDiff = true
+	}
+
+	if len(delAttrs) > 0 {
+		optArgs.Delattr = &delAttrs
+	}
+
+	if !plan.SSHPubKeys.Equal(state.SSHPubKeys) {
+		values := []string{}
+
+		resp.Diagnostics.Append(plan.SSHPubKeys.ElementsAs(ctx, &values, false)...)
+
+		optArgs.Ipasshpubkey = &values
+		hasDiff = true
+	}
+
+	if !plan.Certificates.Equal(state.Certificates) {
+		values := []string{}
+
+		resp.Diagnostics.Append(plan.Certificates.ElementsAs(ctx, &values, false)...)
+
+		// An empty list removes all the certificates.
+		optArgs.Usercertificate = &[]interface{}{}
+		if certificates := certificatesPointer(values); certificates != nil {
+			optArgs.Usercertificate = certificates
+		}
+
+		hasDiff = true
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if hasDiff {
+		tflog.Trace(ctx, "Calling IdoverrideuserMod", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		res, err := r.provider.Client().IdoverrideuserMod(args, optArgs)
+
+		tflog.Trace(ctx, "Called IdoverrideuserMod", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		if err != nil {
+			var freeipaErr *freeipa.Error
+
+			if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.EmptyModlistCode {
+				resp.Diagnostics.AddError("Failed to update user ID override", "Reason: "+err.Error())
+				return
+			}
+
+			tflog.Debug(ctx, "Updated user ID override has no effective difference", map[string]any{
+				"args":     args,
+				"opt_args": optArgs,
+			})
+		}
+	}
+
+	state = plan
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *IDOverrideUser) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state IDOverrideUserModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.IdoverrideuserDelArgs{
+		Idviewcn:      state.IDView.ValueString(),
+		Ipaanchoruuid: []string{state.Anchor.ValueString()},
+	}
+
+	tflog.Trace(ctx, "Calling IdoverrideuserDel", map[string]any{
+		"args":     args,
+		"opt_args": nil,
+	})
+
+	res, err := r.provider.Client().IdoverrideuserDel(args, nil)
+
+	tflog.Trace(ctx, "Called IdoverrideuserDel", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.NotFoundCode {
+			resp.Diagnostics.AddError("Failed to delete user ID override", "Reason: "+err.Error())
+			return
+		}
+	}
+}
+
+func (r *IDOverrideUser) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := strings.Split(req.ID, "/")
+
+	if len(id) != 2 {
+		resp.Diagnostics.AddError("Invalid ID format", "Expected ID format is “<ID view>/<anchor>”")
+
+		return
+	}
+
+	state := IDOverrideUserModel{
+		IDView:       types.StringValue(id[0]),
+		Anchor:       types.StringValue(id[1]),
+		SSHPubKeys:   types.SetNull(types.StringType),
+		Certificates: types.SetNull(types.StringType),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func NewIDOverrideUser(p *provider.Provider) resource.Resource {
+	r := &IDOverrideUser{
+		provider: p,
+	}
+
+	var _ resource.Resource = r
+	var _ resource.ResourceWithImportState = r
+
+	return r
+}
+
+func init() {
+	resources = append(resources, NewIDOverrideUser)
+}
+
+// setSSHPubKeys sets the SSH public keys of a user ID override, keeping them
+// in their current form when FreeIPA only normalised their whitespace or
+// comment.
+func (m *IDOverrideUserModel) setSSHPubKeys(ctx context.Context, keys *[]string) (diags diag.Diagnostics) {
+	var currentKeys []string
+
+	diags.Append(m.SSHPubKeys.ElementsAs(ctx, &currentKeys, false)...)
+
+	if keys != nil {
+		keys = normalizedValues(*keys, currentKeys, sshPublicKeyID)
+	}
+
+	var d diag.Diagnostics
+
+	m.SSHPubKeys, d = membersSetValue(ctx, m.SSHPubKeys, keys)
+	diags.Append(d...)
+
+	return
+}
+
+// setCertificates sets the certificates of a user ID override, which
+// FreeIPA returns as bytes, keeping them in their current form when only
+// their line breaks differ.
+func (m *IDOverrideUserModel) setCertificates(ctx context.Context, values *[]interface{}) (diags diag.Diagnostics) {
+	var currentCertificates []string
+
+	diags.Append(m.Certificates.ElementsAs(ctx, &currentCertificates, false)...)
+
+	var certificates *[]string
+
+	if values != nil {
+		var encoded []string
+
+		for _, value := range *values {
+			// The client wraps the list of certificates in another list.
+			items, ok := value.([]any)
+			if !ok {
+				items = []any{value}
+			}
+
+			for _, item := range items {
+				der, err := decodeCertificate(item)
+				if err != nil {
+					diags.AddError("Failed to read user ID override certificate", "Reason: "+err.Error())
+
+					return
+				}
+
+				encoded = append(encoded, base64.StdEncoding.EncodeToString(der))
+			}
+		}
+
+		certificates = normalizedValues(encoded, currentCertificates, func(s string) string {
+			return strings.Join(strings.Fields(s), "")
+		})
+	}
+
+	var d diag.Diagnostics
+
+	m.Certificates, d = membersSetValue(ctx, m.Certificates, certificates)
+	diags.Append(d...)
+
+	return
+}
+
+// certificatesPointer returns a pointer to the given base64 encoded
+// certificates, or nil if there are none.
+func certificatesPointer(certificates []string) *[]interface{} {
+	if len(certificates) == 0 {
+		return nil
+	}
+
+	values := make([]interface{}, 0, len(certificates))
+
+	for _, certificate := range certificates {
+		values = append(values, certificate)
+	}
+
+	return &values
+}
@@ -0,0 +1,54 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestIDOverrideUserRead(t *testing.T) {
+	p := newTestProvider(t, map[string]rpcHandler{
+		"idoverrideuser_show": func(t *testing.T, params map[string]any) string {
+			// The anchor is returned as the unique ID of the user and the
+			// certificates as bytes.
+			return rpcResult(map[string]any{
+				"summary": nil,
+				"value":   "jdoe",
+				"result": map[string]any{
+					"ipaanchoruuid":   []string{":IPA:example.test:0c2ad7d0-1a4c-11ef-9d4b-525400c2c1b3"},
+					"uid":             []string{"john"},
+					"uidnumber":       []string{"10001"},
+					"loginshell":      []string{"/bin/zsh"},
+					"ipasshpubkey":    []string{"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIGk john@example.test"},
+					"usercertificate": []any{map[string]any{"__base64__": "Y2VydDE="}, map[string]any{"__base64__": "Y2VydDI="}},
+				},
+			})
+		},
+	})
+
+	ctx := context.Background()
+
+	model := testRead(t, NewIDOverrideUser(p), IDOverrideUserModel{
+		IDView:       types.StringValue("legacy"),
+		Anchor:       types.StringValue("jdoe"),
+		Login:        types.StringValue("john"),
+		UIDNumber:    types.Int64Value(10001),
+		SSHPubKeys:   types.SetValueMust(types.StringType, []attr.Value{types.StringValue("ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIGk")}),
+		Certificates: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("Y2Vy\ndDE=")}),
+	})
+
+	sshPubKeys, _ := types.SetValueFrom(ctx, types.StringType, []string{"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIGk"})
+	certificates, _ := types.SetValueFrom(ctx, types.StringType, []string{"Y2Vy\ndDE=", "Y2VydDI="})
+
+	testValues(t, map[string]testValue{
+		"anchor":       {model.Anchor, types.StringValue("jdoe")},
+		"login":        {model.Login, types.StringValue("john")},
+		"uid_number":   {model.UIDNumber, types.Int64Value(10001)},
+		"gid_number":   {model.GIDNumber, types.Int64Null()},
+		"shell":        {model.Shell, types.StringValue("/bin/zsh")},
+		"sshpubkeys":   {model.SSHPubKeys, sshPubKeys},
+		"certificates": {model.Certificates, certificates},
+	})
+}
@@ -0,0 +1,381 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/camptocamp/go-freeipa/freeipa"
+	"github.com/camptocamp/terraform-provider-freeipa/internal/provider"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var idpTemplates = []string{"keycloak", "google", "github", "microsoft", "okta"}
+
+type IdP struct {
+	provider *provider.Provider
+}
+
+type IdPModel struct {
+	Name         types.String `tfsdk:"cn"`
+	Template     types.String `tfsdk:"template"`
+	Organization types.String `tfsdk:"organization"`
+	BaseURL      types.String `tfsdk:"base_url"`
+	ClientID     types.String `tfsdk:"client_id"`
+	ClientSecret types.String `tfsdk:"client_secret"`
+	AuthURI      types.String `tfsdk:"auth_uri"`
+	DevAuthURI   types.String `tfsdk:"dev_auth_uri"`
+	TokenURI     types.String `tfsdk:"token_uri"`
+	UserInfoURI  types.String `tfsdk:"userinfo_uri"`
+	KeysURI      types.String `tfsdk:"keys_uri"`
+	IssuerURL    types.String `tfsdk:"issuer_url"`
+	Scope        types.String `tfsdk:"scope"`
+	IdPUserID    types.String `tfsdk:"idp_user_id"`
+}
+
+func (r *IdP) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_idp"
+}
+
+func (r *IdP) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	templateAttribute := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{
+			Description: description,
+			Optional:    true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		}
+	}
+
+	endpointAttribute := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{
+			Description: description,
+			Optional:    true,
+			Computed:    true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Version:     0,
+		Description: "Manages an external identity provider users can authenticate with through the OAuth 2.0 device authorization flow",
+		Attributes: map[string]schema.Attribute{
+			"cn": schema.StringAttribute{
+				Description: "Identity provider name",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"template":     templateAttribute("Template of a known provider filling the endpoints on creation, one of “keycloak”, “google”, “github”, “microsoft” or “okta”"),
+			"organization": templateAttribute("Organization ID or realm name for the provider templates"),
+			"base_url":     templateAttribute("Base URL for the provider templates"),
+			"client_id": schema.StringAttribute{
+				Description: "OAuth 2.0 client identifier",
+				Required:    true,
+			},
+			"client_secret": schema.StringAttribute{
+				Description: "OAuth 2.0 client secret",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"auth_uri":     endpointAttribute("OAuth 2.0 authorization endpoint"),
+			"dev_auth_uri": endpointAttribute("Device authorization endpoint"),
+			"token_uri":    endpointAttribute("Token endpoint"),
+			"userinfo_uri": endpointAttribute("User information endpoint"),
+			"keys_uri":     endpointAttribute("JWKS endpoint"),
+			"issuer_url":   endpointAttribute("The identity provider OIDC URL"),
+			"scope":        endpointAttribute("OAuth 2.0 scope, multiple scopes are separated by spaces"),
+			"idp_user_id":  endpointAttribute("Attribute for user identity in OAuth 2.0 userinfo"),
+		},
+	}
+}
+
+func (r *IdP) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config IdPModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Template.IsNull() && !config.Template.IsUnknown() {
+		if !slices.Contains(idpTemplates, config.Template.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("template"),
+				"Invalid configuration",
+				"“template” must be one of: "+strings.Join(idpTemplates, ", ")+".",
+			)
+		}
+	} else if !config.Organization.IsNull() || !config.BaseURL.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("template"),
+			"Invalid configuration",
+			"“template” must be set when “organization” or “base_url” is set.",
+		)
+	}
+}
+
+func (r *IdP) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan, state IdPModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.IdpAddArgs{
+		Cn:             plan.Name.ValueString(),
+		Ipaidpclientid: plan.ClientID.ValueString(),
+	}
+
+	optArgs := &freeipa.IdpAddOptionalArgs{
+		Ipaidpprovider:         plan.Template.ValueStringPointer(),
+		Ipaidporg:              plan.Organization.ValueStringPointer(),
+		Ipaidpbaseurl:          plan.BaseURL.ValueStringPointer(),
+		Ipaidpclientsecret:     plan.ClientSecret.ValueStringPointer(),
+		Ipaidpauthendpoint:     knownString(plan.AuthURI),
+		Ipaidpdevauthendpoint:  knownString(plan.DevAuthURI),
+		Ipaidptokenendpoint:    knownString(plan.TokenURI),
+		Ipaidpuserinfoendpoint: knownString(plan.UserInfoURI),
+		Ipaidpkeysendpoint:     knownString(plan.KeysURI),
+		Ipaidpissuerurl:        knownString(plan.IssuerURL),
+		Ipaidpscope:            knownString(plan.Scope),
+		Ipaidpsub:              knownString(plan.IdPUserID),
+		All:                    freeipa.Bool(true),
+	}
+
+	// Do not log the client secret.
+	loggedOptArgs := *optArgs
+	loggedOptArgs.Ipaidpclientsecret = nil
+
+	tflog.Trace(ctx, "Calling IdpAdd", map[string]any{
+		"args":     args,
+		"opt_args": loggedOptArgs,
+	})
+
+	var res rpcEntryResult
+
+	err := r.provider.Call("idp_add", nil, struct {
+		*freeipa.IdpAddArgs
+		*freeipa.IdpAddOptionalArgs
+	}{args, optArgs}, &res)
+	delete(res.Result, "ipaidpclientsecret")
+	tflog.Trace(ctx, "Called IdpAdd", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create identity provider", "Reason: "+err.Error())
+		return
+	}
+
+	state = plan
+	state.readEntry(res.Result)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *IdP) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state IdPModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.IdpShowArgs{
+		Cn: state.Name.ValueString(),
+	}
+
+	optArgs := &freeipa.IdpShowOptionalArgs{
+		All: freeipa.Bool(true),
+	}
+
+	tflog.Trace(ctx, "Calling IdpShow", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	var res rpcEntryResult
+
+	err := r.provider.Call("idp_show", []any{args.Cn}, optArgs, &res)
+	delete(res.Result, "ipaidpclientsecret")
+	tflog.Trace(ctx, "Called IdpShow", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if errors.As(err, &freeipaErr) && freeipaErr.Code == freeipa.NotFoundCode {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Failed to read identity provider", "Reason: "+err.Error())
+		return
+	}
+
+	state.ClientID = types.StringPointerValue(entryString(res.Result, "ipaidpclientid"))
+	state.readEntry(res.Result)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *IdP) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state, plan IdPModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var hasDiff bool
+
+	args := &freeipa.IdpModArgs{
+		Cn: state.Name.ValueString(),
+	}
+
+	optArgs := &freeipa.IdpModOptionalArgs{}
+
+	for _, attr := range []struct {
+		plan, state types.String
+		value       **string
+	}{
+		{plan.ClientID, state.ClientID, &optArgs.Ipaidpclientid},
+		{plan.ClientSecret, state.ClientSecret, &optArgs.Ipaidpclientsecret},
+		{plan.AuthURI, state.AuthURI, &optArgs.Ipaidpauthendpoint},
+		{plan.DevAuthURI, state.DevAuthURI, &optArgs.Ipaidpdevauthendpoint},
+		{plan.TokenURI, state.TokenURI, &optArgs.Ipaidptokenendpoint},
+		{plan.UserInfoURI, state.UserInfoURI, &optArgs.Ipaidpuserinfoendpoint},
+		{plan.KeysURI, state.KeysURI, &optArgs.Ipaidpkeysendpoint},
+		{plan.IssuerURL, state.IssuerURL, &optArgs.Ipaidpissuerurl},
+		{plan.Scope, state.Scope, &optArgs.Ipaidpscope},
+		{plan.IdPUserID, state.IdPUserID, &optArgs.Ipaidpsub},
+	} {
+		if !attr.plan.Equal(attr.state) {
+			*attr.value = freeipa.String(attr.plan.ValueString())
+			hasDiff = true
+		}
+	}
+
+	if hasDiff {
+		// Do not log the client secret.
+		loggedOptArgs := *optArgs
+		loggedOptArgs.Ipaidpclientsecret = nil
+
+		tflog.Trace(ctx, "Calling IdpMod", map[string]any{
+			"args":     args,
+			"opt_args": loggedOptArgs,
+		})
+
+		err := r.provider.Call("idp_mod", []any{args.Cn}, optArgs, nil)
+		tflog.Trace(ctx, "Called IdpMod", map[string]any{
+			"err": err,
+		})
+
+		if err != nil {
+			var freeipaErr *freeipa.Error
+
+			if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.EmptyModlistCode {
+				resp.Diagnostics.AddError("Failed to update identity provider", "Reason: "+err.Error())
+				return
+			}
+
+			tflog.Debug(ctx, "Updated identity provider has no effective difference", map[string]any{
+				"args":     args,
+				"opt_args": loggedOptArgs,
+			})
+		}
+	}
+
+	state = plan
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *IdP) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state IdPModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.IdpDelArgs{
+		Cn: []string{state.Name.ValueString()},
+	}
+
+	tflog.Trace(ctx, "Calling IdpDel", map[string]any{
+		"args":     args,
+		"opt_args": nil,
+	})
+
+	res, err := r.provider.Client().IdpDel(args, nil)
+
+	tflog.Trace(ctx, "Called IdpDel", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.NotFoundCode {
+			resp.Diagnostics.AddError("Failed to delete identity provider", "Reason: "+err.Error())
+			return
+		}
+	}
+}
+
+func (r *IdP) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	state := IdPModel{
+		Name: types.StringValue(req.ID),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func NewIdP(p *provider.Provider) resource.Resource {
+	r := &IdP{
+		provider: p,
+	}
+
+	var _ resource.Resource = r
+	var _ resource.ResourceWithValidateConfig = r
+	var _ resource.ResourceWithImportState = r
+
+	return r
+}
+
+func init() {
+	resources = append(resources, NewIdP)
+}
+
+// readEntry sets the endpoints of the model from an identity provider entry
+// returned by a raw call. The client secret is never read back.
+func (m *IdPModel) readEntry(entry map[string]any) {
+	m.AuthURI = types.StringPointerValue(entryString(entry, "ipaidpauthendpoint"))
+	m.DevAuthURI = types.StringPointerValue(entryString(entry, "ipaidpdevauthendpoint"))
+	m.TokenURI = types.StringPointerValue(entryString(entry, "ipaidptokenendpoint"))
+	m.UserInfoURI = types.StringPointerValue(entryString(entry, "ipaidpuserinfoendpoint"))
+	m.KeysURI = types.StringPointerValue(entryString(entry, "ipaidpkeysendpoint"))
+	m.IssuerURL = types.StringPointerValue(entryString(entry, "ipaidpissuerurl"))
+	m.Scope = types.StringPointerValue(entryString(entry, "ipaidpscope"))
+	m.IdPUserID = types.StringPointerValue(entryString(entry, "ipaidpsub"))
+}
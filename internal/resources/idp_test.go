@@ -0,0 +1,47 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestIdPRead(t *testing.T) {
+	p := newTestProvider(t, map[string]rpcHandler{
+		"idp_show": func(t *testing.T, params map[string]any) string {
+			// The client secret is returned as bytes, which the client fails
+			// to decode.
+			return rpcResult(map[string]any{
+				"summary": nil,
+				"value":   "azure",
+				"result": map[string]any{
+					"cn":                     []string{"azure"},
+					"ipaidpclientid":         []string{"c0ffee"},
+					"ipaidpclientsecret":     []any{map[string]any{"__base64__": "c2VjcmV0"}},
+					"ipaidpdevauthendpoint":  []string{"https://login.microsoftonline.com/example/oauth2/v2.0/devicecode"},
+					"ipaidptokenendpoint":    []string{"https://login.microsoftonline.com/example/oauth2/v2.0/token"},
+					"ipaidpuserinfoendpoint": []string{"https://graph.microsoft.com/oidc/userinfo"},
+					"ipaidpscope":            []string{"openid email"},
+					"ipaidpsub":              []string{"email"},
+				},
+			})
+		},
+	})
+
+	model := testRead(t, NewIdP(p), IdPModel{
+		Name:         types.StringValue("azure"),
+		Template:     types.StringValue("microsoft"),
+		Organization: types.StringValue("example"),
+		ClientID:     types.StringValue("c0ffee"),
+		ClientSecret: types.StringValue("secret"),
+	})
+
+	testValues(t, map[string]testValue{
+		"template":      {model.Template, types.StringValue("microsoft")},
+		"client_secret": {model.ClientSecret, types.StringValue("secret")},
+		"token_uri":     {model.TokenURI, types.StringValue("https://login.microsoftonline.com/example/oauth2/v2.0/token")},
+		"scope":         {model.Scope, types.StringValue("openid email")},
+		"idp_user_id":   {model.IdPUserID, types.StringValue("email")},
+		"auth_uri":      {model.AuthURI, types.StringNull()},
+	})
+}
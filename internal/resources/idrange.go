@@ -0,0 +1,457 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/camptocamp/go-freeipa/freeipa"
+	"github.com/camptocamp/terraform-provider-freeipa/internal/provider"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var idRangeTypes = []string{"ipa-local", "ipa-ad-trust", "ipa-ad-trust-posix"}
+
+// idRangeTypeDescriptions maps the descriptions FreeIPA returns in place of
+// the range types to the types.
+var idRangeTypeDescriptions = map[string]string{
+	"local domain range":                                 "ipa-local",
+	"Active Directory domain range":                      "ipa-ad-trust",
+	"Active Directory trust range with POSIX attributes": "ipa-ad-trust-posix",
+}
+
+var idRangeAutoPrivateGroups = []string{"true", "false", "hybrid"}
+
+type IDRange struct {
+	provider *provider.Provider
+}
+
+type IDRangeModel struct {
+	Name              types.String `tfsdk:"cn"`
+	Type              types.String `tfsdk:"type"`
+	BaseID            types.Int64  `tfsdk:"base_id"`
+	Size              types.Int64  `tfsdk:"size"`
+	RIDBase           types.Int64  `tfsdk:"rid_base"`
+	SecondaryRIDBase  types.Int64  `tfsdk:"secondary_rid_base"`
+	DomainSID         types.String `tfsdk:"domain_sid"`
+	DomainName        types.String `tfsdk:"domain_name"`
+	AutoPrivateGroups types.String `tfsdk:"auto_private_groups"`
+}
+
+func (r *IDRange) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_idrange"
+}
+
+func (r *IDRange) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version:     0,
+		Description: "Manages a range of POSIX IDs, local or mapped from the SIDs of a trusted Active Directory domain",
+		Attributes: map[string]schema.Attribute{
+			"cn": schema.StringAttribute{
+				Description: "ID range name",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Description: "ID range type, “ipa-local”, “ipa-ad-trust” or “ipa-ad-trust-posix”, guessed from the domain when unset",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"base_id": schema.Int64Attribute{
+				Description: "First POSIX ID of the range",
+				Required:    true,
+			},
+			"size": schema.Int64Attribute{
+				Description: "Number of IDs in the range",
+				Required:    true,
+			},
+			"rid_base": schema.Int64Attribute{
+				Description: "First RID of the corresponding RID range",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"secondary_rid_base": schema.Int64Attribute{
+				Description: "First RID of the secondary RID range, local ranges only",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"domain_sid": schema.StringAttribute{
+				Description: "SID of the trusted domain, resolved from “domain_name” when unset",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"domain_name": schema.StringAttribute{
+				Description: "Name of the trusted domain, only used to resolve its SID",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"auto_private_groups": schema.StringAttribute{
+				Description: "Automatic creation of private groups, “true”, “false” or “hybrid”, trusted domain ranges only",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *IDRange) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config IDRangeModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	trusted := !config.DomainSID.IsNull() || !config.DomainName.IsNull()
+
+	if !config.Type.IsNull() && !config.Type.IsUnknown() {
+		if !slices.Contains(idRangeTypes, config.Type.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("type"),
+				"Invalid configuration",
+				"“type” must be one of: "+strings.Join(idRangeTypes, ", ")+".",
+			)
+		} else if config.Type.ValueString() == "ipa-local" {
+			trusted = false
+
+			for name, value := range map[string]types.String{
+				"domain_sid":          config.DomainSID,
+				"domain_name":         config.DomainName,
+				"auto_private_groups": config.AutoPrivateGroups,
+			} {
+				if !value.IsNull() {
+					resp.Diagnostics.AddAttributeError(
+						path.Root(name),
+						"Invalid configuration",
+						"“"+name+"” cannot be set for local ranges.",
+					)
+				}
+			}
+		} else if !trusted {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("domain_sid"),
+				"Invalid configuration",
+				"“domain_sid” or “domain_name” must be set for trusted domain ranges.",
+			)
+		}
+	}
+
+	if trusted && !config.SecondaryRIDBase.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("secondary_rid_base"),
+			"Invalid configuration",
+			"“secondary_rid_base” cannot be set for trusted domain ranges.",
+		)
+	}
+
+	if !config.DomainSID.IsNull() && !config.DomainName.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("domain_name"),
+			"Invalid configuration",
+			"“domain_name” cannot be set along with “domain_sid”.",
+		)
+	}
+
+	if !config.AutoPrivateGroups.IsNull() && !config.AutoPrivateGroups.IsUnknown() {
+		if !slices.Contains(idRangeAutoPrivateGroups, config.AutoPrivateGroups.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("auto_private_groups"),
+				"Invalid configuration",
+				"“auto_private_groups” must be one of: "+strings.Join(idRangeAutoPrivateGroups, ", ")+".",
+			)
+		}
+	}
+}
+
+func (r *IDRange) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan, state IDRangeModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.IdrangeAddArgs{
+		Cn:             plan.Name.ValueString(),
+		Ipabaseid:      int(plan.BaseID.ValueInt64()),
+		Ipaidrangesize: int(plan.Size.ValueInt64()),
+	}
+
+	optArgs := &freeipa.IdrangeAddOptionalArgs{
+		Iparangetype:           knownString(plan.Type),
+		Ipabaserid:             knownInt(plan.RIDBase),
+		Ipasecondarybaserid:    knownInt(plan.SecondaryRIDBase),
+		Ipanttrusteddomainsid:  knownString(plan.DomainSID),
+		Ipanttrusteddomainname: plan.DomainName.ValueStringPointer(),
+		Ipaautoprivategroups:   plan.AutoPrivateGroups.ValueStringPointer(),
+		All:                    freeipa.Bool(true),
+	}
+
+	tflog.Trace(ctx, "Calling IdrangeAdd", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	res, err := r.provider.Client().IdrangeAdd(args, optArgs)
+
+	tflog.Trace(ctx, "Called IdrangeAdd", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create ID range", "Reason: "+err.Error())
+		return
+	}
+
+	state = plan
+	state.readEntry(&res.Result)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *IDRange) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state IDRangeModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.IdrangeShowArgs{
+		Cn: state.Name.ValueString(),
+	}
+
+	optArgs := &freeipa.IdrangeShowOptionalArgs{
+		All: freeipa.Bool(true),
+	}
+
+	tflog.Trace(ctx, "Calling IdrangeShow", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	res, err := r.provider.Client().IdrangeShow(args, optArgs)
+
+	tflog.Trace(ctx, "Called IdrangeShow", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if errors.As(err, &freeipaErr) && freeipaErr.Code == freeipa.NotFoundCode {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Failed to read ID range", "Reason: "+err.Error())
+		return
+	}
+
+	state.readEntry(&res.Result)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *IDRange) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state, plan IDRangeModel
+	var hasDiff bool
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keep the computed values FreeIPA did not set, such as the RID bases of
+	// ranges it does not use them for.
+	for _, attr := range []struct {
+		plan  *types.Int64
+		state types.Int64
+	}{
+		{&plan.RIDBase, state.RIDBase},
+		{&plan.SecondaryRIDBase, state.SecondaryRIDBase},
+	} {
+		if attr.plan.IsUnknown() {
+			*attr.plan = attr.state
+		}
+	}
+
+	for _, attr := range []struct {
+		plan  *types.String
+		state types.String
+	}{
+		{&plan.Type, state.Type},
+		{&plan.DomainSID, state.DomainSID},
+	} {
+		if attr.plan.IsUnknown() {
+			*attr.plan = attr.state
+		}
+	}
+
+	args := &freeipa.IdrangeModArgs{
+		Cn: plan.Name.ValueString(),
+	}
+
+	optArgs := &freeipa.IdrangeModOptionalArgs{
+		All: freeipa.Bool(true),
+	}
+
+	for _, attr := range []struct {
+		plan, state types.Int64
+		value       **int
+	}{
+		{plan.BaseID, state.BaseID, &optArgs.Ipabaseid},
+		{plan.Size, state.Size, &optArgs.Ipaidrangesize},
+		{plan.RIDBase, state.RIDBase, &optArgs.Ipabaserid},
+		{plan.SecondaryRIDBase, state.SecondaryRIDBase, &optArgs.Ipasecondarybaserid},
+	} {
+		if !attr.plan.Equal(attr.state) {
+			*attr.value = knownInt(attr.plan)
+			hasDiff = true
+		}
+	}
+
+	if !plan.AutoPrivateGroups.Equal(state.AutoPrivateGroups) {
+		optArgs.Ipaautoprivategroups = freeipa.String(plan.AutoPrivateGroups.ValueString())
+		hasDiff = true
+	}
+
+	if hasDiff {
+		tflog.Trace(ctx, "Calling IdrangeMod", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		res, err := r.provider.Client().IdrangeMod(args, optArgs)
+
+		tflog.Trace(ctx, "Called IdrangeMod", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		if err != nil {
+			var freeipaErr *freeipa.Error
+
+			if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.EmptyModlistCode {
+				resp.Diagnostics.AddError("Failed to update ID range", "Reason: "+err.Error())
+				return
+			}
+
+			tflog.Debug(ctx, "Updated ID range has no effective difference", map[string]any{
+				"args":     args,
+				"opt_args": optArgs,
+			})
+		}
+	}
+
+	state = plan
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *IDRange) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state IDRangeModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.IdrangeDelArgs{
+		Cn: []string{state.Name.ValueString()},
+	}
+
+	tflog.Trace(ctx, "Calling IdrangeDel", map[string]any{
+		"args":     args,
+		"opt_args": nil,
+	})
+
+	res, err := r.provider.Client().IdrangeDel(args, nil)
+
+	tflog.Trace(ctx, "Called IdrangeDel", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.NotFoundCode {
+			resp.Diagnostics.AddError("Failed to delete ID range", "Reason: "+err.Error())
+			return
+		}
+	}
+}
+
+func (r *IDRange) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	state := IDRangeModel{
+		Name: types.StringValue(req.ID),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func NewIDRange(p *provider.Provider) resource.Resource {
+	r := &IDRange{
+		provider: p,
+	}
+
+	var _ resource.Resource = r
+	var _ resource.ResourceWithValidateConfig = r
+	var _ resource.ResourceWithImportState = r
+
+	return r
+}
+
+func init() {
+	resources = append(resources, NewIDRange)
+}
+
+// readEntry sets the model from an ID range returned by FreeIPA. The domain
+// name is only used to resolve the domain SID and is not read back.
+func (m *IDRangeModel) readEntry(entry *freeipa.Idrange) {
+	m.BaseID = types.Int64Value(int64(entry.Ipabaseid))
+	m.Size = types.Int64Value(int64(entry.Ipaidrangesize))
+	m.RIDBase = types.Int64PointerValue(int64Pointer(entry.Ipabaserid))
+	m.SecondaryRIDBase = types.Int64PointerValue(int64Pointer(entry.Ipasecondarybaserid))
+	m.DomainSID = types.StringPointerValue(entry.Ipanttrusteddomainsid)
+	m.AutoPrivateGroups = types.StringPointerValue(entry.Ipaautoprivategroups)
+
+	if entry.Iparangetype != nil {
+		rangeType := *entry.Iparangetype
+
+		if t, ok := idRangeTypeDescriptions[rangeType]; ok {
+			rangeType = t
+		}
+
+		m.Type = types.StringValue(rangeType)
+	}
+}
@@ -0,0 +1,409 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/camptocamp/go-freeipa/freeipa"
+	"github.com/camptocamp/terraform-provider-freeipa/internal/provider"
+	"github.com/camptocamp/terraform-provider-freeipa/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type IDView struct {
+	provider *provider.Provider
+}
+
+type IDViewModel struct {
+	Name                  types.String `tfsdk:"cn"`
+	Description           types.String `tfsdk:"description"`
+	DomainResolutionOrder types.String `tfsdk:"domain_resolution_order"`
+	Hosts                 types.Set    `tfsdk:"hosts"`
+	Hostgroups            types.Set    `tfsdk:"hostgroups"`
+}
+
+func (r *IDView) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_idview"
+}
+
+func (r *IDView) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version:     0,
+		Description: "Manages an ID view, overriding POSIX attributes of users and groups on the hosts it is applied to",
+		Attributes: map[string]schema.Attribute{
+			"cn": schema.StringAttribute{
+				Description: "ID view name",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description: "ID view description",
+				Optional:    true,
+			},
+			"domain_resolution_order": schema.StringAttribute{
+				Description: "Colon-separated list of domains used for short name qualification on the hosts of the view",
+				Optional:    true,
+			},
+			"hosts": schema.SetAttribute{
+				Description: "Hosts the ID view is applied to",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"hostgroups": schema.SetAttribute{
+				Description: "Host groups to whose hosts the ID view is applied, hosts added to the groups later on are not affected",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *IDView) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan, state IDViewModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var hosts, hostgroups []string
+
+	resp.Diagnostics.Append(plan.Hosts.ElementsAs(ctx, &hosts, false)...)
+	resp.Diagnostics.Append(plan.Hostgroups.ElementsAs(ctx, &hostgroups, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.IdviewAddArgs{
+		Cn: plan.Name.ValueString(),
+	}
+
+	optArgs := &freeipa.IdviewAddOptionalArgs{
+		Description:              plan.Description.ValueStringPointer(),
+		Ipadomainresolutionorder: plan.DomainResolutionOrder.ValueStringPointer(),
+	}
+
+	tflog.Trace(ctx, "Calling IdviewAdd", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	err := r.provider.Call("idview_add", nil, struct {
+		*freeipa.IdviewAddArgs
+		*freeipa.IdviewAddOptionalArgs
+	}{args, optArgs}, nil)
+	tflog.Trace(ctx, "Called IdviewAdd", map[string]any{
+		"err": err,
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create ID view", "Reason: "+err.Error())
+		return
+	}
+
+	state = plan
+
+	saveCreated(ctx, resp, state)
+
+	resp.Diagnostics.Append(r.apply(ctx, plan.Name.ValueString(), hosts, hostgroups)...)
+}
+
+func (r *IDView) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state IDViewModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.IdviewShowArgs{
+		Cn: state.Name.ValueString(),
+	}
+
+	optArgs := &freeipa.IdviewShowOptionalArgs{
+		All:       freeipa.Bool(true),
+		ShowHosts: freeipa.Bool(true),
+	}
+
+	tflog.Trace(ctx, "Calling IdviewShow", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	var res rpcEntryResult
+
+	err := r.provider.Call("idview_show", []any{args.Cn}, optArgs, &res)
+	tflog.Trace(ctx, "Called IdviewShow", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if errors.As(err, &freeipaErr) && freeipaErr.Code == freeipa.NotFoundCode {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Failed to read ID view", "Reason: "+err.Error())
+		return
+	}
+
+	var currentHosts []string
+
+	resp.Diagnostics.Append(state.Hosts.ElementsAs(ctx, &currentHosts, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The view is also applied to the hosts of its host groups, only keep
+	// track of the configured hosts it is no longer applied to. Host groups
+	// are not recorded on the view and cannot be read back.
+	appliedHosts := entryStrings(res.Result, "appliedtohosts")
+	hosts := slices.DeleteFunc(currentHosts, func(host string) bool {
+		return !slices.Contains(appliedHosts, host)
+	})
+
+	var diags diag.Diagnostics
+
+	state.Description = types.StringPointerValue(entryString(res.Result, "description"))
+	state.DomainResolutionOrder = types.StringPointerValue(entryString(res.Result, "ipadomainresolutionorder"))
+
+	state.Hosts, diags = membersSetValue(ctx, state.Hosts, &hosts)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *IDView) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state, plan IDViewModel
+	var hasDiff bool
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var actualHosts, desiredHosts, actualHostgroups, desiredHostgroups []string
+
+	resp.Diagnostics.Append(state.Hosts.ElementsAs(ctx, &actualHosts, false)...)
+	resp.Diagnostics.Append(plan.Hosts.ElementsAs(ctx, &desiredHosts, false)...)
+	resp.Diagnostics.Append(state.Hostgroups.ElementsAs(ctx, &actualHostgroups, false)...)
+	resp.Diagnostics.Append(plan.Hostgroups.ElementsAs(ctx, &desiredHostgroups, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.IdviewModArgs{
+		Cn: plan.Name.ValueString(),
+	}
+
+	optArgs := &freeipa.IdviewModOptionalArgs{}
+
+	for _, attr := range []struct {
+		plan, state types.String
+		value       **string
+	}{
+		{plan.Description, state.Description, &optArgs.Description},
+		{plan.DomainResolutionOrder, state.DomainResolutionOrder, &optArgs.Ipadomainresolutionorder},
+	} {
+		if !attr.plan.Equal(attr.state) {
+			*attr.value = freeipa.String(attr.plan.ValueString())
+			hasDiff = true
+		}
+	}
+
+	if hasDiff {
+		tflog.Trace(ctx, "Calling IdviewMod", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		err := r.provider.Call("idview_mod", []any{args.Cn}, optArgs, nil)
+		tflog.Trace(ctx, "Called IdviewMod", map[string]any{
+			"err": err,
+		})
+
+		if err != nil {
+			var freeipaErr *freeipa.Error
+
+			if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.EmptyModlistCode {
+				resp.Diagnostics.AddError("Failed to update ID view", "Reason: "+err.Error())
+				return
+			}
+
+			tflog.Debug(ctx, "Updated ID view has no effective difference", map[string]any{
+				"args":     args,
+				"opt_args": optArgs,
+			})
+		}
+	}
+
+	hostsToApply, hostsToUnapply := utils.SetDiff(actualHosts, desiredHosts)
+	hostgroupsToApply, hostgroupsToUnapply := utils.SetDiff(actualHostgroups, desiredHostgroups)
+
+	// Unapply first, as unapplying a host group clears the view of all its
+	// hosts, including the ones the view is still applied to directly.
+	resp.Diagnostics.Append(r.unapply(ctx, hostsToUnapply, hostgroupsToUnapply)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(hostgroupsToUnapply) > 0 {
+		hostsToApply = desiredHosts
+		hostgroupsToApply = desiredHostgroups
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, plan.Name.ValueString(), hostsToApply, hostgroupsToApply)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state = plan
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *IDView) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state IDViewModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.IdviewDelArgs{
+		Cn: []string{state.Name.ValueString()},
+	}
+
+	tflog.Trace(ctx, "Calling IdviewDel", map[string]any{
+		"args":     args,
+		"opt_args": nil,
+	})
+
+	res, err := r.provider.Client().IdviewDel(args, nil)
+
+	tflog.Trace(ctx, "Called IdviewDel", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.NotFoundCode {
+			resp.Diagnostics.AddError("Failed to delete ID view", "Reason: "+err.Error())
+			return
+		}
+	}
+}
+
+func (r *IDView) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	state := IDViewModel{
+		Name:       types.StringValue(req.ID),
+		Hosts:      types.SetNull(types.StringType),
+		Hostgroups: types.SetNull(types.StringType),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func NewIDView(p *provider.Provider) resource.Resource {
+	r := &IDView{
+		provider: p,
+	}
+
+	var _ resource.Resource = r
+	var _ resource.ResourceWithImportState = r
+
+	return r
+}
+
+func init() {
+	resources = append(resources, NewIDView)
+}
+
+func (r *IDView) apply(ctx context.Context, cn string, hosts, hostgroups []string) (diags diag.Diagnostics) {
+	if len(hosts) == 0 && len(hostgroups) == 0 {
+		return
+	}
+
+	args := &freeipa.IdviewApplyArgs{
+		Cn: cn,
+	}
+
+	optArgs := &freeipa.IdviewApplyOptionalArgs{
+		Host:      membersPointer(hosts),
+		Hostgroup: membersPointer(hostgroups),
+	}
+
+	tflog.Trace(ctx, "Calling IdviewApply", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	res, err := r.provider.Client().IdviewApply(args, optArgs)
+
+	tflog.Trace(ctx, "Called IdviewApply", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	var failed freeipa.FailedOperations
+	if res != nil {
+		failed = res.Failed
+	}
+
+	diags.Append(membershipDiags("Failed to apply ID view", failed, err)...)
+
+	return
+}
+
+func (r *IDView) unapply(ctx context.Context, hosts, hostgroups []string) (diags diag.Diagnostics) {
+	if len(hosts) == 0 && len(hostgroups) == 0 {
+		return
+	}
+
+	args := &freeipa.IdviewUnapplyArgs{}
+
+	optArgs := &freeipa.IdviewUnapplyOptionalArgs{
+		Host:      membersPointer(hosts),
+		Hostgroup: membersPointer(hostgroups),
+	}
+
+	tflog.Trace(ctx, "Calling IdviewUnapply", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	res, err := r.provider.Client().IdviewUnapply(args, optArgs)
+
+	tflog.Trace(ctx, "Called IdviewUnapply", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	var failed freeipa.FailedOperations
+	if res != nil {
+		failed = res.Failed
+	}
+
+	diags.Append(membershipDiags("Failed to unapply ID view", failed, err)...)
+
+	return
+}
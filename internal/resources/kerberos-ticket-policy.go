@@ -0,0 +1,323 @@
+package resources
+
+import (
+	"context"
+	"errors"
+
+	"github.com/camptocamp/go-freeipa/freeipa"
+	"github.com/camptocamp/terraform-provider-freeipa/internal/provider"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// globalKerberosTicketPolicyID is the import ID of the global Kerberos ticket
+// policy.
+const globalKerberosTicketPolicyID = "global"
+
+type KerberosTicketPolicy struct {
+	provider *provider.Provider
+}
+
+type KerberosTicketPolicyModel struct {
+	UID              types.String `tfsdk:"uid"`
+	MaxLife          types.Int64  `tfsdk:"maxlife"`
+	MaxRenew         types.Int64  `tfsdk:"maxrenew"`
+	OTPMaxLife       types.Int64  `tfsdk:"otp_maxlife"`
+	OTPMaxRenew      types.Int64  `tfsdk:"otp_maxrenew"`
+	RadiusMaxLife    types.Int64  `tfsdk:"radius_maxlife"`
+	RadiusMaxRenew   types.Int64  `tfsdk:"radius_maxrenew"`
+	PKINITMaxLife    types.Int64  `tfsdk:"pkinit_maxlife"`
+	PKINITMaxRenew   types.Int64  `tfsdk:"pkinit_maxrenew"`
+	HardenedMaxLife  types.Int64  `tfsdk:"hardened_maxlife"`
+	HardenedMaxRenew types.Int64  `tfsdk:"hardened_maxrenew"`
+	IdPMaxLife       types.Int64  `tfsdk:"idp_maxlife"`
+	IdPMaxRenew      types.Int64  `tfsdk:"idp_maxrenew"`
+}
+
+func (r *KerberosTicketPolicy) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_kerberos_ticket_policy"
+}
+
+func (r *KerberosTicketPolicy) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	lifetimeAttribute := func(description string) schema.Int64Attribute {
+		return schema.Int64Attribute{
+			Description: description,
+			Optional:    true,
+			Computed:    true,
+			PlanModifiers: []planmodifier.Int64{
+				int64planmodifier.UseStateForUnknown(),
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Version:     0,
+		Description: "Manages the global Kerberos ticket policy or the ticket policy of a user. Destroying the resource resets the policy to its defaults. Import with the user name, or “global” for the global policy",
+		Attributes: map[string]schema.Attribute{
+			"uid": schema.StringAttribute{
+				Description: "User the policy applies to, the global policy is managed when unset",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"maxlife":           lifetimeAttribute("Maximum ticket life (seconds)"),
+			"maxrenew":          lifetimeAttribute("Maximum renewable age (seconds)"),
+			"otp_maxlife":       lifetimeAttribute("OTP token maximum ticket life (seconds)"),
+			"otp_maxrenew":      lifetimeAttribute("OTP token ticket maximum renewable age (seconds)"),
+			"radius_maxlife":    lifetimeAttribute("RADIUS maximum ticket life (seconds)"),
+			"radius_maxrenew":   lifetimeAttribute("RADIUS ticket maximum renewable age (seconds)"),
+			"pkinit_maxlife":    lifetimeAttribute("PKINIT maximum ticket life (seconds)"),
+			"pkinit_maxrenew":   lifetimeAttribute("PKINIT ticket maximum renewable age (seconds)"),
+			"hardened_maxlife":  lifetimeAttribute("Hardened ticket maximum ticket life (seconds)"),
+			"hardened_maxrenew": lifetimeAttribute("Hardened ticket maximum renewable age (seconds)"),
+			"idp_maxlife":       lifetimeAttribute("External Identity Provider ticket maximum ticket life (seconds)"),
+			"idp_maxrenew":      lifetimeAttribute("External Identity Provider ticket maximum renewable age (seconds)"),
+		},
+	}
+}
+
+func (r *KerberosTicketPolicy) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var config, plan, state KerberosTicketPolicyModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.mod(ctx, config, plan, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entry, err := r.show(ctx, plan.UID)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read Kerberos ticket policy", "Reason: "+err.Error())
+		return
+	}
+
+	state = kerberosTicketPolicyFromEntry(plan.UID, entry)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *KerberosTicketPolicy) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state KerberosTicketPolicyModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entry, err := r.show(ctx, state.UID)
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if errors.As(err, &freeipaErr) && freeipaErr.Code == freeipa.NotFoundCode {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Failed to read Kerberos ticket policy", "Reason: "+err.Error())
+		return
+	}
+
+	state = kerberosTicketPolicyFromEntry(state.UID, entry)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *KerberosTicketPolicy) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var config, state, plan KerberosTicketPolicyModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.mod(ctx, config, plan, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state = plan
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *KerberosTicketPolicy) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state KerberosTicketPolicyModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := kerberosTicketPolicyArgs(state.UID)
+
+	tflog.Trace(ctx, "Calling KrbtpolicyReset", map[string]any{
+		"args":     args,
+		"opt_args": nil,
+	})
+
+	err := r.provider.Call("krbtpolicy_reset", args, nil, nil)
+	tflog.Trace(ctx, "Called KrbtpolicyReset", map[string]any{
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.NotFoundCode {
+			resp.Diagnostics.AddError("Failed to reset Kerberos ticket policy", "Reason: "+err.Error())
+			return
+		}
+	}
+}
+
+func (r *KerberosTicketPolicy) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	uid := types.StringValue(req.ID)
+
+	if req.ID == globalKerberosTicketPolicyID {
+		uid = types.StringNull()
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, kerberosTicketPolicyFromEntry(uid, nil))...)
+}
+
+func NewKerberosTicketPolicy(p *provider.Provider) resource.Resource {
+	r := &KerberosTicketPolicy{
+		provider: p,
+	}
+
+	var _ resource.Resource = r
+	var _ resource.ResourceWithImportState = r
+
+	return r
+}
+
+func init() {
+	resources = append(resources, NewKerberosTicketPolicy)
+}
+
+// kerberosTicketPolicyArgs returns the positional arguments of the
+// krbtpolicy commands: the user, or none for the global policy. The client
+// has no user argument, so these commands use raw calls.
+func kerberosTicketPolicyArgs(uid types.String) []any {
+	if uid.IsNull() {
+		return []any{}
+	}
+
+	return []any{uid.ValueString()}
+}
+
+// kerberosTicketPolicyFromEntry returns the model of a ticket policy entry
+// returned by a raw call.
+func kerberosTicketPolicyFromEntry(uid types.String, entry map[string]any) KerberosTicketPolicyModel {
+	return KerberosTicketPolicyModel{
+		UID:              uid,
+		MaxLife:          types.Int64PointerValue(entryInt64(entry, "krbmaxticketlife")),
+		MaxRenew:         types.Int64PointerValue(entryInt64(entry, "krbmaxrenewableage")),
+		OTPMaxLife:       types.Int64PointerValue(entryInt64(entry, "krbauthindmaxticketlife_otp")),
+		OTPMaxRenew:      types.Int64PointerValue(entryInt64(entry, "krbauthindmaxrenewableage_otp")),
+		RadiusMaxLife:    types.Int64PointerValue(entryInt64(entry, "krbauthindmaxticketlife_radius")),
+		RadiusMaxRenew:   types.Int64PointerValue(entryInt64(entry, "krbauthindmaxrenewableage_radius")),
+		PKINITMaxLife:    types.Int64PointerValue(entryInt64(entry, "krbauthindmaxticketlife_pkinit")),
+		PKINITMaxRenew:   types.Int64PointerValue(entryInt64(entry, "krbauthindmaxrenewableage_pkinit")),
+		HardenedMaxLife:  types.Int64PointerValue(entryInt64(entry, "krbauthindmaxticketlife_hardened")),
+		HardenedMaxRenew: types.Int64PointerValue(entryInt64(entry, "krbauthindmaxrenewableage_hardened")),
+		IdPMaxLife:       types.Int64PointerValue(entryInt64(entry, "krbauthindmaxticketlife_idp")),
+		IdPMaxRenew:      types.Int64PointerValue(entryInt64(entry, "krbauthindmaxrenewableage_idp")),
+	}
+}
+
+// show returns the entry of a ticket policy. For users without their own
+// lifetimes, FreeIPA returns the ones of the global policy.
+func (r *KerberosTicketPolicy) show(ctx context.Context, uid types.String) (map[string]any, error) {
+	args := kerberosTicketPolicyArgs(uid)
+
+	optArgs := &freeipa.KrbtpolicyShowOptionalArgs{
+		All: freeipa.Bool(true),
+	}
+
+	tflog.Trace(ctx, "Calling KrbtpolicyShow", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	var res rpcEntryResult
+
+	err := r.provider.Call("krbtpolicy_show", args, optArgs, &res)
+	tflog.Trace(ctx, "Called KrbtpolicyShow", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	return res.Result, err
+}
+
+// mod sets the lifetimes of a ticket policy which are configured or changed.
+// The others are left unset so that the policy of a user keeps following the
+// global one.
+func (r *KerberosTicketPolicy) mod(ctx context.Context, config, plan, state KerberosTicketPolicyModel) (diags diag.Diagnostics) {
+	args := kerberosTicketPolicyArgs(plan.UID)
+
+	optArgs := &freeipa.KrbtpolicyModOptionalArgs{
+		Krbmaxticketlife:                  kerberosTicketPolicyLifetime(config.MaxLife, plan.MaxLife, state.MaxLife),
+		Krbmaxrenewableage:                kerberosTicketPolicyLifetime(config.MaxRenew, plan.MaxRenew, state.MaxRenew),
+		KrbauthindmaxticketlifeOtp:        kerberosTicketPolicyLifetime(config.OTPMaxLife, plan.OTPMaxLife, state.OTPMaxLife),
+		KrbauthindmaxrenewableageOtp:      kerberosTicketPolicyLifetime(config.OTPMaxRenew, plan.OTPMaxRenew, state.OTPMaxRenew),
+		KrbauthindmaxticketlifeRadius:     kerberosTicketPolicyLifetime(config.RadiusMaxLife, plan.RadiusMaxLife, state.RadiusMaxLife),
+		KrbauthindmaxrenewableageRadius:   kerberosTicketPolicyLifetime(config.RadiusMaxRenew, plan.RadiusMaxRenew, state.RadiusMaxRenew),
+		KrbauthindmaxticketlifePkinit:     kerberosTicketPolicyLifetime(config.PKINITMaxLife, plan.PKINITMaxLife, state.PKINITMaxLife),
+		KrbauthindmaxrenewableagePkinit:   kerberosTicketPolicyLifetime(config.PKINITMaxRenew, plan.PKINITMaxRenew, state.PKINITMaxRenew),
+		KrbauthindmaxticketlifeHardened:   kerberosTicketPolicyLifetime(config.HardenedMaxLife, plan.HardenedMaxLife, state.HardenedMaxLife),
+		KrbauthindmaxrenewableageHardened: kerberosTicketPolicyLifetime(config.HardenedMaxRenew, plan.HardenedMaxRenew, state.HardenedMaxRenew),
+		KrbauthindmaxticketlifeIdp:        kerberosTicketPolicyLifetime(config.IdPMaxLife, plan.IdPMaxLife, state.IdPMaxLife),
+		KrbauthindmaxrenewableageIdp:      kerberosTicketPolicyLifetime(config.IdPMaxRenew, plan.IdPMaxRenew, state.IdPMaxRenew),
+	}
+
+	tflog.Trace(ctx, "Calling KrbtpolicyMod", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	err := r.provider.Call("krbtpolicy_mod", args, optArgs, nil)
+	tflog.Trace(ctx, "Called KrbtpolicyMod", map[string]any{
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.EmptyModlistCode {
+			diags.AddError("Failed to update Kerberos ticket policy", "Reason: "+err.Error())
+			return
+		}
+
+		tflog.Debug(ctx, "Updated Kerberos ticket policy has no effective difference", map[string]any{
+			"args": args,
+		})
+	}
+
+	return
+}
+
+// kerberosTicketPolicyLifetime returns the lifetime to set, when it is
+// configured or changed.
+func kerberosTicketPolicyLifetime(config, plan, state types.Int64) *int {
+	if config.IsNull() && plan.Equal(state) {
+		return nil
+	}
+
+	return knownInt(plan)
+}
@@ -0,0 +1,106 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestKerberosTicketPolicyRead(t *testing.T) {
+	p := newTestProvider(t, map[string]rpcHandler{
+		"krbtpolicy_show": func(t *testing.T, params map[string]any) string {
+			return rpcResult(map[string]any{
+				"summary": nil,
+				"value":   "admin",
+				"result": map[string]any{
+					"uid":                           []string{"admin"},
+					"krbmaxticketlife":              []string{"3600"},
+					"krbmaxrenewableage":            []string{"604800"},
+					"krbauthindmaxticketlife_otp":   []string{"1800"},
+					"krbauthindmaxticketlife_idp":   3600,
+					"krbauthindmaxrenewableage_otp": []string{"7200"},
+				},
+			})
+		},
+	})
+
+	model := testRead(t, NewKerberosTicketPolicy(p), KerberosTicketPolicyModel{
+		UID: types.StringValue("admin"),
+	})
+
+	testValues(t, map[string]testValue{
+		"maxlife":        {model.MaxLife, types.Int64Value(3600)},
+		"maxrenew":       {model.MaxRenew, types.Int64Value(604800)},
+		"otp_maxlife":    {model.OTPMaxLife, types.Int64Value(1800)},
+		"otp_maxrenew":   {model.OTPMaxRenew, types.Int64Value(7200)},
+		"idp_maxlife":    {model.IdPMaxLife, types.Int64Value(3600)},
+		"pkinit_maxlife": {model.PKINITMaxLife, types.Int64Null()},
+	})
+
+	if model.UID.ValueString() != "admin" {
+		t.Errorf("unexpected uid %v", model.UID)
+	}
+}
+
+func TestKerberosTicketPolicyReadUserNotFound(t *testing.T) {
+	p := newTestProvider(t, map[string]rpcHandler{
+		"krbtpolicy_show": func(t *testing.T, params map[string]any) string {
+			return rpcError(4001, "NotFound", "admin: user not found")
+		},
+	})
+
+	model := testRead(t, NewKerberosTicketPolicy(p), KerberosTicketPolicyModel{
+		UID: types.StringValue("admin"),
+	})
+
+	if model != nil {
+		t.Errorf("expected the policy of a missing user to be removed from state")
+	}
+}
+
+func TestKerberosTicketPolicyUpdate(t *testing.T) {
+	p := newTestProvider(t, map[string]rpcHandler{
+		"krbtpolicy_mod": func(t *testing.T, params map[string]any) string {
+			// The inherited lifetimes must not become overrides of the user.
+			for name, value := range params {
+				if name != "version" && name != "krbmaxticketlife" {
+					t.Errorf("unexpected %s %v", name, value)
+				}
+			}
+
+			if params["krbmaxticketlife"] != float64(1800) {
+				t.Errorf("unexpected krbmaxticketlife %v", params["krbmaxticketlife"])
+			}
+
+			return rpcResult(map[string]any{
+				"summary": nil,
+				"value":   "admin",
+				"result":  map[string]any{},
+			})
+		},
+	})
+
+	inherited := KerberosTicketPolicyModel{
+		UID:              types.StringValue("admin"),
+		MaxLife:          types.Int64Value(86400),
+		MaxRenew:         types.Int64Value(604800),
+		OTPMaxLife:       types.Int64Value(86400),
+		OTPMaxRenew:      types.Int64Value(604800),
+		RadiusMaxLife:    types.Int64Value(86400),
+		RadiusMaxRenew:   types.Int64Value(604800),
+		PKINITMaxLife:    types.Int64Value(86400),
+		PKINITMaxRenew:   types.Int64Value(604800),
+		HardenedMaxLife:  types.Int64Value(86400),
+		HardenedMaxRenew: types.Int64Value(604800),
+		IdPMaxLife:       types.Int64Value(86400),
+		IdPMaxRenew:      types.Int64Value(604800),
+	}
+
+	planned := inherited
+	planned.MaxLife = types.Int64Value(1800)
+
+	testUpdate(t, NewKerberosTicketPolicy(p), KerberosTicketPolicyModel{
+		UID:     types.StringValue("admin"),
+		MaxLife: types.Int64Value(1800),
+	}, planned, inherited)
+}
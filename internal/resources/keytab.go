@@ -0,0 +1,492 @@
+package resources
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/camptocamp/terraform-provider-freeipa/internal/provider"
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// getKeytabOID is the LDAP extended operation of the FreeIPA directory server
+// which ipa-getkeytab uses to generate or retrieve the keys of a principal.
+const getKeytabOID = "2.16.840.1.113730.3.8.10.5"
+
+// Tags of the getkeytab request and reply, which are choices of the same
+// control.
+const (
+	getKeytabNewKeysTag     = 0
+	getKeytabCurrentKeysTag = 1
+)
+
+// keytabEncryptionTypes maps the names of the encryption types to their
+// numbers.
+var keytabEncryptionTypes = map[string]int{
+	"aes256-cts-hmac-sha384-192": 20,
+	"aes128-cts-hmac-sha256-128": 19,
+	"aes256-cts-hmac-sha1-96":    18,
+	"aes128-cts-hmac-sha1-96":    17,
+	"camellia256-cts-cmac":       26,
+	"camellia128-cts-cmac":       25,
+	"des3-cbc-sha1":              16,
+	"arcfour-hmac":               23,
+}
+
+type Keytab struct {
+	provider *provider.Provider
+}
+
+type KeytabModel struct {
+	Principal       types.String `tfsdk:"principal"`
+	RetrieveOnly    types.Bool   `tfsdk:"retrieve_only"`
+	EncryptionTypes types.Set    `tfsdk:"encryption_types"`
+	KVNO            types.Int64  `tfsdk:"kvno"`
+	Keytab          types.String `tfsdk:"keytab"`
+}
+
+// keytabKey is a key of a principal returned by the getkeytab operation.
+type keytabKey struct {
+	encryptionType int
+	value          []byte
+}
+
+func (r *Keytab) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_keytab"
+}
+
+func (r *Keytab) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	encryptionTypes := make([]string, 0, len(keytabEncryptionTypes))
+
+	for name := range keytabEncryptionTypes {
+		encryptionTypes = append(encryptionTypes, name)
+	}
+
+	sort.Strings(encryptionTypes)
+
+	resp.Schema = schema.Schema{
+		Version:     0,
+		Description: "Generates or retrieves the keys of a Kerberos principal as a keytab, like ipa-getkeytab does. The configured user must be allowed to create or retrieve the keytab of the principal. Destroying the resource only removes the keytab from the state",
+		Attributes: map[string]schema.Attribute{
+			"principal": schema.StringAttribute{
+				Description: "Principal name of the host or service (e.g. “HTTP/www.example.test”), in the realm of FreeIPA when it has none",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"retrieve_only": schema.BoolAttribute{
+				Description: "Retrieve the existing keys instead of generating new ones. Generating keys invalidates the keytabs retrieved before, retrieving them requires the principal to already have keys",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"encryption_types": schema.SetAttribute{
+				Description: "Encryption types of the keys, the defaults of the server when unset, one of: " + strings.Join(encryptionTypes, ", "),
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"kvno": schema.Int64Attribute{
+				Description: "Key version number of the keys",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"keytab": schema.StringAttribute{
+				Description: "Keytab holding the keys of the principal, base64 encoded",
+				Computed:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *Keytab) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config KeytabModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, encryptionType := range knownSetStrings(config.EncryptionTypes) {
+		if _, ok := keytabEncryptionTypes[encryptionType]; !ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("encryption_types"),
+				"Invalid configuration",
+				"“"+encryptionType+"” is not a supported encryption type.",
+			)
+		}
+	}
+
+	if !config.EncryptionTypes.IsNull() && !config.EncryptionTypes.IsUnknown() && len(config.EncryptionTypes.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("encryption_types"),
+			"Invalid configuration",
+			"“encryption_types” must contain at least one encryption type.",
+		)
+	}
+}
+
+func (r *Keytab) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan, state KeytabModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	principal := plan.Principal.ValueString()
+
+	if !strings.Contains(principal, "@") {
+		realm, err := r.realm(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to read Kerberos realm", "Reason: "+err.Error())
+			return
+		}
+
+		principal += "@" + realm
+	}
+
+	var encryptionTypes []int
+
+	for _, name := range knownSetStrings(plan.EncryptionTypes) {
+		encryptionTypes = append(encryptionTypes, keytabEncryptionTypes[name])
+	}
+
+	// Prefer the strongest keys, as ipa-getkeytab does.
+	sort.Sort(sort.Reverse(sort.IntSlice(encryptionTypes)))
+
+	generate := !plan.RetrieveOnly.ValueBool()
+
+	// Do not log the keys.
+	tflog.Trace(ctx, "Calling GetKeytab", map[string]any{
+		"principal":        principal,
+		"generate":         generate,
+		"encryption_types": encryptionTypes,
+	})
+
+	res, err := r.provider.Extended(getKeytabOID, getKeytabRequest(principal, generate, encryptionTypes))
+
+	tflog.Trace(ctx, "Called GetKeytab", map[string]any{
+		"err": err,
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get keytab", "Reason: "+err.Error())
+		return
+	}
+
+	kvno, keys, err := parseGetKeytabReply(res)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get keytab", "Reason: "+err.Error())
+		return
+	}
+
+	// The existing keys are retrieved whatever their encryption type.
+	if len(encryptionTypes) > 0 {
+		keys = slices.DeleteFunc(keys, func(key keytabKey) bool {
+			return !slices.Contains(encryptionTypes, key.encryptionType)
+		})
+	}
+
+	if len(keys) == 0 {
+		resp.Diagnostics.AddError("Failed to get keytab", "Reason: the principal has no keys of the requested encryption types")
+		return
+	}
+
+	state = plan
+	state.KVNO = types.Int64Value(int64(kvno))
+	state.Keytab = types.StringValue(base64.StdEncoding.EncodeToString(marshalKeytab(principal, kvno, time.Now(), keys)))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *Keytab) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state KeytabModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The keys cannot be read without retrieving them again, the keytab is
+	// kept as is.
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *Keytab) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state, plan KeytabModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// All the arguments require a replacement.
+	state = plan
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *Keytab) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// The keys are kept, only the keytab is removed from the state.
+}
+
+func NewKeytab(p *provider.Provider) resource.Resource {
+	r := &Keytab{
+		provider: p,
+	}
+
+	var _ resource.Resource = r
+	var _ resource.ResourceWithValidateConfig = r
+
+	return r
+}
+
+func init() {
+	resources = append(resources, NewKeytab)
+}
+
+// realm returns the Kerberos realm of FreeIPA.
+func (r *Keytab) realm(ctx context.Context) (string, error) {
+	tflog.Trace(ctx, "Calling Env", map[string]any{
+		"args":     []any{"realm"},
+		"opt_args": nil,
+	})
+
+	var res struct {
+		Result struct {
+			Realm string `json:"realm"`
+		} `json:"result"`
+	}
+
+	err := r.provider.Call("env", []any{"realm"}, nil, &res)
+	tflog.Trace(ctx, "Called Env", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	if res.Result.Realm == "" {
+		return "", fmt.Errorf("missing realm in server environment")
+	}
+
+	return res.Result.Realm, nil
+}
+
+// getKeytabRequest returns the value of a getkeytab request, generating new
+// keys of the given encryption types or retrieving the current ones:
+//
+//	GetKeytabControl ::= CHOICE {
+//	    newkeys      [0] NewKeys,
+//	    curkeys      [1] CurrentKeys,
+//	    reply        [2] Reply
+//	}
+//
+//	NewKeys ::= SEQUENCE {
+//	    serviceIdentity [0] OCTET STRING,
+//	    enctypes        [1] SEQUENCE OF Int16,
+//	    password        [2] OCTET STRING OPTIONAL
+//	}
+//
+//	CurrentKeys ::= SEQUENCE {
+//	    serviceIdentity [0] OCTET STRING
+//	}
+func getKeytabRequest(principal string, generate bool, encryptionTypes []int) []byte {
+	tag := ber.Tag(getKeytabCurrentKeysTag)
+
+	if generate {
+		tag = getKeytabNewKeysTag
+	}
+
+	request := ber.Encode(ber.ClassContext, ber.TypeConstructed, tag, nil, "GetKeytabControl")
+	request.AppendChild(ber.NewString(ber.ClassContext, ber.TypePrimitive, 0, principal, "serviceIdentity"))
+
+	if generate {
+		enctypes := ber.Encode(ber.ClassContext, ber.TypeConstructed, 1, nil, "enctypes")
+
+		for _, encryptionType := range encryptionTypes {
+			enctypes.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, encryptionType, "enctype"))
+		}
+
+		request.AppendChild(enctypes)
+	}
+
+	return request.Bytes()
+}
+
+// parseGetKeytabReply returns the key version number and the keys of a
+// getkeytab reply:
+//
+//	Reply ::= SEQUENCE {
+//	    new_kvno        Int32,
+//	    keys            SEQUENCE OF KrbKey
+//	}
+//
+//	KrbKey ::= SEQUENCE {
+//	    key       [0] EncryptionKey,
+//	    salt      [1] KrbSalt OPTIONAL,
+//	    s2kparams [2] OCTET STRING OPTIONAL
+//	}
+//
+//	EncryptionKey ::= SEQUENCE {
+//	    keytype   [0] Int32,
+//	    keyvalue  [1] OCTET STRING
+//	}
+func parseGetKeytabReply(b []byte) (kvno int, keys []keytabKey, err error) {
+	reply, err := ber.DecodePacketErr(b)
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid getkeytab reply: %w", err)
+	}
+
+	if len(reply.Children) != 2 {
+		return 0, nil, fmt.Errorf("invalid getkeytab reply: expected 2 elements, got %d", len(reply.Children))
+	}
+
+	kvno, err = berInt(reply.Children[0])
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid getkeytab reply: %w", err)
+	}
+
+	for _, krbKey := range reply.Children[1].Children {
+		key := berTagged(krbKey, 0)
+		if key == nil {
+			return 0, nil, fmt.Errorf("invalid getkeytab reply: missing key")
+		}
+
+		// The directory server uses implicit tags, but accept an explicitly
+		// tagged key as well.
+		if len(key.Children) == 1 && key.Children[0].ClassType == ber.ClassUniversal {
+			key = key.Children[0]
+		}
+
+		keyType := berTagged(key, 0)
+		keyValue := berTagged(key, 1)
+
+		if keyType == nil || keyValue == nil {
+			return 0, nil, fmt.Errorf("invalid getkeytab reply: incomplete key")
+		}
+
+		encryptionType, err := berInt(keyType)
+		if err != nil {
+			return 0, nil, fmt.Errorf("invalid getkeytab reply: %w", err)
+		}
+
+		keys = append(keys, keytabKey{
+			encryptionType: encryptionType,
+			value:          berBytes(keyValue),
+		})
+	}
+
+	return kvno, keys, nil
+}
+
+// berTagged returns the child of a packet with the given context tag, or nil.
+func berTagged(packet *ber.Packet, tag ber.Tag) *ber.Packet {
+	for _, child := range packet.Children {
+		if child.ClassType == ber.ClassContext && child.Tag == tag {
+			return child
+		}
+	}
+
+	return nil
+}
+
+// berInt returns the value of an integer, explicitly tagged or not.
+func berInt(packet *ber.Packet) (int, error) {
+	if packet.TagType == ber.TypeConstructed && len(packet.Children) == 1 {
+		return berInt(packet.Children[0])
+	}
+
+	if v, ok := packet.Value.(int64); ok {
+		return int(v), nil
+	}
+
+	if packet.TagType == ber.TypePrimitive && packet.Data.Len() > 0 {
+		return int(new(big.Int).SetBytes(packet.Data.Bytes()).Int64()), nil
+	}
+
+	return 0, fmt.Errorf("expected an integer")
+}
+
+// berBytes returns the value of an octet string, explicitly tagged or not.
+func berBytes(packet *ber.Packet) []byte {
+	if packet.TagType == ber.TypeConstructed && len(packet.Children) == 1 {
+		return berBytes(packet.Children[0])
+	}
+
+	return packet.Data.Bytes()
+}
+
+// marshalKeytab returns the keys of a principal in the keytab file format of
+// MIT Kerberos (version 0x502).
+func marshalKeytab(principal string, kvno int, timestamp time.Time, keys []keytabKey) []byte {
+	name, realm, _ := strings.Cut(principal, "@")
+	components := strings.Split(name, "/")
+
+	var buf bytes.Buffer
+
+	buf.Write([]byte{0x05, 0x02})
+
+	for _, key := range keys {
+		var entry bytes.Buffer
+
+		writeCounted := func(b []byte) {
+			_ = binary.Write(&entry, binary.BigEndian, uint16(len(b)))
+			entry.Write(b)
+		}
+
+		_ = binary.Write(&entry, binary.BigEndian, uint16(len(components)))
+		writeCounted([]byte(realm))
+
+		for _, component := range components {
+			writeCounted([]byte(component))
+		}
+
+		// KRB5_NT_PRINCIPAL
+		_ = binary.Write(&entry, binary.BigEndian, uint32(1))
+		_ = binary.Write(&entry, binary.BigEndian, uint32(timestamp.Unix()))
+		entry.WriteByte(uint8(kvno))
+		_ = binary.Write(&entry, binary.BigEndian, uint16(key.encryptionType))
+		writeCounted(key.value)
+		_ = binary.Write(&entry, binary.BigEndian, uint32(kvno))
+
+		_ = binary.Write(&buf, binary.BigEndian, int32(entry.Len()))
+		buf.Write(entry.Bytes())
+	}
+
+	return buf.Bytes()
+}
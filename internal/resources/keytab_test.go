@@ -0,0 +1,152 @@
+package resources
+
+import (
+	"bytes"
+	"encoding/hex"
+	"reflect"
+	"testing"
+	"time"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+)
+
+func TestGetKeytabRequest(t *testing.T) {
+	request, err := ber.DecodePacketErr(getKeytabRequest("HTTP/www.example.test@EXAMPLE.TEST", true, []int{18, 17}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if request.ClassType != ber.ClassContext || request.Tag != getKeytabNewKeysTag || len(request.Children) != 2 {
+		t.Fatalf("unexpected request %v", request)
+	}
+
+	if principal := string(request.Children[0].Data.Bytes()); principal != "HTTP/www.example.test@EXAMPLE.TEST" {
+		t.Errorf("unexpected principal %q", principal)
+	}
+
+	var encryptionTypes []int
+
+	for _, child := range request.Children[1].Children {
+		encryptionTypes = append(encryptionTypes, int(child.Value.(int64)))
+	}
+
+	if !reflect.DeepEqual(encryptionTypes, []int{18, 17}) {
+		t.Errorf("unexpected encryption types %v", encryptionTypes)
+	}
+
+	request, err = ber.DecodePacketErr(getKeytabRequest("HTTP/www.example.test@EXAMPLE.TEST", false, []int{18}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The current keys are retrieved whatever their encryption type.
+	if request.Tag != getKeytabCurrentKeysTag || len(request.Children) != 1 {
+		t.Errorf("unexpected request %v", request)
+	}
+}
+
+func TestParseGetKeytabReply(t *testing.T) {
+	explicit := func(tag ber.Tag, child *ber.Packet) *ber.Packet {
+		p := ber.Encode(ber.ClassContext, ber.TypeConstructed, tag, nil, "")
+		p.AppendChild(child)
+		return p
+	}
+
+	key := func(encryptionType int, value string) *ber.Packet {
+		encryptionKey := ber.NewSequence("EncryptionKey")
+		encryptionKey.AppendChild(explicit(0, ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, encryptionType, "keytype")))
+		encryptionKey.AppendChild(explicit(1, ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, value, "keyvalue")))
+
+		krbKey := ber.NewSequence("KrbKey")
+		krbKey.AppendChild(explicit(0, encryptionKey))
+		return krbKey
+	}
+
+	keys := ber.NewSequence("keys")
+	keys.AppendChild(key(18, "0123456789abcdef0123456789abcdef"))
+	keys.AppendChild(key(17, "0123456789abcdef"))
+
+	reply := ber.Encode(ber.ClassContext, ber.TypeConstructed, 2, nil, "Reply")
+	reply.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, 3, "new_kvno"))
+	reply.AppendChild(keys)
+
+	kvno, parsed, err := parseGetKeytabReply(reply.Bytes())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []keytabKey{
+		{encryptionType: 18, value: []byte("0123456789abcdef0123456789abcdef")},
+		{encryptionType: 17, value: []byte("0123456789abcdef")},
+	}
+
+	if kvno != 3 || !reflect.DeepEqual(parsed, expected) {
+		t.Errorf("unexpected reply %d %v", kvno, parsed)
+	}
+
+	if _, _, err := parseGetKeytabReply([]byte{0x01}); err == nil {
+		t.Errorf("expected an error for an invalid reply")
+	}
+}
+
+func TestParseGetKeytabReplyImplicit(t *testing.T) {
+	tlv := func(tag byte, content ...[]byte) []byte {
+		value := bytes.Join(content, nil)
+		return append([]byte{tag, byte(len(value))}, value...)
+	}
+
+	aes256 := bytes.Repeat([]byte{0x01}, 32)
+	aes128 := bytes.Repeat([]byte{0x02}, 16)
+
+	// A reply of the directory server, which uses implicit tags.
+	reply := tlv(0xa2,
+		tlv(0x02, []byte{0x04}),
+		tlv(0x30,
+			tlv(0x30,
+				tlv(0xa0, tlv(0x80, []byte{18}), tlv(0x81, aes256)),
+				tlv(0xa1, tlv(0x80, []byte{4}), tlv(0x81, []byte("EXAMPLE.TESTHTTPwww"))),
+			),
+			tlv(0x30,
+				tlv(0xa0, tlv(0x80, []byte{17}), tlv(0x81, aes128)),
+			),
+		),
+	)
+
+	kvno, keys, err := parseGetKeytabReply(reply)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []keytabKey{
+		{encryptionType: 18, value: aes256},
+		{encryptionType: 17, value: aes128},
+	}
+
+	if kvno != 4 || !reflect.DeepEqual(keys, expected) {
+		t.Errorf("unexpected reply %d %v", kvno, keys)
+	}
+}
+
+func TestMarshalKeytab(t *testing.T) {
+	keytab := marshalKeytab("HTTP/www@EXAMPLE.TEST", 2, time.Unix(1, 0), []keytabKey{
+		{encryptionType: 17, value: []byte{0xaa, 0xbb}},
+	})
+
+	expected, _ := hex.DecodeString("" +
+		"0502" + // version
+		"0000002e" + // size
+		"0002" + // components
+		"000c" + hex.EncodeToString([]byte("EXAMPLE.TEST")) +
+		"0004" + hex.EncodeToString([]byte("HTTP")) +
+		"0003" + hex.EncodeToString([]byte("www")) +
+		"00000001" + // name type
+		"00000001" + // timestamp
+		"02" + // vno8
+		"0011" + // key type
+		"0002aabb" + // key
+		"00000002") // vno
+
+	if !bytes.Equal(keytab, expected) {
+		t.Errorf("unexpected keytab %x, expected %x", keytab, expected)
+	}
+}
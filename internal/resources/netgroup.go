@@ -0,0 +1,550 @@
+package resources
+
+import (
+	"context"
+	"errors"
+
+	"github.com/camptocamp/go-freeipa/freeipa"
+	"github.com/camptocamp/terraform-provider-freeipa/internal/provider"
+	"github.com/camptocamp/terraform-provider-freeipa/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type Netgroup struct {
+	provider *provider.Provider
+}
+
+type NetgroupModel struct {
+	Name             types.String `tfsdk:"cn"`
+	Description      types.String `tfsdk:"description"`
+	NisDomain        types.String `tfsdk:"nis_domain"`
+	UserCategory     types.String `tfsdk:"usercategory"`
+	HostCategory     types.String `tfsdk:"hostcategory"`
+	MemberUsers      types.Set    `tfsdk:"member_users"`
+	MemberGroups     types.Set    `tfsdk:"member_groups"`
+	MemberHosts      types.Set    `tfsdk:"member_hosts"`
+	MemberHostgroups types.Set    `tfsdk:"member_hostgroups"`
+	MemberNetgroups  types.Set    `tfsdk:"member_netgroups"`
+	ExternalHosts    types.Set    `tfsdk:"external_hosts"`
+}
+
+// netgroupMembers holds the members of a netgroup, by member type.
+type netgroupMembers struct {
+	users         []string
+	groups        []string
+	hosts         []string
+	hostgroups    []string
+	netgroups     []string
+	externalHosts []string
+}
+
+func (r *Netgroup) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_netgroup"
+}
+
+func (r *Netgroup) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version:     0,
+		Description: "Manages a NIS netgroup",
+		Attributes: map[string]schema.Attribute{
+			"cn": schema.StringAttribute{
+				Description: "Netgroup name",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description: "Netgroup description",
+				Optional:    true,
+			},
+			"nis_domain": schema.StringAttribute{
+				Description: "NIS domain name, the FreeIPA domain by default",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"usercategory": schema.StringAttribute{
+				Description: "User category the netgroup applies to (allowed value: all)",
+				Optional:    true,
+			},
+			"hostcategory": schema.StringAttribute{
+				Description: "Host category the netgroup applies to (allowed value: all)",
+				Optional:    true,
+			},
+			"member_users": schema.SetAttribute{
+				Description: "Users member of the netgroup",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"member_groups": schema.SetAttribute{
+				Description: "User groups member of the netgroup",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"member_hosts": schema.SetAttribute{
+				Description: "Hosts member of the netgroup",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"member_hostgroups": schema.SetAttribute{
+				Description: "Host groups member of the netgroup",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"member_netgroups": schema.SetAttribute{
+				Description: "Netgroups nested in the netgroup",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"external_hosts": schema.SetAttribute{
+				Description: "Hosts member of the netgroup which are not managed by FreeIPA",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *Netgroup) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config NetgroupModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	categories := []struct {
+		name     string
+		category types.String
+		members  map[string]types.Set
+	}{
+		{"usercategory", config.UserCategory, map[string]types.Set{"member_users": config.MemberUsers, "member_groups": config.MemberGroups}},
+		{"hostcategory", config.HostCategory, map[string]types.Set{"member_hosts": config.MemberHosts, "member_hostgroups": config.MemberHostgroups, "external_hosts": config.ExternalHosts}},
+	}
+
+	for _, c := range categories {
+		if c.category.IsNull() || c.category.IsUnknown() {
+			continue
+		}
+
+		if c.category.ValueString() != "all" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(c.name),
+				"Invalid configuration",
+				"“"+c.name+"” only accepts the value “all”.",
+			)
+
+			continue
+		}
+
+		for name, members := range c.members {
+			if !members.IsNull() && (members.IsUnknown() || len(members.Elements()) > 0) {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(name),
+					"Invalid configuration",
+					"“"+name+"” cannot be set when “"+c.name+"” is “all”.",
+				)
+			}
+		}
+	}
+}
+
+func (r *Netgroup) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan, state NetgroupModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	members, diags := netgroupMembersFromModel(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.NetgroupAddArgs{
+		Cn: plan.Name.ValueString(),
+	}
+
+	optArgs := &freeipa.NetgroupAddOptionalArgs{
+		Description:   plan.Description.ValueStringPointer(),
+		Nisdomainname: knownString(plan.NisDomain),
+		Usercategory:  plan.UserCategory.ValueStringPointer(),
+		Hostcategory:  plan.HostCategory.ValueStringPointer(),
+		All:           freeipa.Bool(true),
+	}
+
+	tflog.Trace(ctx, "Calling NetgroupAdd", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	res, err := r.provider.Client().NetgroupAdd(args, optArgs)
+
+	tflog.Trace(ctx, "Called NetgroupAdd", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create netgroup", "Reason: "+err.Error())
+		return
+	}
+
+	state = plan
+	state.NisDomain = types.StringPointerValue(res.Result.Nisdomainname)
+
+	saveCreated(ctx, resp, state)
+
+	resp.Diagnostics.Append(r.addMembers(ctx, plan.Name.ValueString(), members)...)
+}
+
+func (r *Netgroup) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state NetgroupModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.NetgroupShowArgs{
+		Cn: state.Name.ValueString(),
+	}
+
+	optArgs := &freeipa.NetgroupShowOptionalArgs{
+		All: freeipa.Bool(true),
+	}
+
+	tflog.Trace(ctx, "Calling NetgroupShow", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	res, err := r.provider.Client().NetgroupShow(args, optArgs)
+
+	tflog.Trace(ctx, "Called NetgroupShow", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if errors.As(err, &freeipaErr) && freeipaErr.Code == freeipa.NotFoundCode {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Failed to read netgroup", "Reason: "+err.Error())
+		return
+	}
+
+	var diags diag.Diagnostics
+
+	state.Description = types.StringPointerValue(res.Result.Description)
+	state.NisDomain = types.StringPointerValue(res.Result.Nisdomainname)
+	state.UserCategory = types.StringPointerValue(res.Result.Usercategory)
+	state.HostCategory = types.StringPointerValue(res.Result.Hostcategory)
+
+	state.MemberUsers, diags = membersSetValue(ctx, state.MemberUsers, res.Result.MemberuserUser)
+	resp.Diagnostics.Append(diags...)
+
+	state.MemberGroups, diags = membersSetValue(ctx, state.MemberGroups, res.Result.MemberuserGroup)
+	resp.Diagnostics.Append(diags...)
+
+	state.MemberHosts, diags = membersSetValue(ctx, state.MemberHosts, res.Result.MemberhostHost)
+	resp.Diagnostics.Append(diags...)
+
+	state.MemberHostgroups, diags = membersSetValue(ctx, state.MemberHostgroups, res.Result.MemberhostHostgroup)
+	resp.Diagnostics.Append(diags...)
+
+	state.MemberNetgroups, diags = membersSetValue(ctx, state.MemberNetgroups, res.Result.MemberNetgroup)
+	resp.Diagnostics.Append(diags...)
+
+	state.ExternalHosts, diags = membersSetValue(ctx, state.ExternalHosts, res.Result.Externalhost)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *Netgroup) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state, plan NetgroupModel
+	var hasDiff bool
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	actualMembers, diags := netgroupMembersFromModel(ctx, state)
+	resp.Diagnostics.Append(diags...)
+
+	desiredMembers, diags := netgroupMembersFromModel(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	membersToAdd, membersToRemove := actualMembers.diff(desiredMembers)
+
+	// Remove members first, as they cannot coexist with a category set to
+	// “all”.
+	resp.Diagnostics.Append(r.removeMembers(ctx, plan.Name.ValueString(), membersToRemove)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.NetgroupModArgs{
+		Cn: plan.Name.ValueString(),
+	}
+
+	optArgs := &freeipa.NetgroupModOptionalArgs{
+		All: freeipa.Bool(true),
+	}
+
+	for _, attr := range []struct {
+		plan, state types.String
+		value       **string
+	}{
+		{plan.Description, state.Description, &optArgs.Description},
+		{plan.NisDomain, state.NisDomain, &optArgs.Nisdomainname},
+		{plan.UserCategory, state.UserCategory, &optArgs.Usercategory},
+		{plan.HostCategory, state.HostCategory, &optArgs.Hostcategory},
+	} {
+		if !attr.plan.Equal(attr.state) {
+			*attr.value = freeipa.String(attr.plan.ValueString())
+			hasDiff = true
+		}
+	}
+
+	if hasDiff {
+		tflog.Trace(ctx, "Calling NetgroupMod", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		res, err := r.provider.Client().NetgroupMod(args, optArgs)
+
+		tflog.Trace(ctx, "Called NetgroupMod", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		if err != nil {
+			var freeipaErr *freeipa.Error
+
+			if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.EmptyModlistCode {
+				resp.Diagnostics.AddError("Failed to update netgroup", "Reason: "+err.Error())
+				return
+			}
+
+			tflog.Debug(ctx, "Updated netgroup has no effective difference", map[string]any{
+				"args":     args,
+				"opt_args": optArgs,
+			})
+		}
+	}
+
+	resp.Diagnostics.Append(r.addMembers(ctx, plan.Name.ValueString(), membersToAdd)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state = plan
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *Netgroup) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state NetgroupModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.NetgroupDelArgs{
+		Cn: []string{state.Name.ValueString()},
+	}
+
+	tflog.Trace(ctx, "Calling NetgroupDel", map[string]any{
+		"args":     args,
+		"opt_args": nil,
+	})
+
+	res, err := r.provider.Client().NetgroupDel(args, nil)
+
+	tflog.Trace(ctx, "Called NetgroupDel", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.NotFoundCode {
+			resp.Diagnostics.AddError("Failed to delete netgroup", "Reason: "+err.Error())
+			return
+		}
+	}
+}
+
+func (r *Netgroup) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	state := NetgroupModel{
+		Name:             types.StringValue(req.ID),
+		MemberUsers:      types.SetNull(types.StringType),
+		MemberGroups:     types.SetNull(types.StringType),
+		MemberHosts:      types.SetNull(types.StringType),
+		MemberHostgroups: types.SetNull(types.StringType),
+		MemberNetgroups:  types.SetNull(types.StringType),
+		ExternalHosts:    types.SetNull(types.StringType),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func NewNetgroup(p *provider.Provider) resource.Resource {
+	r := &Netgroup{
+		provider: p,
+	}
+
+	var _ resource.Resource = r
+	var _ resource.ResourceWithValidateConfig = r
+	var _ resource.ResourceWithImportState = r
+
+	return r
+}
+
+func init() {
+	resources = append(resources, NewNetgroup)
+}
+
+func netgroupMembersFromModel(ctx context.Context, model NetgroupModel) (members netgroupMembers, diags diag.Diagnostics) {
+	diags.Append(model.MemberUsers.ElementsAs(ctx, &members.users, false)...)
+	diags.Append(model.MemberGroups.ElementsAs(ctx, &members.groups, false)...)
+	diags.Append(model.MemberHosts.ElementsAs(ctx, &members.hosts, false)...)
+	diags.Append(model.MemberHostgroups.ElementsAs(ctx, &members.hostgroups, false)...)
+	diags.Append(model.MemberNetgroups.ElementsAs(ctx, &members.netgroups, false)...)
+	diags.Append(model.ExternalHosts.ElementsAs(ctx, &members.externalHosts, false)...)
+
+	return
+}
+
+// diff returns the members to add and to remove to go from the actual
+// members to the desired ones.
+func (actual netgroupMembers) diff(desired netgroupMembers) (toAdd, toRemove netgroupMembers) {
+	toAdd.users, toRemove.users = utils.SetDiff(actual.users, desired.users)
+	toAdd.groups, toRemove.groups = utils.SetDiff(actual.groups, desired.groups)
+	toAdd.hosts, toRemove.hosts = utils.SetDiff(actual.hosts, desired.hosts)
+	toAdd.hostgroups, toRemove.hostgroups = utils.SetDiff(actual.hostgroups, desired.hostgroups)
+	toAdd.netgroups, toRemove.netgroups = utils.SetDiff(actual.netgroups, desired.netgroups)
+	toAdd.externalHosts, toRemove.externalHosts = utils.SetDiff(actual.externalHosts, desired.externalHosts)
+
+	return
+}
+
+// memberHosts returns the hosts to pass to the membership commands: FreeIPA
+// records the hosts it does not manage as external hosts.
+func (members netgroupMembers) memberHosts() []string {
+	return append(append([]string{}, members.hosts...), members.externalHosts...)
+}
+
+func (members netgroupMembers) empty() bool {
+	return len(members.users) == 0 && len(members.groups) == 0 && len(members.hosts) == 0 &&
+		len(members.hostgroups) == 0 && len(members.netgroups) == 0 && len(members.externalHosts) == 0
+}
+
+func (r *Netgroup) addMembers(ctx context.Context, cn string, members netgroupMembers) (diags diag.Diagnostics) {
+	if members.empty() {
+		return
+	}
+
+	args := &freeipa.NetgroupAddMemberArgs{
+		Cn: cn,
+	}
+
+	optArgs := &freeipa.NetgroupAddMemberOptionalArgs{
+		User:      membersPointer(members.users),
+		Group:     membersPointer(members.groups),
+		Host:      membersPointer(members.memberHosts()),
+		Hostgroup: membersPointer(members.hostgroups),
+		Netgroup:  membersPointer(members.netgroups),
+	}
+
+	tflog.Trace(ctx, "Calling NetgroupAddMember", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	res, err := r.provider.Client().NetgroupAddMember(args, optArgs)
+
+	tflog.Trace(ctx, "Called NetgroupAddMember", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	var failed freeipa.FailedOperations
+	if res != nil {
+		failed = res.Failed
+	}
+
+	diags.Append(membershipDiags("Failed to add netgroup members", failed, err)...)
+
+	return
+}
+
+func (r *Netgroup) removeMembers(ctx context.Context, cn string, members netgroupMembers) (diags diag.Diagnostics) {
+	if members.empty() {
+		return
+	}
+
+	args := &freeipa.NetgroupRemoveMemberArgs{
+		Cn: cn,
+	}
+
+	optArgs := &freeipa.NetgroupRemoveMemberOptionalArgs{
+		User:      membersPointer(members.users),
+		Group:     membersPointer(members.groups),
+		Host:      membersPointer(members.memberHosts()),
+		Hostgroup: membersPointer(members.hostgroups),
+		Netgroup:  membersPointer(members.netgroups),
+	}
+
+	tflog.Trace(ctx, "Calling NetgroupRemoveMember", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	res, err := r.provider.Client().NetgroupRemoveMember(args, optArgs)
+
+	tflog.Trace(ctx, "Called NetgroupRemoveMember", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	var failed freeipa.FailedOperations
+	if res != nil {
+		failed = res.Failed
+	}
+
+	diags.Append(membershipDiags("Failed to remove netgroup members", failed, err)...)
+
+	return
+}
@@ -0,0 +1,68 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNetgroupUpdate(t *testing.T) {
+	p := newTestProvider(t, map[string]rpcHandler{
+		"netgroup_remove_member": func(t *testing.T, params map[string]any) string {
+			if hosts, ok := params["host"].([]any); !ok || len(hosts) != 1 || hosts[0] != "old.example.test" {
+				t.Errorf("unexpected parameters %v", params)
+			}
+
+			return rpcResult(map[string]any{
+				"result":    map[string]any{"cn": []string{"exports"}},
+				"failed":    map[string]any{},
+				"completed": 1,
+			})
+		},
+		"netgroup_add_member": func(t *testing.T, params map[string]any) string {
+			// External hosts are added as hosts, FreeIPA records those it
+			// does not manage as external hosts.
+			if hosts, ok := params["host"].([]any); !ok || len(hosts) != 1 || hosts[0] != "nfs.legacy.example.com" {
+				t.Errorf("unexpected parameters %v", params)
+			}
+
+			if _, ok := params["user"]; ok {
+				t.Errorf("unexpected users %v", params["user"])
+			}
+
+			return rpcResult(map[string]any{
+				"result":    map[string]any{"cn": []string{"exports"}},
+				"failed":    map[string]any{},
+				"completed": 1,
+			})
+		},
+	})
+
+	ctx := context.Background()
+
+	users, _ := types.SetValueFrom(ctx, types.StringType, []string{"alice"})
+	oldHosts, _ := types.SetValueFrom(ctx, types.StringType, []string{"old.example.test"})
+	externalHosts, _ := types.SetValueFrom(ctx, types.StringType, []string{"nfs.legacy.example.com"})
+
+	current := NetgroupModel{
+		Name:             types.StringValue("exports"),
+		NisDomain:        types.StringValue("example.test"),
+		MemberUsers:      users,
+		MemberHosts:      oldHosts,
+		MemberGroups:     types.SetNull(types.StringType),
+		MemberHostgroups: types.SetNull(types.StringType),
+		MemberNetgroups:  types.SetNull(types.StringType),
+		ExternalHosts:    types.SetNull(types.StringType),
+	}
+
+	plan := current
+	plan.MemberHosts = types.SetNull(types.StringType)
+	plan.ExternalHosts = externalHosts
+
+	model := testUpdate(t, NewNetgroup(p), plan, plan, current)
+
+	if !model.ExternalHosts.Equal(externalHosts) {
+		t.Errorf("unexpected external_hosts %v, expected %v", model.ExternalHosts, externalHosts)
+	}
+}
@@ -0,0 +1,570 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/camptocamp/go-freeipa/freeipa"
+	"github.com/camptocamp/terraform-provider-freeipa/internal/provider"
+	"github.com/camptocamp/terraform-provider-freeipa/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var otpTokenTypes = []string{"totp", "hotp"}
+
+var otpTokenAlgorithms = []string{"sha1", "sha256", "sha384", "sha512"}
+
+type OTPToken struct {
+	provider *provider.Provider
+}
+
+type OTPTokenModel struct {
+	UniqueID       types.String `tfsdk:"unique_id"`
+	Type           types.String `tfsdk:"type"`
+	Description    types.String `tfsdk:"description"`
+	Owner          types.String `tfsdk:"owner"`
+	ManagedByUsers types.Set    `tfsdk:"managed_by_users"`
+	Disabled       types.Bool   `tfsdk:"disabled"`
+	Algorithm      types.String `tfsdk:"algorithm"`
+	Digits         types.Int64  `tfsdk:"digits"`
+	Interval       types.Int64  `tfsdk:"interval"`
+	URI            types.String `tfsdk:"uri"`
+	Secret         types.String `tfsdk:"secret"`
+}
+
+func (r *OTPToken) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_otp_token"
+}
+
+func (r *OTPToken) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version: 0,
+		Attributes: map[string]schema.Attribute{
+			"unique_id": schema.StringAttribute{
+				Description: "Unique ID of the token, generated when unset",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Description: "Type of the token, “totp” or “hotp”",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("totp"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description: "Token description",
+				Optional:    true,
+			},
+			"owner": schema.StringAttribute{
+				Description: "User the token is assigned to, the provider user when unset",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"managed_by_users": schema.SetAttribute{
+				Description: "Users allowed to manage the token, the owner when unset",
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"disabled": schema.BoolAttribute{
+				Description: "Whether the token is disabled",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"algorithm": schema.StringAttribute{
+				Description: "Token hash algorithm, one of “sha1”, “sha256”, “sha384” or “sha512”",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"digits": schema.Int64Attribute{
+				Description: "Number of digits of the token codes, 6 or 8",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"interval": schema.Int64Attribute{
+				Description: "Validity of the codes of TOTP tokens (seconds)",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"uri": schema.StringAttribute{
+				Description: "Provisioning URI of the token, only known when it is created",
+				Computed:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"secret": schema.StringAttribute{
+				Description: "Base32 secret of the token, only known when it is created",
+				Computed:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *OTPToken) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config OTPTokenModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Type.IsNull() && !config.Type.IsUnknown() {
+		if !slices.Contains(otpTokenTypes, config.Type.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("type"),
+				"Invalid configuration",
+				"“type” must be one of: "+strings.Join(otpTokenTypes, ", ")+".",
+			)
+		} else if config.Type.ValueString() == "hotp" && !config.Interval.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("interval"),
+				"Invalid configuration",
+				"“interval” cannot be set for HOTP tokens.",
+			)
+		}
+	}
+
+	if !config.Algorithm.IsNull() && !config.Algorithm.IsUnknown() {
+		if !slices.Contains(otpTokenAlgorithms, config.Algorithm.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("algorithm"),
+				"Invalid configuration",
+				"“algorithm” must be one of: "+strings.Join(otpTokenAlgorithms, ", ")+".",
+			)
+		}
+	}
+
+	if !config.Digits.IsNull() && !config.Digits.IsUnknown() {
+		if digits := config.Digits.ValueInt64(); digits != 6 && digits != 8 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("digits"),
+				"Invalid configuration",
+				"“digits” must be one of: 6, 8.",
+			)
+		}
+	}
+}
+
+func (r *OTPToken) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan, state OTPTokenModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var args []any
+
+	if !plan.UniqueID.IsUnknown() && !plan.UniqueID.IsNull() {
+		args = append(args, plan.UniqueID.ValueString())
+	}
+
+	optArgs := &freeipa.OtptokenAddOptionalArgs{
+		Type:                 plan.Type.ValueStringPointer(),
+		Description:          plan.Description.ValueStringPointer(),
+		Ipatokendisabled:     plan.Disabled.ValueBoolPointer(),
+		Ipatokenotpalgorithm: knownString(plan.Algorithm),
+		Ipatokenotpdigits:    knownInt(plan.Digits),
+		Ipatokentotptimestep: knownInt(plan.Interval),
+		NoQrcode:             freeipa.Bool(true),
+		All:                  freeipa.Bool(true),
+	}
+
+	if !plan.Owner.IsUnknown() {
+		optArgs.Ipatokenowner = plan.Owner.ValueStringPointer()
+	}
+
+	tflog.Trace(ctx, "Calling OtptokenAdd", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	// The client neither sends the unique ID nor decodes the token key, use
+	// a raw call instead.
+	var res struct {
+		rpcEntryResult
+		Value string `json:"value"`
+	}
+
+	err := r.provider.Call("otptoken_add", args, optArgs, &res)
+
+	// Do not log the token key.
+	loggedRes := maps.Clone(res.Result)
+	delete(loggedRes, "ipatokenotpkey")
+	delete(loggedRes, "uri")
+
+	tflog.Trace(ctx, "Called OtptokenAdd", map[string]any{
+		"res": loggedRes,
+		"err": err,
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create OTP token", "Reason: "+err.Error())
+		return
+	}
+
+	state = plan
+	state.UniqueID = types.StringValue(res.Value)
+	state.URI = types.StringPointerValue(entryString(res.Result, "uri"))
+	state.Secret = types.StringNull()
+
+	if uri, err := url.Parse(state.URI.ValueString()); err == nil && uri.Query().Has("secret") {
+		state.Secret = types.StringValue(uri.Query().Get("secret"))
+	}
+
+	state.readEntry(res.Result)
+
+	var diags diag.Diagnostics
+
+	managedByUsers := entryStrings(res.Result, "managedby_user")
+
+	state.ManagedByUsers, diags = membersSetValue(ctx, types.SetNull(types.StringType), &managedByUsers)
+	resp.Diagnostics.Append(diags...)
+
+	saveCreated(ctx, resp, state)
+	if resp.Diagnostics.HasError() || plan.ManagedByUsers.IsUnknown() {
+		return
+	}
+
+	var actualManagedByUsers, desiredManagedByUsers []string
+
+	resp.Diagnostics.Append(state.ManagedByUsers.ElementsAs(ctx, &actualManagedByUsers, false)...)
+	resp.Diagnostics.Append(plan.ManagedByUsers.ElementsAs(ctx, &desiredManagedByUsers, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.updateManagedByUsers(ctx, state.UniqueID.ValueString(), actualManagedByUsers, desiredManagedByUsers)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.ManagedByUsers = plan.ManagedByUsers
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *OTPToken) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state OTPTokenModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	optArgs := &freeipa.OtptokenShowOptionalArgs{
+		All: freeipa.Bool(true),
+	}
+
+	tflog.Trace(ctx, "Calling OtptokenShow", map[string]any{
+		"args":     state.UniqueID.ValueString(),
+		"opt_args": optArgs,
+	})
+
+	var res rpcEntryResult
+
+	err := r.provider.Call("otptoken_show", []any{state.UniqueID.ValueString()}, optArgs, &res)
+	tflog.Trace(ctx, "Called OtptokenShow", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if errors.As(err, &freeipaErr) && freeipaErr.Code == freeipa.NotFoundCode {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Failed to read OTP token", "Reason: "+err.Error())
+		return
+	}
+
+	state.readEntry(res.Result)
+
+	var diags diag.Diagnostics
+
+	managedByUsers := entryStrings(res.Result, "managedby_user")
+
+	state.ManagedByUsers, diags = membersSetValue(ctx, state.ManagedByUsers, &managedByUsers)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *OTPToken) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state, plan OTPTokenModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var hasDiff bool
+
+	args := &freeipa.OtptokenModArgs{
+		Ipatokenuniqueid: state.UniqueID.ValueString(),
+	}
+
+	optArgs := &freeipa.OtptokenModOptionalArgs{}
+
+	if !plan.Description.Equal(state.Description) {
+		hasDiff = true
+		optArgs.Description = freeipa.String(plan.Description.ValueString())
+	}
+
+	if !plan.Owner.Equal(state.Owner) {
+		hasDiff = true
+		optArgs.Ipatokenowner = freeipa.String(plan.Owner.ValueString())
+	}
+
+	if !plan.Disabled.Equal(state.Disabled) {
+		hasDiff = true
+		optArgs.Ipatokendisabled = plan.Disabled.ValueBoolPointer()
+	}
+
+	if hasDiff {
+		tflog.Trace(ctx, "Calling OtptokenMod", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		err := r.provider.Call("otptoken_mod", []any{args.Ipatokenuniqueid}, optArgs, nil)
+		tflog.Trace(ctx, "Called OtptokenMod", map[string]any{
+			"err": err,
+		})
+
+		if err != nil {
+			var freeipaErr *freeipa.Error
+
+			if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.EmptyModlistCode {
+				resp.Diagnostics.AddError("Failed to update OTP token", "Reason: "+err.Error())
+				return
+			}
+
+			tflog.Debug(ctx, "Updated OTP token has no effective difference", map[string]any{
+				"args":     args,
+				"opt_args": optArgs,
+			})
+		}
+	}
+
+	var actualManagedByUsers, desiredManagedByUsers []string
+
+	resp.Diagnostics.Append(state.ManagedByUsers.ElementsAs(ctx, &actualManagedByUsers, false)...)
+	resp.Diagnostics.Append(plan.ManagedByUsers.ElementsAs(ctx, &desiredManagedByUsers, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.updateManagedByUsers(ctx, state.UniqueID.ValueString(), actualManagedByUsers, desiredManagedByUsers)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Description = plan.Description
+	state.Owner = plan.Owner
+	state.Disabled = plan.Disabled
+	state.ManagedByUsers = plan.ManagedByUsers
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *OTPToken) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state OTPTokenModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.OtptokenDelArgs{
+		Ipatokenuniqueid: []string{state.UniqueID.ValueString()},
+	}
+
+	tflog.Trace(ctx, "Calling OtptokenDel", map[string]any{
+		"args":     args,
+		"opt_args": nil,
+	})
+
+	res, err := r.provider.Client().OtptokenDel(args, nil)
+
+	tflog.Trace(ctx, "Called OtptokenDel", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.NotFoundCode {
+			resp.Diagnostics.AddError("Failed to delete OTP token", "Reason: "+err.Error())
+			return
+		}
+	}
+}
+
+func (r *OTPToken) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	state := OTPTokenModel{
+		UniqueID:       types.StringValue(req.ID),
+		ManagedByUsers: types.SetNull(types.StringType),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func NewOTPToken(p *provider.Provider) resource.Resource {
+	r := &OTPToken{
+		provider: p,
+	}
+
+	var _ resource.Resource = r
+	var _ resource.ResourceWithValidateConfig = r
+	var _ resource.ResourceWithImportState = r
+
+	return r
+}
+
+func init() {
+	resources = append(resources, NewOTPToken)
+}
+
+// readEntry sets the attributes of the model from a token entry returned by a
+// raw call, except its managers.
+func (m *OTPTokenModel) readEntry(entry map[string]any) {
+	if tokenType := entryString(entry, "type"); tokenType != nil {
+		m.Type = types.StringValue(strings.ToLower(*tokenType))
+	}
+
+	m.Description = types.StringPointerValue(entryString(entry, "description"))
+	m.Owner = types.StringPointerValue(entryString(entry, "ipatokenowner"))
+	m.Disabled = types.BoolValue(false)
+
+	if disabled := entryBool(entry, "ipatokendisabled"); disabled != nil {
+		m.Disabled = types.BoolPointerValue(disabled)
+	}
+
+	m.Algorithm = types.StringPointerValue(entryString(entry, "ipatokenotpalgorithm"))
+	m.Digits = types.Int64PointerValue(entryInt64(entry, "ipatokenotpdigits"))
+	m.Interval = types.Int64PointerValue(entryInt64(entry, "ipatokentotptimestep"))
+}
+
+func (r *OTPToken) updateManagedByUsers(ctx context.Context, uniqueID string, actualUsers, desiredUsers []string) (diags diag.Diagnostics) {
+	usersToAdd, usersToRemove := utils.SetDiff(actualUsers, desiredUsers)
+
+	if len(usersToAdd) > 0 {
+		args := &freeipa.OtptokenAddManagedbyArgs{
+			Ipatokenuniqueid: uniqueID,
+		}
+
+		optArgs := &freeipa.OtptokenAddManagedbyOptionalArgs{
+			User: &usersToAdd,
+		}
+
+		tflog.Trace(ctx, "Calling OtptokenAddManagedby", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		res, err := r.provider.Client().OtptokenAddManagedby(args, optArgs)
+
+		tflog.Trace(ctx, "Called OtptokenAddManagedby", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		var failed freeipa.FailedOperations
+		if res != nil {
+			failed = res.Failed
+		}
+
+		diags.Append(membershipDiags("Failed to add OTP token managers", failed, err)...)
+	}
+
+	// Remove the managers after adding the new ones, so that the token
+	// stays manageable.
+	if len(usersToRemove) > 0 {
+		args := &freeipa.OtptokenRemoveManagedbyArgs{
+			Ipatokenuniqueid: uniqueID,
+		}
+
+		optArgs := &freeipa.OtptokenRemoveManagedbyOptionalArgs{
+			User: &usersToRemove,
+		}
+
+		tflog.Trace(ctx, "Calling OtptokenRemoveManagedby", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		res, err := r.provider.Client().OtptokenRemoveManagedby(args, optArgs)
+
+		tflog.Trace(ctx, "Called OtptokenRemoveManagedby", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		var failed freeipa.FailedOperations
+		if res != nil {
+			failed = res.Failed
+		}
+
+		diags.Append(membershipDiags("Failed to remove OTP token managers", failed, err)...)
+	}
+
+	return
+}
@@ -0,0 +1,69 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestOTPTokenCreate(t *testing.T) {
+	p := newTestProvider(t, map[string]rpcHandler{
+		"otptoken_add": func(t *testing.T, params map[string]any) string {
+			if params["ipatokenowner"] != "automation" || params["type"] != "totp" {
+				t.Errorf("unexpected parameters %v", params)
+			}
+
+			// The token key is returned as bytes, which the client fails to
+			// decode.
+			return rpcResult(map[string]any{
+				"summary": "Added OTP token \"4c1f2c63\"",
+				"value":   "4c1f2c63",
+				"result": map[string]any{
+					"ipatokenuniqueid":     []string{"4c1f2c63"},
+					"type":                 "TOTP",
+					"ipatokenowner":        []string{"automation"},
+					"managedby_user":       []string{"automation"},
+					"ipatokenotpalgorithm": []string{"sha1"},
+					"ipatokenotpdigits":    []string{"6"},
+					"ipatokentotptimestep": []string{"30"},
+					"ipatokenotpkey":       []any{map[string]any{"__base64__": "c2VjcmV0"}},
+					"uri":                  "otpauth://totp/automation@EXAMPLE.TEST:4c1f2c63?digits=6&secret=ONSWG4TFOQ%3D%3D%3D%3D&period=30&algorithm=SHA1&issuer=automation%40EXAMPLE.TEST",
+				},
+			})
+		},
+	})
+
+	ctx := context.Background()
+
+	plan := OTPTokenModel{
+		UniqueID:       types.StringUnknown(),
+		Type:           types.StringValue("totp"),
+		Owner:          types.StringValue("automation"),
+		ManagedByUsers: types.SetUnknown(types.StringType),
+		Disabled:       types.BoolValue(false),
+		Algorithm:      types.StringUnknown(),
+		Digits:         types.Int64Unknown(),
+		Interval:       types.Int64Unknown(),
+		URI:            types.StringUnknown(),
+		Secret:         types.StringUnknown(),
+	}
+
+	model := testCreate(t, NewOTPToken(p), plan, plan)
+
+	managedByUsers, _ := types.SetValueFrom(ctx, types.StringType, []string{"automation"})
+
+	testValues(t, map[string]testValue{
+		"unique_id":        {model.UniqueID, types.StringValue("4c1f2c63")},
+		"type":             {model.Type, types.StringValue("totp")},
+		"algorithm":        {model.Algorithm, types.StringValue("sha1")},
+		"digits":           {model.Digits, types.Int64Value(6)},
+		"interval":         {model.Interval, types.Int64Value(30)},
+		"managed_by_users": {model.ManagedByUsers, managedByUsers},
+		"secret":           {model.Secret, types.StringValue("ONSWG4TFOQ====")},
+	})
+
+	if model.URI.IsNull() {
+		t.Errorf("expected the provisioning URI to be set")
+	}
+}
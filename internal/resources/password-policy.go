@@ -0,0 +1,406 @@
+package resources
+
+import (
+	"context"
+	"errors"
+
+	"github.com/camptocamp/go-freeipa/freeipa"
+	"github.com/camptocamp/terraform-provider-freeipa/internal/provider"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type PasswordPolicy struct {
+	provider *provider.Provider
+}
+
+type PasswordPolicyModel struct {
+	Name         types.String `tfsdk:"cn"`
+	Priority     types.Int64  `tfsdk:"priority"`
+	MaxLife      types.Int64  `tfsdk:"maxlife"`
+	MinLife      types.Int64  `tfsdk:"minlife"`
+	History      types.Int64  `tfsdk:"history"`
+	MinClasses   types.Int64  `tfsdk:"minclasses"`
+	MinLength    types.Int64  `tfsdk:"minlength"`
+	MaxFail      types.Int64  `tfsdk:"maxfail"`
+	FailInterval types.Int64  `tfsdk:"failinterval"`
+	LockoutTime  types.Int64  `tfsdk:"lockouttime"`
+	MaxRepeat    types.Int64  `tfsdk:"maxrepeat"`
+	MaxSequence  types.Int64  `tfsdk:"maxsequence"`
+	DictCheck    types.Bool   `tfsdk:"dictcheck"`
+	UserCheck    types.Bool   `tfsdk:"usercheck"`
+	GraceLimit   types.Int64  `tfsdk:"gracelimit"`
+}
+
+// passwordPolicySettings holds the settings shared by group password policies
+// and the global password policy.
+type passwordPolicySettings struct {
+	MaxLife      types.Int64
+	MinLife      types.Int64
+	History      types.Int64
+	MinClasses   types.Int64
+	MinLength    types.Int64
+	MaxFail      types.Int64
+	FailInterval types.Int64
+	LockoutTime  types.Int64
+	MaxRepeat    types.Int64
+	MaxSequence  types.Int64
+	DictCheck    types.Bool
+	UserCheck    types.Bool
+	GraceLimit   types.Int64
+}
+
+func (m *PasswordPolicyModel) settings() passwordPolicySettings {
+	return passwordPolicySettings{
+		MaxLife:      m.MaxLife,
+		MinLife:      m.MinLife,
+		History:      m.History,
+		MinClasses:   m.MinClasses,
+		MinLength:    m.MinLength,
+		MaxFail:      m.MaxFail,
+		FailInterval: m.FailInterval,
+		LockoutTime:  m.LockoutTime,
+		MaxRepeat:    m.MaxRepeat,
+		MaxSequence:  m.MaxSequence,
+		DictCheck:    m.DictCheck,
+		UserCheck:    m.UserCheck,
+		GraceLimit:   m.GraceLimit,
+	}
+}
+
+func (m *PasswordPolicyModel) setSettings(s passwordPolicySettings) {
+	m.MaxLife = s.MaxLife
+	m.MinLife = s.MinLife
+	m.History = s.History
+	m.MinClasses = s.MinClasses
+	m.MinLength = s.MinLength
+	m.MaxFail = s.MaxFail
+	m.FailInterval = s.FailInterval
+	m.LockoutTime = s.LockoutTime
+	m.MaxRepeat = s.MaxRepeat
+	m.MaxSequence = s.MaxSequence
+	m.DictCheck = s.DictCheck
+	m.UserCheck = s.UserCheck
+	m.GraceLimit = s.GraceLimit
+}
+
+// passwordPolicyAttributes returns the schema of the settings shared by group
+// password policies and the global password policy. Settings missing from
+// the configuration keep the value they have in FreeIPA.
+func passwordPolicyAttributes() map[string]schema.Attribute {
+	intAttribute := func(description string) schema.Int64Attribute {
+		return schema.Int64Attribute{
+			Description: description,
+			Optional:    true,
+			Computed:    true,
+			PlanModifiers: []planmodifier.Int64{
+				int64planmodifier.UseStateForUnknown(),
+			},
+		}
+	}
+
+	boolAttribute := func(description string) schema.BoolAttribute {
+		return schema.BoolAttribute{
+			Description: description,
+			Optional:    true,
+			Computed:    true,
+			PlanModifiers: []planmodifier.Bool{
+				boolplanmodifier.UseStateForUnknown(),
+			},
+		}
+	}
+
+	return map[string]schema.Attribute{
+		"maxlife":      intAttribute("Maximum password lifetime (in days)"),
+		"minlife":      intAttribute("Minimum password lifetime (in hours)"),
+		"history":      intAttribute("Password history size"),
+		"minclasses":   intAttribute("Minimum number of character classes"),
+		"minlength":    intAttribute("Minimum length of password"),
+		"maxfail":      intAttribute("Consecutive failures before lockout"),
+		"failinterval": intAttribute("Period after which failure count will be reset (seconds)"),
+		"lockouttime":  intAttribute("Period for which lockout is enforced (seconds)"),
+		"maxrepeat":    intAttribute("Maximum number of same consecutive characters"),
+		"maxsequence":  intAttribute("The max. length of monotonic character sequences (abcd)"),
+		"dictcheck":    boolAttribute("Check if the password is a dictionary word"),
+		"usercheck":    boolAttribute("Check if the password contains the username"),
+		"gracelimit":   intAttribute("Number of LDAP authentications allowed after expiration"),
+	}
+}
+
+// optArgs returns the options setting the known settings, which pwpolicy_add
+// accepts as well.
+func (s passwordPolicySettings) optArgs() *freeipa.PwpolicyModOptionalArgs {
+	return &freeipa.PwpolicyModOptionalArgs{
+		Krbmaxpwdlife:              knownInt(s.MaxLife),
+		Krbminpwdlife:              knownInt(s.MinLife),
+		Krbpwdhistorylength:        knownInt(s.History),
+		Krbpwdmindiffchars:         knownInt(s.MinClasses),
+		Krbpwdminlength:            knownInt(s.MinLength),
+		Krbpwdmaxfailure:           knownInt(s.MaxFail),
+		Krbpwdfailurecountinterval: knownInt(s.FailInterval),
+		Krbpwdlockoutduration:      knownInt(s.LockoutTime),
+		Ipapwdmaxrepeat:            knownInt(s.MaxRepeat),
+		Ipapwdmaxsequence:          knownInt(s.MaxSequence),
+		Ipapwddictcheck:            knownBool(s.DictCheck),
+		Ipapwdusercheck:            knownBool(s.UserCheck),
+		Passwordgracelimit:         knownInt(s.GraceLimit),
+		All:                        freeipa.Bool(true),
+	}
+}
+
+// passwordPolicySettingsFromEntry returns the settings of a password policy
+// entry returned by a raw call.
+func passwordPolicySettingsFromEntry(entry map[string]any) passwordPolicySettings {
+	return passwordPolicySettings{
+		MaxLife:      types.Int64PointerValue(entryInt64(entry, "krbmaxpwdlife")),
+		MinLife:      types.Int64PointerValue(entryInt64(entry, "krbminpwdlife")),
+		History:      types.Int64PointerValue(entryInt64(entry, "krbpwdhistorylength")),
+		MinClasses:   types.Int64PointerValue(entryInt64(entry, "krbpwdmindiffchars")),
+		MinLength:    types.Int64PointerValue(entryInt64(entry, "krbpwdminlength")),
+		MaxFail:      types.Int64PointerValue(entryInt64(entry, "krbpwdmaxfailure")),
+		FailInterval: types.Int64PointerValue(entryInt64(entry, "krbpwdfailurecountinterval")),
+		LockoutTime:  types.Int64PointerValue(entryInt64(entry, "krbpwdlockoutduration")),
+		MaxRepeat:    types.Int64PointerValue(entryInt64(entry, "ipapwdmaxrepeat")),
+		MaxSequence:  types.Int64PointerValue(entryInt64(entry, "ipapwdmaxsequence")),
+		DictCheck:    types.BoolPointerValue(entryBool(entry, "ipapwddictcheck")),
+		UserCheck:    types.BoolPointerValue(entryBool(entry, "ipapwdusercheck")),
+		GraceLimit:   types.Int64PointerValue(entryInt64(entry, "passwordgracelimit")),
+	}
+}
+
+func (r *PasswordPolicy) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_password_policy"
+}
+
+func (r *PasswordPolicy) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := passwordPolicyAttributes()
+
+	attributes["cn"] = schema.StringAttribute{
+		Description: "Group the password policy applies to",
+		Required:    true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+
+	attributes["priority"] = schema.Int64Attribute{
+		Description: "Priority of the policy (higher number means lower priority)",
+		Required:    true,
+	}
+
+	resp.Schema = schema.Schema{
+		Version:     0,
+		Description: "Manages the password policy of a group",
+		Attributes:  attributes,
+	}
+}
+
+func (r *PasswordPolicy) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan, state PasswordPolicyModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.PwpolicyAddArgs{
+		Cn:          plan.Name.ValueString(),
+		Cospriority: int(plan.Priority.ValueInt64()),
+	}
+
+	optArgs := plan.settings().optArgs()
+	optArgs.Cospriority = &args.Cospriority
+
+	tflog.Trace(ctx, "Calling PwpolicyAdd", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	// The result is decoded like the entries of showPasswordPolicy.
+	var res rpcEntryResult
+
+	err := r.provider.Call("pwpolicy_add", []any{args.Cn}, optArgs, &res)
+	tflog.Trace(ctx, "Called PwpolicyAdd", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create password policy", "Reason: "+err.Error())
+		return
+	}
+
+	state = plan
+	state.setSettings(passwordPolicySettingsFromEntry(res.Result))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *PasswordPolicy) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state PasswordPolicyModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entry, err := showPasswordPolicy(ctx, r.provider, []any{state.Name.ValueString()})
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if errors.As(err, &freeipaErr) && freeipaErr.Code == freeipa.NotFoundCode {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Failed to read password policy", "Reason: "+err.Error())
+		return
+	}
+
+	state.Priority = types.Int64PointerValue(entryInt64(entry, "cospriority"))
+	state.setSettings(passwordPolicySettingsFromEntry(entry))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *PasswordPolicy) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state, plan PasswordPolicyModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	optArgs := plan.settings().optArgs()
+	optArgs.Cospriority = knownInt(plan.Priority)
+
+	diags := modPasswordPolicy(ctx, r.provider, []any{plan.Name.ValueString()}, optArgs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state = plan
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *PasswordPolicy) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state PasswordPolicyModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.PwpolicyDelArgs{
+		Cn: []string{state.Name.ValueString()},
+	}
+
+	tflog.Trace(ctx, "Calling PwpolicyDel", map[string]any{
+		"args":     args,
+		"opt_args": nil,
+	})
+
+	res, err := r.provider.Client().PwpolicyDel(args, nil)
+
+	tflog.Trace(ctx, "Called PwpolicyDel", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.NotFoundCode {
+			resp.Diagnostics.AddError("Failed to delete password policy", "Reason: "+err.Error())
+			return
+		}
+	}
+}
+
+func (r *PasswordPolicy) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	state := PasswordPolicyModel{
+		Name: types.StringValue(req.ID),
+	}
+
+	state.setSettings(passwordPolicySettingsFromEntry(nil))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func NewPasswordPolicy(p *provider.Provider) resource.Resource {
+	r := &PasswordPolicy{
+		provider: p,
+	}
+
+	var _ resource.Resource = r
+	var _ resource.ResourceWithImportState = r
+
+	return r
+}
+
+func init() {
+	resources = append(resources, NewPasswordPolicy)
+}
+
+// showPasswordPolicy returns the entry of a password policy, the global one
+// when args is empty. The client has no group argument and fails to decode the
+// global policy, which has no priority, use a raw call instead.
+func showPasswordPolicy(ctx context.Context, p *provider.Provider, args []any) (map[string]any, error) {
+	optArgs := &freeipa.PwpolicyShowOptionalArgs{
+		All: freeipa.Bool(true),
+	}
+
+	tflog.Trace(ctx, "Calling PwpolicyShow", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	var res rpcEntryResult
+
+	err := p.Call("pwpolicy_show", args, optArgs, &res)
+	tflog.Trace(ctx, "Called PwpolicyShow", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	return res.Result, err
+}
+
+// modPasswordPolicy modifies a password policy, the global one when args is
+// empty.
+func modPasswordPolicy(ctx context.Context, p *provider.Provider, args []any, optArgs *freeipa.PwpolicyModOptionalArgs) (diags diag.Diagnostics) {
+	tflog.Trace(ctx, "Calling PwpolicyMod", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	err := p.Call("pwpolicy_mod", args, optArgs, nil)
+	tflog.Trace(ctx, "Called PwpolicyMod", map[string]any{
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.EmptyModlistCode {
+			diags.AddError("Failed to update password policy", "Reason: "+err.Error())
+			return
+		}
+
+		tflog.Debug(ctx, "Updated password policy has no effective difference", map[string]any{
+			"args": args,
+		})
+	}
+
+	return
+}
@@ -0,0 +1,47 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPasswordPolicyRead(t *testing.T) {
+	p := newTestProvider(t, map[string]rpcHandler{
+		"pwpolicy_show": func(t *testing.T, params map[string]any) string {
+			return rpcResult(map[string]any{
+				"summary": nil,
+				"value":   "admins",
+				"result": map[string]any{
+					"cn":                  []string{"admins"},
+					"cospriority":         []string{"10"},
+					"krbmaxpwdlife":       []string{"90"},
+					"krbpwdminlength":     []string{"12"},
+					"ipapwdmaxrepeat":     []string{"0"},
+					"ipapwddictcheck":     []string{"TRUE"},
+					"ipapwdusercheck":     false,
+					"passwordgracelimit":  []string{"-1"},
+					"krbpwdhistorylength": []string{"4"},
+				},
+			})
+		},
+	})
+
+	model := testRead(t, NewPasswordPolicy(p), PasswordPolicyModel{
+		Name: types.StringValue("admins"),
+	})
+
+	testValues(t, map[string]testValue{
+		"priority":   {model.Priority, types.Int64Value(10)},
+		"maxlife":    {model.MaxLife, types.Int64Value(90)},
+		"minlength":  {model.MinLength, types.Int64Value(12)},
+		"history":    {model.History, types.Int64Value(4)},
+		"maxrepeat":  {model.MaxRepeat, types.Int64Value(0)},
+		"gracelimit": {model.GraceLimit, types.Int64Value(-1)},
+		"maxfail":    {model.MaxFail, types.Int64Null()},
+	})
+
+	if !model.DictCheck.ValueBool() || model.UserCheck.ValueBool() {
+		t.Errorf("unexpected checks %v, %v", model.DictCheck, model.UserCheck)
+	}
+}
@@ -0,0 +1,469 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/camptocamp/go-freeipa/freeipa"
+	"github.com/camptocamp/terraform-provider-freeipa/internal/provider"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var permissionRights = []string{"read", "search", "compare", "write", "add", "delete", "all"}
+
+var permissionBindTypes = []string{"permission", "all", "anonymous", "self"}
+
+type Permission struct {
+	provider *provider.Provider
+}
+
+type PermissionModel struct {
+	Name        types.String `tfsdk:"cn"`
+	Rights      types.Set    `tfsdk:"rights"`
+	Attrs       types.Set    `tfsdk:"attrs"`
+	BindType    types.String `tfsdk:"bind_type"`
+	Subtree     types.String `tfsdk:"subtree"`
+	Filters     types.Set    `tfsdk:"filters"`
+	Target      types.String `tfsdk:"target"`
+	TargetTo    types.String `tfsdk:"target_to"`
+	TargetFrom  types.String `tfsdk:"target_from"`
+	MemberOf    types.Set    `tfsdk:"member_of"`
+	TargetGroup types.String `tfsdk:"target_group"`
+	Type        types.String `tfsdk:"type"`
+}
+
+func (r *Permission) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_permission"
+}
+
+func (r *Permission) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version:     0,
+		Description: "Manages a permission, the access rights granted on directory entries by the privileges including it",
+		Attributes: map[string]schema.Attribute{
+			"cn": schema.StringAttribute{
+				Description: "Permission name",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"rights": schema.SetAttribute{
+				Description: "Rights to grant (read, search, compare, write, add, delete, all)",
+				ElementType: types.StringType,
+				Required:    true,
+			},
+			"attrs": schema.SetAttribute{
+				Description: "Attributes to which the permission applies",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"bind_type": schema.StringAttribute{
+				Description: "Bind rule type (permission, all, anonymous, self)",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("permission"),
+			},
+			"subtree": schema.StringAttribute{
+				Description: "Subtree to apply permissions to, set by FreeIPA according to the type when unset",
+				Optional:    true,
+				Computed:    true,
+			},
+			"filters": schema.SetAttribute{
+				Description: "Extra target filters",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"target": schema.StringAttribute{
+				Description: "DN to apply the permission to, set by FreeIPA according to the target group when unset",
+				Optional:    true,
+				Computed:    true,
+			},
+			"target_to": schema.StringAttribute{
+				Description: "DN subtree where an entry can be moved to",
+				Optional:    true,
+			},
+			"target_from": schema.StringAttribute{
+				Description: "DN subtree from where an entry can be moved",
+				Optional:    true,
+			},
+			"member_of": schema.SetAttribute{
+				Description: "Target members of these groups",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"target_group": schema.StringAttribute{
+				Description: "User group to apply permissions to",
+				Optional:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "Type of FreeIPA object to apply permissions to (user, group, host, service, ...)",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *Permission) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config PermissionModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, right := range knownSetStrings(config.Rights) {
+		if !slices.Contains(permissionRights, right) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("rights"),
+				"Invalid configuration",
+				"“rights” must only contain: "+strings.Join(permissionRights, ", ")+".",
+			)
+			break
+		}
+	}
+
+	if !config.BindType.IsNull() && !config.BindType.IsUnknown() {
+		if !slices.Contains(permissionBindTypes, config.BindType.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("bind_type"),
+				"Invalid configuration",
+				"“bind_type” must be one of: "+strings.Join(permissionBindTypes, ", ")+".",
+			)
+		}
+	}
+}
+
+func (r *Permission) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan, state PermissionModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var rights, attrs, filters, memberOf []string
+
+	resp.Diagnostics.Append(plan.Rights.ElementsAs(ctx, &rights, false)...)
+	resp.Diagnostics.Append(plan.Attrs.ElementsAs(ctx, &attrs, false)...)
+	resp.Diagnostics.Append(plan.Filters.ElementsAs(ctx, &filters, false)...)
+	resp.Diagnostics.Append(plan.MemberOf.ElementsAs(ctx, &memberOf, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.PermissionAddArgs{
+		Cn: plan.Name.ValueString(),
+	}
+
+	optArgs := &freeipa.PermissionAddOptionalArgs{
+		Ipapermright:        membersPointer(rights),
+		Attrs:               membersPointer(attrs),
+		Ipapermbindruletype: knownString(plan.BindType),
+		Ipapermlocation:     knownString(plan.Subtree),
+		Extratargetfilter:   membersPointer(filters),
+		Ipapermtarget:       knownString(plan.Target),
+		Ipapermtargetto:     plan.TargetTo.ValueStringPointer(),
+		Ipapermtargetfrom:   plan.TargetFrom.ValueStringPointer(),
+		Memberof:            membersPointer(memberOf),
+		Targetgroup:         plan.TargetGroup.ValueStringPointer(),
+		Type:                plan.Type.ValueStringPointer(),
+		All:                 freeipa.Bool(true),
+	}
+
+	tflog.Trace(ctx, "Calling PermissionAdd", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	var res rpcEntryResult
+
+	err := r.provider.Call("permission_add", []any{args.Cn}, optArgs, &res)
+	tflog.Trace(ctx, "Called PermissionAdd", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create permission", "Reason: "+err.Error())
+		return
+	}
+
+	state = plan
+	resp.Diagnostics.Append(state.readEntry(ctx, res.Result)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *Permission) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state PermissionModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.PermissionShowArgs{
+		Cn: state.Name.ValueString(),
+	}
+
+	optArgs := &freeipa.PermissionShowOptionalArgs{
+		All: freeipa.Bool(true),
+	}
+
+	tflog.Trace(ctx, "Calling PermissionShow", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	var res rpcEntryResult
+
+	err := r.provider.Call("permission_show", []any{args.Cn}, optArgs, &res)
+	tflog.Trace(ctx, "Called PermissionShow", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if errors.As(err, &freeipaErr) && freeipaErr.Code == freeipa.NotFoundCode {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Failed to read permission", "Reason: "+err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(state.readEntry(ctx, res.Result)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *Permission) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state, plan PermissionModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var hasDiff bool
+
+	args := &freeipa.PermissionModArgs{
+		Cn: state.Name.ValueString(),
+	}
+
+	optArgs := &freeipa.PermissionModOptionalArgs{
+		All: freeipa.Bool(true),
+	}
+
+	for _, attr := range []struct {
+		plan, state types.String
+		value       **string
+	}{
+		{plan.BindType, state.BindType, &optArgs.Ipapermbindruletype},
+		{plan.Subtree, state.Subtree, &optArgs.Ipapermlocation},
+		{plan.Target, state.Target, &optArgs.Ipapermtarget},
+		{plan.TargetTo, state.TargetTo, &optArgs.Ipapermtargetto},
+		{plan.TargetFrom, state.TargetFrom, &optArgs.Ipapermtargetfrom},
+		{plan.TargetGroup, state.TargetGroup, &optArgs.Targetgroup},
+		{plan.Type, state.Type, &optArgs.Type},
+	} {
+		// Computed attributes left unset are derived by FreeIPA.
+		if !attr.plan.IsUnknown() && !attr.plan.Equal(attr.state) {
+			*attr.value = freeipa.String(attr.plan.ValueString())
+			hasDiff = true
+		}
+	}
+
+	for _, attr := range []struct {
+		plan, state types.Set
+		values      **[]string
+	}{
+		{plan.Rights, state.Rights, &optArgs.Ipapermright},
+		{plan.Attrs, state.Attrs, &optArgs.Attrs},
+		{plan.Filters, state.Filters, &optArgs.Extratargetfilter},
+		{plan.MemberOf, state.MemberOf, &optArgs.Memberof},
+	} {
+		if !attr.plan.Equal(attr.state) {
+			values := []string{}
+
+			resp.Diagnostics.Append(attr.plan.ElementsAs(ctx, &values, false)...)
+
+			*attr.values = &values
+			hasDiff = true
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if hasDiff {
+		tflog.Trace(ctx, "Calling PermissionMod", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		var res rpcEntryResult
+
+		err := r.provider.Call("permission_mod", []any{args.Cn}, optArgs, &res)
+		tflog.Trace(ctx, "Called PermissionMod", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		if err != nil {
+			var freeipaErr *freeipa.Error
+
+			if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.EmptyModlistCode {
+				resp.Diagnostics.AddError("Failed to update permission", "Reason: "+err.Error())
+				return
+			}
+
+			tflog.Debug(ctx, "Updated permission has no effective difference", map[string]any{
+				"args":     args,
+				"opt_args": optArgs,
+			})
+		} else {
+			resp.Diagnostics.Append(plan.readEntry(ctx, res.Result)...)
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The derived attributes are unchanged when there was no effective
+	// difference.
+	if plan.Subtree.IsUnknown() {
+		plan.Subtree = state.Subtree
+	}
+
+	if plan.Target.IsUnknown() {
+		plan.Target = state.Target
+	}
+
+	state = plan
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *Permission) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state PermissionModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.PermissionDelArgs{
+		Cn: []string{state.Name.ValueString()},
+	}
+
+	tflog.Trace(ctx, "Calling PermissionDel", map[string]any{
+		"args":     args,
+		"opt_args": nil,
+	})
+
+	res, err := r.provider.Client().PermissionDel(args, nil)
+
+	tflog.Trace(ctx, "Called PermissionDel", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.NotFoundCode {
+			resp.Diagnostics.AddError("Failed to delete permission", "Reason: "+err.Error())
+			return
+		}
+	}
+}
+
+func (r *Permission) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	state := PermissionModel{
+		Name:     types.StringValue(req.ID),
+		Rights:   types.SetNull(types.StringType),
+		Attrs:    types.SetNull(types.StringType),
+		Filters:  types.SetNull(types.StringType),
+		MemberOf: types.SetNull(types.StringType),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func NewPermission(p *provider.Provider) resource.Resource {
+	r := &Permission{
+		provider: p,
+	}
+
+	var _ resource.Resource = r
+	var _ resource.ResourceWithImportState = r
+	var _ resource.ResourceWithValidateConfig = r
+
+	return r
+}
+
+func init() {
+	resources = append(resources, NewPermission)
+}
+
+func (m *PermissionModel) readEntry(ctx context.Context, entry map[string]any) (diags diag.Diagnostics) {
+	var d diag.Diagnostics
+
+	var currentAttrs []string
+
+	diags.Append(m.Attrs.ElementsAs(ctx, &currentAttrs, false)...)
+
+	attrs := keepCase(entryStrings(entry, "attrs"), currentAttrs)
+
+	m.Attrs, d = membersSetValue(ctx, m.Attrs, &attrs)
+	diags.Append(d...)
+
+	for _, attr := range []struct {
+		value *types.Set
+		name  string
+	}{
+		{&m.Rights, "ipapermright"},
+		{&m.Filters, "extratargetfilter"},
+		{&m.MemberOf, "memberof"},
+	} {
+		values := entryStrings(entry, attr.name)
+
+		*attr.value, d = membersSetValue(ctx, *attr.value, &values)
+		diags.Append(d...)
+	}
+
+	m.BindType = types.StringPointerValue(entryString(entry, "ipapermbindruletype"))
+	m.Subtree = types.StringPointerValue(entryString(entry, "ipapermlocation"))
+	m.Target = types.StringPointerValue(entryString(entry, "ipapermtarget"))
+	m.TargetTo = types.StringPointerValue(entryString(entry, "ipapermtargetto"))
+	m.TargetFrom = types.StringPointerValue(entryString(entry, "ipapermtargetfrom"))
+	m.TargetGroup = types.StringPointerValue(entryString(entry, "targetgroup"))
+	m.Type = types.StringPointerValue(entryString(entry, "type"))
+
+	return
+}
@@ -0,0 +1,65 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPermissionCreate(t *testing.T) {
+	p := newTestProvider(t, map[string]rpcHandler{
+		"permission_add": func(t *testing.T, params map[string]any) string {
+			if params["type"] != "user" || params["ipapermbindruletype"] != "permission" {
+				t.Errorf("unexpected parameters %v", params)
+			}
+
+			if _, ok := params["ipapermlocation"]; ok {
+				t.Errorf("unexpected subtree %v", params["ipapermlocation"])
+			}
+
+			// The bind rule and ACI are only returned for some permissions,
+			// which the client fails to decode.
+			return rpcResult(map[string]any{
+				"summary": "Added permission \"Read employee numbers\"",
+				"value":   "Read employee numbers",
+				"result": map[string]any{
+					"cn":                  []string{"Read employee numbers"},
+					"ipapermright":        []string{"read", "search"},
+					"attrs":               []string{"employeenumber"},
+					"ipapermbindruletype": []string{"permission"},
+					"ipapermlocation":     []string{"cn=users,cn=accounts,dc=example,dc=test"},
+					"ipapermtargetfilter": []string{"(objectclass=posixaccount)"},
+					"type":                "user",
+				},
+			})
+		},
+	})
+
+	ctx := context.Background()
+
+	rights, _ := types.SetValueFrom(ctx, types.StringType, []string{"read", "search"})
+	attrs, _ := types.SetValueFrom(ctx, types.StringType, []string{"employeeNumber"})
+	plan := PermissionModel{
+		Name:     types.StringValue("Read employee numbers"),
+		Rights:   rights,
+		Attrs:    attrs,
+		BindType: types.StringValue("permission"),
+		Subtree:  types.StringUnknown(),
+		Filters:  types.SetNull(types.StringType),
+		Target:   types.StringUnknown(),
+		MemberOf: types.SetNull(types.StringType),
+		Type:     types.StringValue("user"),
+	}
+
+	model := testCreate(t, NewPermission(p), plan, plan)
+
+	testValues(t, map[string]testValue{
+		"rights":  {model.Rights, rights},
+		"attrs":   {model.Attrs, attrs},
+		"subtree": {model.Subtree, types.StringValue("cn=users,cn=accounts,dc=example,dc=test")},
+		"target":  {model.Target, types.StringNull()},
+		"filters": {model.Filters, types.SetNull(types.StringType)},
+		"type":    {model.Type, types.StringValue("user")},
+	})
+}
@@ -0,0 +1,341 @@
+package resources
+
+import (
+	"context"
+	"errors"
+
+	"github.com/camptocamp/go-freeipa/freeipa"
+	"github.com/camptocamp/terraform-provider-freeipa/internal/provider"
+	"github.com/camptocamp/terraform-provider-freeipa/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type Privilege struct {
+	provider *provider.Provider
+}
+
+type PrivilegeModel struct {
+	Name        types.String `tfsdk:"cn"`
+	Description types.String `tfsdk:"description"`
+	Permissions types.Set    `tfsdk:"permissions"`
+}
+
+func (r *Privilege) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_privilege"
+}
+
+func (r *Privilege) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version:     0,
+		Description: "Manages a privilege, the set of permissions granted by the roles including it",
+		Attributes: map[string]schema.Attribute{
+			"cn": schema.StringAttribute{
+				Description: "Privilege name",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description: "Privilege description",
+				Optional:    true,
+			},
+			"permissions": schema.SetAttribute{
+				Description: "Permissions granted by the privilege",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *Privilege) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan, state PrivilegeModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var permissions []string
+
+	resp.Diagnostics.Append(plan.Permissions.ElementsAs(ctx, &permissions, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.PrivilegeAddArgs{
+		Cn: plan.Name.ValueString(),
+	}
+
+	optArgs := &freeipa.PrivilegeAddOptionalArgs{
+		Description: plan.Description.ValueStringPointer(),
+	}
+
+	tflog.Trace(ctx, "Calling PrivilegeAdd", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	res, err := r.provider.Client().PrivilegeAdd(args, optArgs)
+
+	tflog.Trace(ctx, "Called PrivilegeAdd", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create privilege", "Reason: "+err.Error())
+		return
+	}
+
+	state = plan
+
+	saveCreated(ctx, resp, state)
+
+	resp.Diagnostics.Append(r.updatePermissions(ctx, plan.Name.ValueString(), nil, permissions)...)
+}
+
+func (r *Privilege) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state PrivilegeModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.PrivilegeShowArgs{
+		Cn: state.Name.ValueString(),
+	}
+
+	optArgs := &freeipa.PrivilegeShowOptionalArgs{
+		All: freeipa.Bool(true),
+	}
+
+	tflog.Trace(ctx, "Calling PrivilegeShow", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	res, err := r.provider.Client().PrivilegeShow(args, optArgs)
+
+	tflog.Trace(ctx, "Called PrivilegeShow", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if errors.As(err, &freeipaErr) && freeipaErr.Code == freeipa.NotFoundCode {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Failed to read privilege", "Reason: "+err.Error())
+		return
+	}
+
+	var diags diag.Diagnostics
+
+	state.Description = types.StringPointerValue(res.Result.Description)
+
+	state.Permissions, diags = membersSetValue(ctx, state.Permissions, res.Result.MemberofPermission)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *Privilege) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state, plan PrivilegeModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var actualPermissions, desiredPermissions []string
+
+	resp.Diagnostics.Append(state.Permissions.ElementsAs(ctx, &actualPermissions, false)...)
+	resp.Diagnostics.Append(plan.Permissions.ElementsAs(ctx, &desiredPermissions, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Description.Equal(state.Description) {
+		args := &freeipa.PrivilegeModArgs{
+			Cn: plan.Name.ValueString(),
+		}
+
+		optArgs := &freeipa.PrivilegeModOptionalArgs{
+			Description: freeipa.String(plan.Description.ValueString()),
+		}
+
+		tflog.Trace(ctx, "Calling PrivilegeMod", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		res, err := r.provider.Client().PrivilegeMod(args, optArgs)
+
+		tflog.Trace(ctx, "Called PrivilegeMod", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		if err != nil {
+			var freeipaErr *freeipa.Error
+
+			if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.EmptyModlistCode {
+				resp.Diagnostics.AddError("Failed to update privilege", "Reason: "+err.Error())
+				return
+			}
+
+			tflog.Debug(ctx, "Updated privilege has no effective difference", map[string]any{
+				"args":     args,
+				"opt_args": optArgs,
+			})
+		}
+	}
+
+	resp.Diagnostics.Append(r.updatePermissions(ctx, plan.Name.ValueString(), actualPermissions, desiredPermissions)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state = plan
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *Privilege) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state PrivilegeModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.PrivilegeDelArgs{
+		Cn: []string{state.Name.ValueString()},
+	}
+
+	tflog.Trace(ctx, "Calling PrivilegeDel", map[string]any{
+		"args":     args,
+		"opt_args": nil,
+	})
+
+	res, err := r.provider.Client().PrivilegeDel(args, nil)
+
+	tflog.Trace(ctx, "Called PrivilegeDel", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.NotFoundCode {
+			resp.Diagnostics.AddError("Failed to delete privilege", "Reason: "+err.Error())
+			return
+		}
+	}
+}
+
+func (r *Privilege) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	state := PrivilegeModel{
+		Name:        types.StringValue(req.ID),
+		Permissions: types.SetNull(types.StringType),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func NewPrivilege(p *provider.Provider) resource.Resource {
+	r := &Privilege{
+		provider: p,
+	}
+
+	var _ resource.Resource = r
+	var _ resource.ResourceWithImportState = r
+
+	return r
+}
+
+func init() {
+	resources = append(resources, NewPrivilege)
+}
+
+func (r *Privilege) updatePermissions(ctx context.Context, cn string, actualPermissions, desiredPermissions []string) (diags diag.Diagnostics) {
+	permissionsToAdd, permissionsToRemove := utils.SetDiff(actualPermissions, desiredPermissions)
+
+	if len(permissionsToRemove) > 0 {
+		args := &freeipa.PrivilegeRemovePermissionArgs{
+			Cn: cn,
+		}
+
+		optArgs := &freeipa.PrivilegeRemovePermissionOptionalArgs{
+			Permission: &permissionsToRemove,
+		}
+
+		tflog.Trace(ctx, "Calling PrivilegeRemovePermission", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		res, err := r.provider.Client().PrivilegeRemovePermission(args, optArgs)
+
+		tflog.Trace(ctx, "Called PrivilegeRemovePermission", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		var failed freeipa.FailedOperations
+		if res != nil {
+			failed = res.Failed
+		}
+
+		diags.Append(membershipDiags("Failed to remove privilege permissions", failed, err)...)
+	}
+
+	if len(permissionsToAdd) > 0 {
+		args := &freeipa.PrivilegeAddPermissionArgs{
+			Cn: cn,
+		}
+
+		optArgs := &freeipa.PrivilegeAddPermissionOptionalArgs{
+			Permission: &permissionsToAdd,
+		}
+
+		tflog.Trace(ctx, "Calling PrivilegeAddPermission", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		res, err := r.provider.Client().PrivilegeAddPermission(args, optArgs)
+
+		tflog.Trace(ctx, "Called PrivilegeAddPermission", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		var failed freeipa.FailedOperations
+		if res != nil {
+			failed = res.Failed
+		}
+
+		diags.Append(membershipDiags("Failed to add privilege permissions", failed, err)...)
+	}
+
+	return
+}
@@ -0,0 +1,326 @@
+package resources
+
+import (
+	"context"
+	"errors"
+
+	"github.com/camptocamp/go-freeipa/freeipa"
+	"github.com/camptocamp/terraform-provider-freeipa/internal/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type RadiusProxy struct {
+	provider *provider.Provider
+}
+
+type RadiusProxyModel struct {
+	Name          types.String `tfsdk:"cn"`
+	Description   types.String `tfsdk:"description"`
+	Server        types.String `tfsdk:"server"`
+	Secret        types.String `tfsdk:"secret"`
+	Timeout       types.Int64  `tfsdk:"timeout"`
+	Retries       types.Int64  `tfsdk:"retries"`
+	UserAttribute types.String `tfsdk:"user_attribute"`
+}
+
+func (r *RadiusProxy) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_radius_proxy"
+}
+
+func (r *RadiusProxy) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version:     0,
+		Description: "Manages a RADIUS proxy server users can authenticate with",
+		Attributes: map[string]schema.Attribute{
+			"cn": schema.StringAttribute{
+				Description: "RADIUS proxy server name",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description: "A description of this RADIUS proxy server",
+				Optional:    true,
+			},
+			"server": schema.StringAttribute{
+				Description: "The hostname or IP (with or without port)",
+				Required:    true,
+			},
+			"secret": schema.StringAttribute{
+				Description: "The secret used to encrypt data",
+				Required:    true,
+				Sensitive:   true,
+			},
+			"timeout": schema.Int64Attribute{
+				Description: "The total timeout across all retries (in seconds)",
+				Optional:    true,
+			},
+			"retries": schema.Int64Attribute{
+				Description: "The number of times to retry authentication",
+				Optional:    true,
+			},
+			"user_attribute": schema.StringAttribute{
+				Description: "The username attribute on the user object",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *RadiusProxy) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan, state RadiusProxyModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.RadiusproxyAddArgs{
+		Cn:                   plan.Name.ValueString(),
+		Ipatokenradiusserver: plan.Server.ValueString(),
+		Ipatokenradiussecret: plan.Secret.ValueString(),
+	}
+
+	optArgs := &freeipa.RadiusproxyAddOptionalArgs{
+		Description:              plan.Description.ValueStringPointer(),
+		Ipatokenradiustimeout:    knownInt(plan.Timeout),
+		Ipatokenradiusretries:    knownInt(plan.Retries),
+		Ipatokenusermapattribute: plan.UserAttribute.ValueStringPointer(),
+	}
+
+	// Do not log the secret.
+	loggedArgs := *args
+	loggedArgs.Ipatokenradiussecret = ""
+
+	tflog.Trace(ctx, "Calling RadiusproxyAdd", map[string]any{
+		"args":     loggedArgs,
+		"opt_args": optArgs,
+	})
+
+	err := r.provider.Call("radiusproxy_add", nil, struct {
+		*freeipa.RadiusproxyAddArgs
+		*freeipa.RadiusproxyAddOptionalArgs
+	}{args, optArgs}, nil)
+	tflog.Trace(ctx, "Called RadiusproxyAdd", map[string]any{
+		"err": err,
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create RADIUS proxy", "Reason: "+err.Error())
+		return
+	}
+
+	state = plan
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *RadiusProxy) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state RadiusProxyModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.RadiusproxyShowArgs{
+		Cn: state.Name.ValueString(),
+	}
+
+	optArgs := &freeipa.RadiusproxyShowOptionalArgs{
+		All: freeipa.Bool(true),
+	}
+
+	tflog.Trace(ctx, "Calling RadiusproxyShow", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	var res rpcEntryResult
+
+	err := r.provider.Call("radiusproxy_show", []any{args.Cn}, optArgs, &res)
+	delete(res.Result, "ipatokenradiussecret")
+	tflog.Trace(ctx, "Called RadiusproxyShow", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if errors.As(err, &freeipaErr) && freeipaErr.Code == freeipa.NotFoundCode {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Failed to read RADIUS proxy", "Reason: "+err.Error())
+		return
+	}
+
+	// The secret is never read back.
+	state.Description = types.StringPointerValue(entryString(res.Result, "description"))
+	state.Server = types.StringPointerValue(entryString(res.Result, "ipatokenradiusserver"))
+	state.Timeout = types.Int64PointerValue(entryInt64(res.Result, "ipatokenradiustimeout"))
+	state.Retries = types.Int64PointerValue(entryInt64(res.Result, "ipatokenradiusretries"))
+	state.UserAttribute = types.StringPointerValue(entryString(res.Result, "ipatokenusermapattribute"))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *RadiusProxy) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state, plan RadiusProxyModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var hasDiff bool
+
+	args := &freeipa.RadiusproxyModArgs{
+		Cn: state.Name.ValueString(),
+	}
+
+	optArgs := &freeipa.RadiusproxyModOptionalArgs{}
+
+	for _, attr := range []struct {
+		plan, state types.String
+		value       **string
+	}{
+		{plan.Description, state.Description, &optArgs.Description},
+		{plan.Server, state.Server, &optArgs.Ipatokenradiusserver},
+		{plan.Secret, state.Secret, &optArgs.Ipatokenradiussecret},
+		{plan.UserAttribute, state.UserAttribute, &optArgs.Ipatokenusermapattribute},
+	} {
+		if !attr.plan.Equal(attr.state) {
+			*attr.value = freeipa.String(attr.plan.ValueString())
+			hasDiff = true
+		}
+	}
+
+	var delAttrs []string
+
+	for _, attr := range []struct {
+		plan, state types.Int64
+		name        string
+		value       **int
+	}{
+		{plan.Timeout, state.Timeout, "ipatokenradiustimeout", &optArgs.Ipatokenradiustimeout},
+		{plan.Retries, state.Retries, "ipatokenradiusretries", &optArgs.Ipatokenradiusretries},
+	} {
+		if attr.plan.Equal(attr.state) {
+			continue
+		}
+
+		// Integer options cannot be emptied, remove the attribute instead.
+		if attr.plan.IsNull() {
+			delAttrs = append(delAttrs, attr.name+"="+attr.state.String())
+		} else {
+			*attr.value = knownInt(attr.plan)
+		}
+
+		hasDiff = true
+	}
+
+	if len(delAttrs) > 0 {
+		optArgs.Delattr = &delAttrs
+	}
+
+	if hasDiff {
+		// Do not log the secret.
+		loggedOptArgs := *optArgs
+		loggedOptArgs.Ipatokenradiussecret = nil
+
+		tflog.Trace(ctx, "Calling RadiusproxyMod", map[string]any{
+			"args":     args,
+			"opt_args": loggedOptArgs,
+		})
+
+		err := r.provider.Call("radiusproxy_mod", []any{args.Cn}, optArgs, nil)
+		tflog.Trace(ctx, "Called RadiusproxyMod", map[string]any{
+			"err": err,
+		})
+
+		if err != nil {
+			var freeipaErr *freeipa.Error
+
+			if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.EmptyModlistCode {
+				resp.Diagnostics.AddError("Failed to update RADIUS proxy", "Reason: "+err.Error())
+				return
+			}
+
+			tflog.Debug(ctx, "Updated RADIUS proxy has no effective difference", map[string]any{
+				"args":     args,
+				"opt_args": loggedOptArgs,
+			})
+		}
+	}
+
+	state = plan
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *RadiusProxy) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state RadiusProxyModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.RadiusproxyDelArgs{
+		Cn: []string{state.Name.ValueString()},
+	}
+
+	tflog.Trace(ctx, "Calling RadiusproxyDel", map[string]any{
+		"args":     args,
+		"opt_args": nil,
+	})
+
+	res, err := r.provider.Client().RadiusproxyDel(args, nil)
+
+	tflog.Trace(ctx, "Called RadiusproxyDel", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.NotFoundCode {
+			resp.Diagnostics.AddError("Failed to delete RADIUS proxy", "Reason: "+err.Error())
+			return
+		}
+	}
+}
+
+func (r *RadiusProxy) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	state := RadiusProxyModel{
+		Name: types.StringValue(req.ID),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func NewRadiusProxy(p *provider.Provider) resource.Resource {
+	r := &RadiusProxy{
+		provider: p,
+	}
+
+	var _ resource.Resource = r
+	var _ resource.ResourceWithImportState = r
+
+	return r
+}
+
+func init() {
+	resources = append(resources, NewRadiusProxy)
+}
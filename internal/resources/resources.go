@@ -1,8 +1,16 @@
 package resources
 
 import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/camptocamp/go-freeipa/freeipa"
 	"github.com/camptocamp/terraform-provider-freeipa/internal/provider"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var (
@@ -12,3 +20,228 @@ var (
 func Resources() []func(p *provider.Provider) resource.Resource {
 	return resources
 }
+
+// saveCreated saves the state of a resource as soon as FreeIPA created it, so
+// that it is not leaked if the calls completing its creation fail.
+func saveCreated(ctx context.Context, resp *resource.CreateResponse, state any) {
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// membersSetValue returns the set value of the given members, keeping it null
+// when there are no members and it was not set.
+func membersSetValue(ctx context.Context, current types.Set, members *[]string) (types.Set, diag.Diagnostics) {
+	if members == nil || len(*members) == 0 {
+		if current.IsNull() {
+			return current, nil
+		}
+
+		return types.SetValueFrom(ctx, types.StringType, []string{})
+	}
+
+	return types.SetValueFrom(ctx, types.StringType, *members)
+}
+
+// membershipDiags returns the diagnostics of a membership update, which
+// reports failures for individual members along with its result.
+func membershipDiags(summary string, failed freeipa.FailedOperations, err error) (diags diag.Diagnostics) {
+	if err != nil {
+		diags.AddError(summary, "Reason: "+err.Error())
+	} else if failures := failed.GetFailures(); len(failures) > 0 {
+		diags.AddError(summary, "Reason: "+failures.String())
+	}
+
+	return
+}
+
+// rpcEntryResult is the result of a raw call returning a single entry.
+type rpcEntryResult struct {
+	Result map[string]any `json:"result"`
+}
+
+// rpcListResult is the result of a raw call returning a list of entries.
+type rpcListResult struct {
+	Result []map[string]any `json:"result"`
+}
+
+// rpcMembershipResult is the result of a raw call updating the members of
+// an entry, which reports failures for individual members.
+type rpcMembershipResult struct {
+	Failed    freeipa.FailedOperations `json:"failed"`
+	Completed int                      `json:"completed"`
+}
+
+// membersPointer returns a pointer to the given members, or nil if there are
+// none, as expected by the membership commands.
+func membersPointer(members []string) *[]string {
+	if len(members) == 0 {
+		return nil
+	}
+
+	return &members
+}
+
+// normalizePrincipals returns the given principal names, keeping them in
+// their current form when it only lacks the realm FreeIPA appends.
+func normalizePrincipals(principals, current []string) []string {
+	normalized := make([]string, 0, len(principals))
+
+	for _, principal := range principals {
+		for _, c := range current {
+			if !strings.Contains(c, "@") && strings.HasPrefix(principal, c+"@") {
+				principal = c
+				break
+			}
+		}
+
+		normalized = append(normalized, principal)
+	}
+
+	return normalized
+}
+
+// knownSetStrings returns the known values of a set of strings, for
+// validation purposes.
+func knownSetStrings(set types.Set) []string {
+	var values []string
+
+	for _, v := range set.Elements() {
+		if s, ok := v.(types.String); ok && !s.IsUnknown() && !s.IsNull() {
+			values = append(values, s.ValueString())
+		}
+	}
+
+	return values
+}
+
+// entryStrings returns the values of an attribute of an entry returned by a
+// raw call, which may be a single value or a list.
+func entryStrings(entry map[string]any, name string) []string {
+	var values []string
+
+	switch v := entry[name].(type) {
+	case string:
+		values = append(values, v)
+	case []any:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+	}
+
+	return values
+}
+
+// entryString returns the first value of an attribute of an entry returned
+// by a raw call, or nil.
+func entryString(entry map[string]any, name string) *string {
+	values := entryStrings(entry, name)
+
+	if len(values) == 0 {
+		return nil
+	}
+
+	return &values[0]
+}
+
+// entryBool returns the boolean value of an attribute of an entry returned by
+// a raw call, or nil.
+func entryBool(entry map[string]any, name string) *bool {
+	v := entry[name]
+
+	if l, ok := v.([]any); ok && len(l) == 1 {
+		v = l[0]
+	}
+
+	switch v := v.(type) {
+	case bool:
+		return &v
+	case string:
+		b := strings.EqualFold(v, "TRUE")
+
+		return &b
+	}
+
+	return nil
+}
+
+// entryInt64 returns the integer value of an attribute of an entry returned
+// by a raw call, or nil.
+func entryInt64(entry map[string]any, name string) *int64 {
+	v := entry[name]
+
+	if l, ok := v.([]any); ok && len(l) == 1 {
+		v = l[0]
+	}
+
+	switch v := v.(type) {
+	case float64:
+		i := int64(v)
+
+		return &i
+	case string:
+		i, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil
+		}
+
+		return &i
+	}
+
+	return nil
+}
+
+// knownString returns a pointer to a string value, or nil if it is null or
+// unknown.
+func knownString(v types.String) *string {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+
+	return v.ValueStringPointer()
+}
+
+// knownInt returns a pointer to an integer value, or nil if it is null or
+// unknown.
+func knownInt(v types.Int64) *int {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+
+	i := int(v.ValueInt64())
+
+	return &i
+}
+
+// int64Pointer converts an integer returned by the client, which may be nil.
+func int64Pointer(v *int) *int64 {
+	if v == nil {
+		return nil
+	}
+
+	i := int64(*v)
+
+	return &i
+}
+
+// knownBool returns a pointer to a boolean value, or nil if it is null or
+// unknown.
+func knownBool(v types.Bool) *bool {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+
+	return v.ValueBoolPointer()
+}
+
+// keepCase returns the given values, spelled as in current when they only
+// differ by case, as FreeIPA lower-cases the attribute names of ACIs.
+func keepCase(values, current []string) []string {
+	for i, value := range values {
+		if j := slices.IndexFunc(current, func(s string) bool { return strings.EqualFold(s, value) }); j >= 0 {
+			values[i] = current[j]
+		}
+	}
+
+	return values
+}
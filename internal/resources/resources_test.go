@@ -0,0 +1,326 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/camptocamp/terraform-provider-freeipa/internal/provider"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	fwprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// rpcHandler returns the canned JSON-RPC response to a FreeIPA call, given
+// its keyword parameters, or the canned response to a REST request.
+type rpcHandler func(t *testing.T, params map[string]any) string
+
+// newTestProvider returns a provider configured against a stubbed FreeIPA
+// JSON-RPC server answering the given methods and REST paths.
+func newTestProvider(t *testing.T, handlers map[string]rpcHandler) *provider.Provider {
+	t.Helper()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/ipa/session/login_password":
+			w.WriteHeader(http.StatusOK)
+		case "/ipa/session/json":
+			var call struct {
+				Method string `json:"method"`
+				Params []json.RawMessage
+			}
+
+			if err := json.NewDecoder(req.Body).Decode(&call); err != nil {
+				t.Errorf("invalid JSON-RPC request: %s", err)
+				w.WriteHeader(http.StatusBadRequest)
+
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+
+			if call.Method == "batch" {
+				_, _ = w.Write([]byte(rpcBatch(t, handlers, call.Params)))
+
+				return
+			}
+
+			res, ok := rpcCall(t, handlers, call.Method, call.Params)
+			if !ok {
+				w.WriteHeader(http.StatusNotImplemented)
+
+				return
+			}
+
+			_, _ = w.Write([]byte(res))
+		default:
+			// Other paths are REST resources, answered by the handler of
+			// their path.
+			handler, ok := handlers[req.URL.Path]
+			if !ok || req.Method != http.MethodGet {
+				w.WriteHeader(http.StatusNotFound)
+
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(handler(t, nil)))
+		}
+	}))
+
+	t.Cleanup(server.Close)
+
+	ctx := context.Background()
+	p := provider.NewFactory(nil, nil)()
+
+	var schemaResp fwprovider.SchemaResponse
+
+	p.Schema(ctx, fwprovider.SchemaRequest{}, &schemaResp)
+
+	config := tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+		"host":     tftypes.NewValue(tftypes.String, strings.TrimPrefix(server.URL, "https://")),
+		"username": tftypes.NewValue(tftypes.String, "admin"),
+		"password": tftypes.NewValue(tftypes.String, "password"),
+		"insecure": tftypes.NewValue(tftypes.Bool, true),
+	})
+
+	var configureResp fwprovider.ConfigureResponse
+
+	p.Configure(ctx, fwprovider.ConfigureRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config},
+	}, &configureResp)
+
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("failed to configure provider: %v", configureResp.Diagnostics)
+	}
+
+	return p.(*provider.Provider)
+}
+
+// rpcCall answers a JSON-RPC command with its handler.
+func rpcCall(t *testing.T, handlers map[string]rpcHandler, method string, rawParams []json.RawMessage) (string, bool) {
+	var params map[string]any
+
+	if len(rawParams) == 2 {
+		if err := json.Unmarshal(rawParams[1], &params); err != nil {
+			t.Errorf("invalid JSON-RPC parameters: %s", err)
+		}
+	}
+
+	handler, ok := handlers[method]
+	if !ok {
+		t.Errorf("unexpected call to %s", method)
+
+		return "", false
+	}
+
+	return handler(t, params), true
+}
+
+// rpcBatch answers a batch of JSON-RPC commands with their handlers, in
+// order, the way FreeIPA does.
+func rpcBatch(t *testing.T, handlers map[string]rpcHandler, rawParams []json.RawMessage) string {
+	var calls []struct {
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+
+	if len(rawParams) > 0 {
+		if err := json.Unmarshal(rawParams[0], &calls); err != nil {
+			t.Errorf("invalid batch parameters: %s", err)
+		}
+	}
+
+	results := []map[string]any{}
+
+	for _, call := range calls {
+		res, ok := rpcCall(t, handlers, call.Method, call.Params)
+		if !ok {
+			res = rpcError(-1, "NotImplemented", "unexpected call to "+call.Method)
+		}
+
+		var response struct {
+			Result map[string]any `json:"result"`
+			Error  *struct {
+				Code    int    `json:"code"`
+				Name    string `json:"name"`
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+
+		if err := json.Unmarshal([]byte(res), &response); err != nil {
+			t.Errorf("invalid JSON-RPC response: %s", err)
+		}
+
+		result := map[string]any{"error": nil}
+
+		if response.Error != nil {
+			result["error"] = response.Error.Message
+			result["error_code"] = response.Error.Code
+			result["error_name"] = response.Error.Name
+		} else {
+			for k, v := range response.Result {
+				result[k] = v
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	return rpcResult(map[string]any{
+		"count":   len(results),
+		"results": results,
+	})
+}
+
+// rpcResult returns a successful JSON-RPC response.
+func rpcResult(result any) string {
+	b, err := json.Marshal(map[string]any{
+		"result": result,
+		"error":  nil,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return string(b)
+}
+
+// rpcError returns a failed JSON-RPC response.
+func rpcError(code int, name, message string) string {
+	b, err := json.Marshal(map[string]any{
+		"result": nil,
+		"error": map[string]any{
+			"code":    code,
+			"name":    name,
+			"message": message,
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return string(b)
+}
+
+// testState returns the state of a resource holding the given model.
+func testState(t *testing.T, r resource.Resource, model any) tfsdk.State {
+	t.Helper()
+
+	var schemaResp resource.SchemaResponse
+
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+
+	if diags := state.Set(context.Background(), model); diags.HasError() {
+		t.Fatalf("failed to set state: %v", diags)
+	}
+
+	return state
+}
+
+// testCreate creates a resource from the given configuration and plan, and
+// returns the model of its new state.
+func testCreate[M any](t *testing.T, r resource.Resource, config, plan M) M {
+	t.Helper()
+
+	ctx := context.Background()
+	configState := testState(t, r, config)
+	planState := testState(t, r, plan)
+
+	resp := resource.CreateResponse{
+		State: tfsdk.State{
+			Schema: planState.Schema,
+			Raw:    tftypes.NewValue(planState.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+
+	r.Create(ctx, resource.CreateRequest{
+		Config: tfsdk.Config{Schema: configState.Schema, Raw: configState.Raw},
+		Plan:   tfsdk.Plan{Schema: planState.Schema, Raw: planState.Raw},
+	}, &resp)
+
+	return testModel[M](t, resp.State, resp.Diagnostics)
+}
+
+// testRead reads a resource from the given state, and returns the model of
+// its refreshed state, or nil if the resource was removed.
+func testRead[M any](t *testing.T, r resource.Resource, current M) *M {
+	t.Helper()
+
+	state := testState(t, r, current)
+	resp := resource.ReadResponse{State: state}
+
+	r.Read(context.Background(), resource.ReadRequest{State: state}, &resp)
+
+	if resp.State.Raw.IsNull() && !resp.Diagnostics.HasError() {
+		return nil
+	}
+
+	model := testModel[M](t, resp.State, resp.Diagnostics)
+
+	return &model
+}
+
+// testUpdate updates a resource from the given configuration, plan and state,
+// and returns the model of its new state.
+func testUpdate[M any](t *testing.T, r resource.Resource, config, plan, current M) M {
+	t.Helper()
+
+	configState := testState(t, r, config)
+	planState := testState(t, r, plan)
+	state := testState(t, r, current)
+
+	resp := resource.UpdateResponse{State: state}
+
+	r.Update(context.Background(), resource.UpdateRequest{
+		Config: tfsdk.Config{Schema: configState.Schema, Raw: configState.Raw},
+		Plan:   tfsdk.Plan{Schema: planState.Schema, Raw: planState.Raw},
+		State:  state,
+	}, &resp)
+
+	return testModel[M](t, resp.State, resp.Diagnostics)
+}
+
+// testModel returns the model of a state, failing on the diagnostics of the
+// call which set it.
+func testModel[M any](t *testing.T, state tfsdk.State, diags diag.Diagnostics) M {
+	t.Helper()
+
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	var model M
+
+	if diags := state.Get(context.Background(), &model); diags.HasError() {
+		t.Fatalf("failed to get state: %v", diags)
+	}
+
+	return model
+}
+
+// testValue is an attribute value along with the expected one.
+type testValue struct {
+	value    attr.Value
+	expected attr.Value
+}
+
+// testValues reports the attribute values which differ from the expected
+// ones.
+func testValues(t *testing.T, values map[string]testValue) {
+	t.Helper()
+
+	for name, v := range values {
+		if !v.value.Equal(v.expected) {
+			t.Errorf("unexpected %s %v, expected %v", name, v.value, v.expected)
+		}
+	}
+}
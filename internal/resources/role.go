@@ -0,0 +1,520 @@
+package resources
+
+import (
+	"context"
+	"errors"
+
+	"github.com/camptocamp/go-freeipa/freeipa"
+	"github.com/camptocamp/terraform-provider-freeipa/internal/provider"
+	"github.com/camptocamp/terraform-provider-freeipa/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type Role struct {
+	provider *provider.Provider
+}
+
+type RoleModel struct {
+	Name             types.String `tfsdk:"cn"`
+	Description      types.String `tfsdk:"description"`
+	MemberUsers      types.Set    `tfsdk:"member_users"`
+	MemberGroups     types.Set    `tfsdk:"member_groups"`
+	MemberHosts      types.Set    `tfsdk:"member_hosts"`
+	MemberHostgroups types.Set    `tfsdk:"member_hostgroups"`
+	MemberServices   types.Set    `tfsdk:"member_services"`
+	Privileges       types.Set    `tfsdk:"privileges"`
+}
+
+// roleMembers holds the members of a role, by member type.
+type roleMembers struct {
+	users      []string
+	groups     []string
+	hosts      []string
+	hostgroups []string
+	services   []string
+}
+
+func (r *Role) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role"
+}
+
+func (r *Role) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version:     0,
+		Description: "Manages a role, the set of privileges granted to its members",
+		Attributes: map[string]schema.Attribute{
+			"cn": schema.StringAttribute{
+				Description: "Role name",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description: "A description of this role-group",
+				Optional:    true,
+			},
+			"member_users": schema.SetAttribute{
+				Description: "Users granted the role",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"member_groups": schema.SetAttribute{
+				Description: "User groups granted the role",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"member_hosts": schema.SetAttribute{
+				Description: "Hosts granted the role",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"member_hostgroups": schema.SetAttribute{
+				Description: "Host groups granted the role",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"member_services": schema.SetAttribute{
+				Description: "Services granted the role",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"privileges": schema.SetAttribute{
+				Description: "Privileges granted by the role",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *Role) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan, state RoleModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	members, diags := roleMembersFromModel(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+
+	var privileges []string
+
+	resp.Diagnostics.Append(plan.Privileges.ElementsAs(ctx, &privileges, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.RoleAddArgs{
+		Cn: plan.Name.ValueString(),
+	}
+
+	optArgs := &freeipa.RoleAddOptionalArgs{
+		Description: plan.Description.ValueStringPointer(),
+	}
+
+	tflog.Trace(ctx, "Calling RoleAdd", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	res, err := r.provider.Client().RoleAdd(args, optArgs)
+
+	tflog.Trace(ctx, "Called RoleAdd", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create role", "Reason: "+err.Error())
+		return
+	}
+
+	state = plan
+
+	saveCreated(ctx, resp, state)
+
+	resp.Diagnostics.Append(r.addMembers(ctx, plan.Name.ValueString(), members)...)
+	resp.Diagnostics.Append(r.updatePrivileges(ctx, plan.Name.ValueString(), nil, privileges)...)
+}
+
+func (r *Role) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state RoleModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.RoleShowArgs{
+		Cn: state.Name.ValueString(),
+	}
+
+	optArgs := &freeipa.RoleShowOptionalArgs{
+		All: freeipa.Bool(true),
+	}
+
+	tflog.Trace(ctx, "Calling RoleShow", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	res, err := r.provider.Client().RoleShow(args, optArgs)
+
+	tflog.Trace(ctx, "Called RoleShow", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if errors.As(err, &freeipaErr) && freeipaErr.Code == freeipa.NotFoundCode {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Failed to read role", "Reason: "+err.Error())
+		return
+	}
+
+	var diags diag.Diagnostics
+
+	state.Description = types.StringPointerValue(res.Result.Description)
+
+	state.MemberUsers, diags = membersSetValue(ctx, state.MemberUsers, res.Result.MemberUser)
+	resp.Diagnostics.Append(diags...)
+
+	state.MemberGroups, diags = membersSetValue(ctx, state.MemberGroups, res.Result.MemberGroup)
+	resp.Diagnostics.Append(diags...)
+
+	state.MemberHosts, diags = membersSetValue(ctx, state.MemberHosts, res.Result.MemberHost)
+	resp.Diagnostics.Append(diags...)
+
+	state.MemberHostgroups, diags = membersSetValue(ctx, state.MemberHostgroups, res.Result.MemberHostgroup)
+	resp.Diagnostics.Append(diags...)
+
+	state.MemberServices, diags = membersSetValue(ctx, state.MemberServices, res.Result.MemberService)
+	resp.Diagnostics.Append(diags...)
+
+	state.Privileges, diags = membersSetValue(ctx, state.Privileges, res.Result.MemberofPrivilege)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *Role) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state, plan RoleModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	actualMembers, diags := roleMembersFromModel(ctx, state)
+	resp.Diagnostics.Append(diags...)
+
+	desiredMembers, diags := roleMembersFromModel(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+
+	var actualPrivileges, desiredPrivileges []string
+
+	resp.Diagnostics.Append(state.Privileges.ElementsAs(ctx, &actualPrivileges, false)...)
+	resp.Diagnostics.Append(plan.Privileges.ElementsAs(ctx, &desiredPrivileges, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Description.Equal(state.Description) {
+		args := &freeipa.RoleModArgs{
+			Cn: plan.Name.ValueString(),
+		}
+
+		optArgs := &freeipa.RoleModOptionalArgs{
+			Description: freeipa.String(plan.Description.ValueString()),
+		}
+
+		tflog.Trace(ctx, "Calling RoleMod", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		res, err := r.provider.Client().RoleMod(args, optArgs)
+
+		tflog.Trace(ctx, "Called RoleMod", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		if err != nil {
+			var freeipaErr *freeipa.Error
+
+			if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.EmptyModlistCode {
+				resp.Diagnostics.AddError("Failed to update role", "Reason: "+err.Error())
+				return
+			}
+
+			tflog.Debug(ctx, "Updated role has no effective difference", map[string]any{
+				"args":     args,
+				"opt_args": optArgs,
+			})
+		}
+	}
+
+	membersToAdd, membersToRemove := actualMembers.diff(desiredMembers)
+
+	resp.Diagnostics.Append(r.removeMembers(ctx, plan.Name.ValueString(), membersToRemove)...)
+	resp.Diagnostics.Append(r.addMembers(ctx, plan.Name.ValueString(), membersToAdd)...)
+	resp.Diagnostics.Append(r.updatePrivileges(ctx, plan.Name.ValueString(), actualPrivileges, desiredPrivileges)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state = plan
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *Role) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state RoleModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.RoleDelArgs{
+		Cn: []string{state.Name.ValueString()},
+	}
+
+	tflog.Trace(ctx, "Calling RoleDel", map[string]any{
+		"args":     args,
+		"opt_args": nil,
+	})
+
+	res, err := r.provider.Client().RoleDel(args, nil)
+
+	tflog.Trace(ctx, "Called RoleDel", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.NotFoundCode {
+			resp.Diagnostics.AddError("Failed to delete role", "Reason: "+err.Error())
+			return
+		}
+	}
+}
+
+func (r *Role) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	state := RoleModel{
+		Name:             types.StringValue(req.ID),
+		MemberUsers:      types.SetNull(types.StringType),
+		MemberGroups:     types.SetNull(types.StringType),
+		MemberHosts:      types.SetNull(types.StringType),
+		MemberHostgroups: types.SetNull(types.StringType),
+		MemberServices:   types.SetNull(types.StringType),
+		Privileges:       types.SetNull(types.StringType),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func NewRole(p *provider.Provider) resource.Resource {
+	r := &Role{
+		provider: p,
+	}
+
+	var _ resource.Resource = r
+	var _ resource.ResourceWithImportState = r
+
+	return r
+}
+
+func init() {
+	resources = append(resources, NewRole)
+}
+
+func roleMembersFromModel(ctx context.Context, model RoleModel) (members roleMembers, diags diag.Diagnostics) {
+	diags.Append(model.MemberUsers.ElementsAs(ctx, &members.users, false)...)
+	diags.Append(model.MemberGroups.ElementsAs(ctx, &members.groups, false)...)
+	diags.Append(model.MemberHosts.ElementsAs(ctx, &members.hosts, false)...)
+	diags.Append(model.MemberHostgroups.ElementsAs(ctx, &members.hostgroups, false)...)
+	diags.Append(model.MemberServices.ElementsAs(ctx, &members.services, false)...)
+
+	return
+}
+
+// diff returns the members to add and to remove to go from the actual
+// members to the desired ones.
+func (actual roleMembers) diff(desired roleMembers) (toAdd, toRemove roleMembers) {
+	toAdd.users, toRemove.users = utils.SetDiff(actual.users, desired.users)
+	toAdd.groups, toRemove.groups = utils.SetDiff(actual.groups, desired.groups)
+	toAdd.hosts, toRemove.hosts = utils.SetDiff(actual.hosts, desired.hosts)
+	toAdd.hostgroups, toRemove.hostgroups = utils.SetDiff(actual.hostgroups, desired.hostgroups)
+	toAdd.services, toRemove.services = utils.SetDiff(actual.services, desired.services)
+
+	return
+}
+
+func (members roleMembers) empty() bool {
+	return len(members.users) == 0 && len(members.groups) == 0 && len(members.hosts) == 0 &&
+		len(members.hostgroups) == 0 && len(members.services) == 0
+}
+
+func (r *Role) addMembers(ctx context.Context, cn string, members roleMembers) (diags diag.Diagnostics) {
+	if members.empty() {
+		return
+	}
+
+	args := &freeipa.RoleAddMemberArgs{
+		Cn: cn,
+	}
+
+	optArgs := &freeipa.RoleAddMemberOptionalArgs{
+		User:      membersPointer(members.users),
+		Group:     membersPointer(members.groups),
+		Host:      membersPointer(members.hosts),
+		Hostgroup: membersPointer(members.hostgroups),
+		Service:   membersPointer(members.services),
+	}
+
+	tflog.Trace(ctx, "Calling RoleAddMember", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	res, err := r.provider.Client().RoleAddMember(args, optArgs)
+
+	tflog.Trace(ctx, "Called RoleAddMember", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	var failed freeipa.FailedOperations
+	if res != nil {
+		failed = res.Failed
+	}
+
+	diags.Append(membershipDiags("Failed to add role members", failed, err)...)
+
+	return
+}
+
+func (r *Role) removeMembers(ctx context.Context, cn string, members roleMembers) (diags diag.Diagnostics) {
+	if members.empty() {
+		return
+	}
+
+	args := &freeipa.RoleRemoveMemberArgs{
+		Cn: cn,
+	}
+
+	optArgs := &freeipa.RoleRemoveMemberOptionalArgs{
+		User:      membersPointer(members.users),
+		Group:     membersPointer(members.groups),
+		Host:      membersPointer(members.hosts),
+		Hostgroup: membersPointer(members.hostgroups),
+		Service:   membersPointer(members.services),
+	}
+
+	tflog.Trace(ctx, "Calling RoleRemoveMember", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	res, err := r.provider.Client().RoleRemoveMember(args, optArgs)
+
+	tflog.Trace(ctx, "Called RoleRemoveMember", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	var failed freeipa.FailedOperations
+	if res != nil {
+		failed = res.Failed
+	}
+
+	diags.Append(membershipDiags("Failed to remove role members", failed, err)...)
+
+	return
+}
+
+func (r *Role) updatePrivileges(ctx context.Context, cn string, actualPrivileges, desiredPrivileges []string) (diags diag.Diagnostics) {
+	privilegesToAdd, privilegesToRemove := utils.SetDiff(actualPrivileges, desiredPrivileges)
+
+	if len(privilegesToRemove) > 0 {
+		args := &freeipa.RoleRemovePrivilegeArgs{
+			Cn: cn,
+		}
+
+		optArgs := &freeipa.RoleRemovePrivilegeOptionalArgs{
+			Privilege: &privilegesToRemove,
+		}
+
+		tflog.Trace(ctx, "Calling RoleRemovePrivilege", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		res, err := r.provider.Client().RoleRemovePrivilege(args, optArgs)
+
+		tflog.Trace(ctx, "Called RoleRemovePrivilege", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		var failed freeipa.FailedOperations
+		if res != nil {
+			failed = res.Failed
+		}
+
+		diags.Append(membershipDiags("Failed to remove role privileges", failed, err)...)
+	}
+
+	if len(privilegesToAdd) > 0 {
+		args := &freeipa.RoleAddPrivilegeArgs{
+			Cn: cn,
+		}
+
+		optArgs := &freeipa.RoleAddPrivilegeOptionalArgs{
+			Privilege: &privilegesToAdd,
+		}
+
+		tflog.Trace(ctx, "Calling RoleAddPrivilege", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		res, err := r.provider.Client().RoleAddPrivilege(args, optArgs)
+
+		tflog.Trace(ctx, "Called RoleAddPrivilege", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		var failed freeipa.FailedOperations
+		if res != nil {
+			failed = res.Failed
+		}
+
+		diags.Append(membershipDiags("Failed to add role privileges", failed, err)...)
+	}
+
+	return
+}
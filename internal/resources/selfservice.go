@@ -0,0 +1,449 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/camptocamp/go-freeipa/freeipa"
+	"github.com/camptocamp/terraform-provider-freeipa/internal/provider"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var aciPermissions = []string{"read", "write"}
+
+// aciAttrPattern matches LDAP attribute type names. Whether the attribute
+// exists in the server schema is checked when planning.
+var aciAttrPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9-]*$`)
+
+type Selfservice struct {
+	provider *provider.Provider
+}
+
+type SelfserviceModel struct {
+	Name        types.String `tfsdk:"name"`
+	Permissions types.Set    `tfsdk:"permissions"`
+	Attrs       types.Set    `tfsdk:"attrs"`
+}
+
+func (r *Selfservice) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_selfservice"
+}
+
+func (r *Selfservice) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version:     0,
+		Description: "Manages a self-service rule, the attributes users may edit on their own entry",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Description: "Self-service name",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"permissions": schema.SetAttribute{
+				Description: "Permissions to grant (read, write), write by default",
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"attrs": schema.SetAttribute{
+				Description: "Attributes to which the self-service rule applies, which must exist in the server schema",
+				ElementType: types.StringType,
+				Required:    true,
+			},
+		},
+	}
+}
+
+func (r *Selfservice) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config SelfserviceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateACIConfig(config.Permissions, config.Attrs)...)
+}
+
+func (r *Selfservice) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var plan SelfserviceModel
+
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateACIAttrs(ctx, r.provider, plan.Attrs)...)
+}
+
+func (r *Selfservice) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan, state SelfserviceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var permissions, attrs []string
+
+	if !plan.Permissions.IsUnknown() {
+		resp.Diagnostics.Append(plan.Permissions.ElementsAs(ctx, &permissions, false)...)
+	}
+
+	resp.Diagnostics.Append(plan.Attrs.ElementsAs(ctx, &attrs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.SelfserviceAddArgs{
+		Aciname: plan.Name.ValueString(),
+		Attrs:   attrs,
+	}
+
+	optArgs := &freeipa.SelfserviceAddOptionalArgs{
+		Permissions: membersPointer(permissions),
+	}
+
+	tflog.Trace(ctx, "Calling SelfserviceAdd", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	var res rpcEntryResult
+
+	err := r.provider.Call("selfservice_add", nil, struct {
+		*freeipa.SelfserviceAddArgs
+		*freeipa.SelfserviceAddOptionalArgs
+	}{args, optArgs}, &res)
+	tflog.Trace(ctx, "Called SelfserviceAdd", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create self-service rule", "Reason: "+err.Error())
+		return
+	}
+
+	state = plan
+	resp.Diagnostics.Append(state.readEntry(ctx, res.Result)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *Selfservice) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state SelfserviceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.SelfserviceShowArgs{
+		Aciname: state.Name.ValueString(),
+	}
+
+	tflog.Trace(ctx, "Calling SelfserviceShow", map[string]any{
+		"args":     args,
+		"opt_args": nil,
+	})
+
+	var res rpcEntryResult
+
+	err := r.provider.Call("selfservice_show", []any{args.Aciname}, nil, &res)
+	tflog.Trace(ctx, "Called SelfserviceShow", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if errors.As(err, &freeipaErr) && freeipaErr.Code == freeipa.NotFoundCode {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Failed to read self-service rule", "Reason: "+err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(state.readEntry(ctx, res.Result)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *Selfservice) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state, plan SelfserviceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var hasDiff bool
+
+	args := &freeipa.SelfserviceModArgs{
+		Aciname: state.Name.ValueString(),
+	}
+
+	optArgs := &freeipa.SelfserviceModOptionalArgs{}
+
+	for _, attr := range []struct {
+		plan, state types.Set
+		values      **[]string
+	}{
+		{plan.Permissions, state.Permissions, &optArgs.Permissions},
+		{plan.Attrs, state.Attrs, &optArgs.Attrs},
+	} {
+		if !attr.plan.Equal(attr.state) {
+			values := []string{}
+
+			resp.Diagnostics.Append(attr.plan.ElementsAs(ctx, &values, false)...)
+
+			*attr.values = &values
+			hasDiff = true
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if hasDiff {
+		tflog.Trace(ctx, "Calling SelfserviceMod", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		err := r.provider.Call("selfservice_mod", []any{args.Aciname}, optArgs, nil)
+		tflog.Trace(ctx, "Called SelfserviceMod", map[string]any{
+			"err": err,
+		})
+
+		if err != nil {
+			var freeipaErr *freeipa.Error
+
+			if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.EmptyModlistCode {
+				resp.Diagnostics.AddError("Failed to update self-service rule", "Reason: "+err.Error())
+				return
+			}
+
+			tflog.Debug(ctx, "Updated self-service rule has no effective difference", map[string]any{
+				"args":     args,
+				"opt_args": optArgs,
+			})
+		}
+	}
+
+	state = plan
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *Selfservice) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state SelfserviceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.SelfserviceDelArgs{
+		Aciname: state.Name.ValueString(),
+	}
+
+	tflog.Trace(ctx, "Calling SelfserviceDel", map[string]any{
+		"args":     args,
+		"opt_args": nil,
+	})
+
+	res, err := r.provider.Client().SelfserviceDel(args, nil)
+
+	tflog.Trace(ctx, "Called SelfserviceDel", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.NotFoundCode {
+			resp.Diagnostics.AddError("Failed to delete self-service rule", "Reason: "+err.Error())
+			return
+		}
+	}
+}
+
+func (r *Selfservice) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	state := SelfserviceModel{
+		Name:        types.StringValue(req.ID),
+		Permissions: types.SetNull(types.StringType),
+		Attrs:       types.SetNull(types.StringType),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func NewSelfservice(p *provider.Provider) resource.Resource {
+	r := &Selfservice{
+		provider: p,
+	}
+
+	var _ resource.Resource = r
+	var _ resource.ResourceWithImportState = r
+	var _ resource.ResourceWithValidateConfig = r
+	var _ resource.ResourceWithModifyPlan = r
+
+	return r
+}
+
+func init() {
+	resources = append(resources, NewSelfservice)
+}
+
+func (m *SelfserviceModel) readEntry(ctx context.Context, entry map[string]any) (diags diag.Diagnostics) {
+	m.Permissions, m.Attrs, diags = aciSetValues(ctx, m.Permissions, m.Attrs, entry)
+
+	return
+}
+
+// aciSetValues returns the permissions and attributes of an ACI entry returned
+// by a raw call.
+func aciSetValues(ctx context.Context, currentPermissions, currentAttrs types.Set, entry map[string]any) (permissions, attrs types.Set, diags diag.Diagnostics) {
+	var d diag.Diagnostics
+
+	var configuredAttrs []string
+
+	if !currentAttrs.IsUnknown() {
+		diags.Append(currentAttrs.ElementsAs(ctx, &configuredAttrs, false)...)
+	}
+
+	permissionValues := entryStrings(entry, "permissions")
+
+	permissions, d = types.SetValueFrom(ctx, types.StringType, permissionValues)
+	diags.Append(d...)
+
+	attrValues := keepCase(entryStrings(entry, "attrs"), configuredAttrs)
+
+	attrs, d = membersSetValue(ctx, currentAttrs, &attrValues)
+	diags.Append(d...)
+
+	return
+}
+
+// validateACIConfig validates the permissions and attributes of an ACI.
+func validateACIConfig(permissions, attrs types.Set) (diags diag.Diagnostics) {
+	for _, permission := range knownSetStrings(permissions) {
+		if !slices.Contains(aciPermissions, permission) {
+			diags.AddAttributeError(
+				path.Root("permissions"),
+				"Invalid configuration",
+				"“permissions” must only contain: "+strings.Join(aciPermissions, ", ")+".",
+			)
+			break
+		}
+	}
+
+	if !attrs.IsNull() && !attrs.IsUnknown() && len(attrs.Elements()) == 0 {
+		diags.AddAttributeError(
+			path.Root("attrs"),
+			"Invalid configuration",
+			"“attrs” must contain at least one attribute.",
+		)
+	}
+
+	for _, attr := range knownSetStrings(attrs) {
+		if !aciAttrPattern.MatchString(attr) {
+			diags.AddAttributeError(
+				path.Root("attrs"),
+				"Invalid configuration",
+				"“"+attr+"” is not a valid attribute name.",
+			)
+		}
+	}
+
+	return
+}
+
+// validateACIAttrs validates that the attributes of an ACI exist in the
+// server schema, as attributes of the user entries which both self-service
+// and delegation rules apply to.
+func validateACIAttrs(ctx context.Context, p *provider.Provider, attrs types.Set) (diags diag.Diagnostics) {
+	values := knownSetStrings(attrs)
+
+	// The provider is not configured yet when its configuration is unknown.
+	if len(values) == 0 || p.Client() == nil {
+		return
+	}
+
+	tflog.Trace(ctx, "Calling JsonMetadata", map[string]any{
+		"args":     []any{"user"},
+		"opt_args": nil,
+	})
+
+	var res struct {
+		Objects map[string]struct {
+			ACIAttrs []string `json:"aciattrs"`
+		} `json:"objects"`
+	}
+
+	// The attributes FreeIPA allows in the ACIs of an object are only
+	// exposed in the metadata of the object, as used by the web UI.
+	err := p.Call("json_metadata", []any{"user"}, nil, &res)
+	tflog.Trace(ctx, "Called JsonMetadata", map[string]any{
+		"err": err,
+	})
+
+	if err != nil {
+		diags.AddError("Failed to read server schema", "Reason: "+err.Error())
+		return
+	}
+
+	known := map[string]bool{}
+
+	for _, attr := range res.Objects["user"].ACIAttrs {
+		known[strings.ToLower(attr)] = true
+	}
+
+	for _, attr := range values {
+		if aciAttrPattern.MatchString(attr) && !known[strings.ToLower(attr)] {
+			diags.AddAttributeError(
+				path.Root("attrs"),
+				"Invalid configuration",
+				"“"+attr+"” is not an attribute of the user entries in the server schema.",
+			)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,121 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSelfserviceRead(t *testing.T) {
+	p := newTestProvider(t, map[string]rpcHandler{
+		"selfservice_show": func(t *testing.T, params map[string]any) string {
+			// The ACI is only returned in raw mode, which the client fails to
+			// decode.
+			return rpcResult(map[string]any{
+				"summary": nil,
+				"value":   "Users can manage their own phone numbers",
+				"result": map[string]any{
+					"aciname":     "Users can manage their own phone numbers",
+					"permissions": []string{"write"},
+					"attrs":       []string{"mobile", "telephonenumber"},
+				},
+			})
+		},
+	})
+
+	ctx := context.Background()
+
+	attrs, _ := types.SetValueFrom(ctx, types.StringType, []string{"telephoneNumber"})
+	model := testRead(t, NewSelfservice(p), SelfserviceModel{
+		Name:        types.StringValue("Users can manage their own phone numbers"),
+		Permissions: types.SetNull(types.StringType),
+		Attrs:       attrs,
+	})
+
+	expectedPermissions, _ := types.SetValueFrom(ctx, types.StringType, []string{"write"})
+	expectedAttrs, _ := types.SetValueFrom(ctx, types.StringType, []string{"mobile", "telephoneNumber"})
+
+	if !model.Permissions.Equal(expectedPermissions) {
+		t.Errorf("unexpected permissions %v, expected %v", model.Permissions, expectedPermissions)
+	}
+
+	if !model.Attrs.Equal(expectedAttrs) {
+		t.Errorf("unexpected attrs %v, expected %v", model.Attrs, expectedAttrs)
+	}
+}
+
+func TestSelfserviceValidateConfig(t *testing.T) {
+	ctx := context.Background()
+
+	r := NewSelfservice(nil).(*Selfservice)
+
+	tests := map[string]struct {
+		permissions []string
+		attrs       []string
+		valid       bool
+	}{
+		"valid":              {[]string{"read", "write"}, []string{"telephoneNumber"}, true},
+		"invalid permission": {[]string{"delete"}, []string{"telephoneNumber"}, false},
+		"invalid attribute":  {[]string{"write"}, []string{"telephone number"}, false},
+		"no attribute":       {[]string{"write"}, []string{}, false},
+	}
+
+	for name, test := range tests {
+		permissions, _ := types.SetValueFrom(ctx, types.StringType, test.permissions)
+		attrs, _ := types.SetValueFrom(ctx, types.StringType, test.attrs)
+		config := testState(t, r, SelfserviceModel{
+			Name:        types.StringValue("test"),
+			Permissions: permissions,
+			Attrs:       attrs,
+		})
+		resp := resource.ValidateConfigResponse{}
+
+		r.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: config.Schema, Raw: config.Raw}}, &resp)
+
+		if resp.Diagnostics.HasError() == test.valid {
+			t.Errorf("%s: unexpected diagnostics %v", name, resp.Diagnostics)
+		}
+	}
+}
+
+func TestSelfserviceModifyPlan(t *testing.T) {
+	p := newTestProvider(t, map[string]rpcHandler{
+		"json_metadata": func(t *testing.T, params map[string]any) string {
+			return rpcResult(map[string]any{
+				"objects": map[string]any{
+					"user": map[string]any{
+						"name":     "user",
+						"aciattrs": []string{"mobile", "telephonenumber"},
+					},
+				},
+				"methods":  map[string]any{},
+				"commands": map[string]any{},
+			})
+		},
+	})
+
+	ctx := context.Background()
+
+	r := NewSelfservice(p).(*Selfservice)
+	attrs, _ := types.SetValueFrom(ctx, types.StringType, []string{"telephoneNumber", "mobil"})
+	planned := testState(t, r, SelfserviceModel{
+		Name:        types.StringValue("Users can manage their own phone numbers"),
+		Permissions: types.SetUnknown(types.StringType),
+		Attrs:       attrs,
+	})
+	plan := tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}
+	resp := resource.ModifyPlanResponse{Plan: plan}
+
+	r.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan}, &resp)
+
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected the misspelled attribute to be reported, got: %v", resp.Diagnostics)
+	}
+
+	if detail := resp.Diagnostics.Errors()[0].Detail(); detail != "“mobil” is not an attribute of the user entries in the server schema." {
+		t.Errorf("unexpected error %s", detail)
+	}
+}
@@ -0,0 +1,381 @@
+package resources
+
+import (
+	"context"
+	"errors"
+
+	"github.com/camptocamp/go-freeipa/freeipa"
+	"github.com/camptocamp/terraform-provider-freeipa/internal/provider"
+	"github.com/camptocamp/terraform-provider-freeipa/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type ServiceDelegationRule struct {
+	provider *provider.Provider
+}
+
+type ServiceDelegationRuleModel struct {
+	Name       types.String `tfsdk:"cn"`
+	Principals types.Set    `tfsdk:"principals"`
+	Targets    types.Set    `tfsdk:"targets"`
+}
+
+func (r *ServiceDelegationRule) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service_delegation_rule"
+}
+
+func (r *ServiceDelegationRule) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version: 0,
+		Attributes: map[string]schema.Attribute{
+			"cn": schema.StringAttribute{
+				Description: "Delegation rule name",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"principals": schema.SetAttribute{
+				Description: "Service principals allowed to delegate user credentials through the rule",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"targets": schema.SetAttribute{
+				Description: "Service delegation targets the principals may delegate user credentials to",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *ServiceDelegationRule) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan, state ServiceDelegationRuleModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var principals, targets []string
+
+	resp.Diagnostics.Append(plan.Principals.ElementsAs(ctx, &principals, false)...)
+	resp.Diagnostics.Append(plan.Targets.ElementsAs(ctx, &targets, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.ServicedelegationruleAddArgs{
+		Cn: plan.Name.ValueString(),
+	}
+
+	tflog.Trace(ctx, "Calling ServicedelegationruleAdd", map[string]any{
+		"args":     args,
+		"opt_args": nil,
+	})
+
+	err := r.provider.Call("servicedelegationrule_add", []any{args.Cn}, nil, nil)
+	tflog.Trace(ctx, "Called ServicedelegationruleAdd", map[string]any{
+		"err": err,
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create service delegation rule", "Reason: "+err.Error())
+		return
+	}
+
+	state = plan
+
+	saveCreated(ctx, resp, state)
+
+	resp.Diagnostics.Append(r.updatePrincipals(ctx, plan.Name.ValueString(), nil, principals)...)
+	resp.Diagnostics.Append(r.updateTargets(ctx, plan.Name.ValueString(), nil, targets)...)
+}
+
+func (r *ServiceDelegationRule) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ServiceDelegationRuleModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entry, err := r.show(ctx, state.Name.ValueString())
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if errors.As(err, &freeipaErr) && freeipaErr.Code == freeipa.NotFoundCode {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Failed to read service delegation rule", "Reason: "+err.Error())
+		return
+	}
+
+	var currentPrincipals []string
+	var diags diag.Diagnostics
+
+	resp.Diagnostics.Append(state.Principals.ElementsAs(ctx, &currentPrincipals, false)...)
+
+	principals := normalizePrincipals(entryStrings(entry, "memberprincipal"), currentPrincipals)
+
+	state.Principals, diags = membersSetValue(ctx, state.Principals, &principals)
+	resp.Diagnostics.Append(diags...)
+
+	targets := entryStrings(entry, "ipaallowedtarget_servicedelegationtarget")
+
+	state.Targets, diags = membersSetValue(ctx, state.Targets, &targets)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *ServiceDelegationRule) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state, plan ServiceDelegationRuleModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var actualPrincipals, desiredPrincipals, actualTargets, desiredTargets []string
+
+	resp.Diagnostics.Append(state.Principals.ElementsAs(ctx, &actualPrincipals, false)...)
+	resp.Diagnostics.Append(plan.Principals.ElementsAs(ctx, &desiredPrincipals, false)...)
+	resp.Diagnostics.Append(state.Targets.ElementsAs(ctx, &actualTargets, false)...)
+	resp.Diagnostics.Append(plan.Targets.ElementsAs(ctx, &desiredTargets, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.updatePrincipals(ctx, plan.Name.ValueString(), actualPrincipals, desiredPrincipals)...)
+	resp.Diagnostics.Append(r.updateTargets(ctx, plan.Name.ValueString(), actualTargets, desiredTargets)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state = plan
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *ServiceDelegationRule) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ServiceDelegationRuleModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.ServicedelegationruleDelArgs{
+		Cn: []string{state.Name.ValueString()},
+	}
+
+	tflog.Trace(ctx, "Calling ServicedelegationruleDel", map[string]any{
+		"args":     args,
+		"opt_args": nil,
+	})
+
+	res, err := r.provider.Client().ServicedelegationruleDel(args, nil)
+
+	tflog.Trace(ctx, "Called ServicedelegationruleDel", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.NotFoundCode {
+			resp.Diagnostics.AddError("Failed to delete service delegation rule", "Reason: "+err.Error())
+			return
+		}
+	}
+}
+
+func (r *ServiceDelegationRule) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	state := ServiceDelegationRuleModel{
+		Name:       types.StringValue(req.ID),
+		Principals: types.SetNull(types.StringType),
+		Targets:    types.SetNull(types.StringType),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func NewServiceDelegationRule(p *provider.Provider) resource.Resource {
+	r := &ServiceDelegationRule{
+		provider: p,
+	}
+
+	var _ resource.Resource = r
+	var _ resource.ResourceWithImportState = r
+
+	return r
+}
+
+func init() {
+	resources = append(resources, NewServiceDelegationRule)
+}
+
+func (r *ServiceDelegationRule) updatePrincipals(ctx context.Context, cn string, actualPrincipals, desiredPrincipals []string) (diags diag.Diagnostics) {
+	principalsToAdd, principalsToRemove := utils.SetDiff(actualPrincipals, desiredPrincipals)
+
+	if len(principalsToRemove) > 0 {
+		args := &freeipa.ServicedelegationruleRemoveMemberArgs{
+			Cn: cn,
+		}
+
+		optArgs := &freeipa.ServicedelegationruleRemoveMemberOptionalArgs{
+			Principal: &principalsToRemove,
+		}
+
+		tflog.Trace(ctx, "Calling ServicedelegationruleRemoveMember", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		res, err := r.provider.Client().ServicedelegationruleRemoveMember(args, optArgs)
+
+		tflog.Trace(ctx, "Called ServicedelegationruleRemoveMember", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		var failed freeipa.FailedOperations
+		if res != nil {
+			failed = res.Failed
+		}
+
+		diags.Append(membershipDiags("Failed to remove service delegation rule principals", failed, err)...)
+	}
+
+	if len(principalsToAdd) > 0 {
+		args := &freeipa.ServicedelegationruleAddMemberArgs{
+			Cn: cn,
+		}
+
+		optArgs := &freeipa.ServicedelegationruleAddMemberOptionalArgs{
+			Principal: &principalsToAdd,
+		}
+
+		tflog.Trace(ctx, "Calling ServicedelegationruleAddMember", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		res, err := r.provider.Client().ServicedelegationruleAddMember(args, optArgs)
+
+		tflog.Trace(ctx, "Called ServicedelegationruleAddMember", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		var failed freeipa.FailedOperations
+		if res != nil {
+			failed = res.Failed
+		}
+
+		diags.Append(membershipDiags("Failed to add service delegation rule principals", failed, err)...)
+	}
+
+	return
+}
+
+func (r *ServiceDelegationRule) updateTargets(ctx context.Context, cn string, actualTargets, desiredTargets []string) (diags diag.Diagnostics) {
+	targetsToAdd, targetsToRemove := utils.SetDiff(actualTargets, desiredTargets)
+
+	if len(targetsToRemove) > 0 {
+		args := &freeipa.ServicedelegationruleRemoveTargetArgs{
+			Cn: cn,
+		}
+
+		optArgs := &freeipa.ServicedelegationruleRemoveTargetOptionalArgs{
+			Servicedelegationtarget: &targetsToRemove,
+		}
+
+		tflog.Trace(ctx, "Calling ServicedelegationruleRemoveTarget", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		res, err := r.provider.Client().ServicedelegationruleRemoveTarget(args, optArgs)
+
+		tflog.Trace(ctx, "Called ServicedelegationruleRemoveTarget", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		var failed freeipa.FailedOperations
+		if res != nil {
+			failed = res.Failed
+		}
+
+		diags.Append(membershipDiags("Failed to remove service delegation rule targets", failed, err)...)
+	}
+
+	if len(targetsToAdd) > 0 {
+		args := &freeipa.ServicedelegationruleAddTargetArgs{
+			Cn: cn,
+		}
+
+		optArgs := &freeipa.ServicedelegationruleAddTargetOptionalArgs{
+			Servicedelegationtarget: &targetsToAdd,
+		}
+
+		tflog.Trace(ctx, "Calling ServicedelegationruleAddTarget", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		res, err := r.provider.Client().ServicedelegationruleAddTarget(args, optArgs)
+
+		tflog.Trace(ctx, "Called ServicedelegationruleAddTarget", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		var failed freeipa.FailedOperations
+		if res != nil {
+			failed = res.Failed
+		}
+
+		diags.Append(membershipDiags("Failed to add service delegation rule targets", failed, err)...)
+	}
+
+	return
+}
+
+// show returns the entry of a service delegation rule.
+func (r *ServiceDelegationRule) show(ctx context.Context, cn string) (map[string]any, error) {
+	optArgs := &freeipa.ServicedelegationruleShowOptionalArgs{
+		All: freeipa.Bool(true),
+	}
+
+	tflog.Trace(ctx, "Calling ServicedelegationruleShow", map[string]any{
+		"args":     cn,
+		"opt_args": optArgs,
+	})
+
+	var res rpcEntryResult
+
+	err := r.provider.Call("servicedelegationrule_show", []any{cn}, optArgs, &res)
+	tflog.Trace(ctx, "Called ServicedelegationruleShow", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	return res.Result, err
+}
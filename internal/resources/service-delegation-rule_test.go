@@ -0,0 +1,44 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestServiceDelegationRuleRead(t *testing.T) {
+	p := newTestProvider(t, map[string]rpcHandler{
+		"servicedelegationrule_show": func(t *testing.T, params map[string]any) string {
+			// A rule with several members, which the client fails to decode.
+			return rpcResult(map[string]any{
+				"summary": nil,
+				"value":   "web-to-db",
+				"result": map[string]any{
+					"cn":              []string{"web-to-db"},
+					"memberprincipal": []string{"HTTP/web1.example.test@EXAMPLE.TEST", "HTTP/web2.example.test@EXAMPLE.TEST"},
+					"ipaallowedtarget_servicedelegationtarget": []string{"db-target"},
+				},
+			})
+		},
+	})
+
+	principals, _ := types.SetValueFrom(context.Background(), types.StringType, []string{"HTTP/web1.example.test"})
+
+	model := testRead(t, NewServiceDelegationRule(p), ServiceDelegationRuleModel{
+		Name:       types.StringValue("web-to-db"),
+		Principals: principals,
+		Targets:    types.SetNull(types.StringType),
+	})
+
+	expectedPrincipals, _ := types.SetValueFrom(context.Background(), types.StringType, []string{"HTTP/web1.example.test", "HTTP/web2.example.test@EXAMPLE.TEST"})
+	expectedTargets, _ := types.SetValueFrom(context.Background(), types.StringType, []string{"db-target"})
+
+	if !model.Principals.Equal(expectedPrincipals) {
+		t.Errorf("unexpected principals %v, expected %v", model.Principals, expectedPrincipals)
+	}
+
+	if !model.Targets.Equal(expectedTargets) {
+		t.Errorf("unexpected targets %v, expected %v", model.Targets, expectedTargets)
+	}
+}
@@ -0,0 +1,300 @@
+package resources
+
+import (
+	"context"
+	"errors"
+
+	"github.com/camptocamp/go-freeipa/freeipa"
+	"github.com/camptocamp/terraform-provider-freeipa/internal/provider"
+	"github.com/camptocamp/terraform-provider-freeipa/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type ServiceDelegationTarget struct {
+	provider *provider.Provider
+}
+
+type ServiceDelegationTargetModel struct {
+	Name       types.String `tfsdk:"cn"`
+	Principals types.Set    `tfsdk:"principals"`
+}
+
+func (r *ServiceDelegationTarget) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service_delegation_target"
+}
+
+func (r *ServiceDelegationTarget) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version: 0,
+		Attributes: map[string]schema.Attribute{
+			"cn": schema.StringAttribute{
+				Description: "Delegation target name",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"principals": schema.SetAttribute{
+				Description: "Service principals delegation rules may delegate to through the target",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *ServiceDelegationTarget) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan, state ServiceDelegationTargetModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var principals []string
+
+	resp.Diagnostics.Append(plan.Principals.ElementsAs(ctx, &principals, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.ServicedelegationtargetAddArgs{
+		Cn: plan.Name.ValueString(),
+	}
+
+	tflog.Trace(ctx, "Calling ServicedelegationtargetAdd", map[string]any{
+		"args":     args,
+		"opt_args": nil,
+	})
+
+	err := r.provider.Call("servicedelegationtarget_add", []any{args.Cn}, nil, nil)
+	tflog.Trace(ctx, "Called ServicedelegationtargetAdd", map[string]any{
+		"err": err,
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create service delegation target", "Reason: "+err.Error())
+		return
+	}
+
+	state = plan
+
+	saveCreated(ctx, resp, state)
+
+	resp.Diagnostics.Append(r.updatePrincipals(ctx, plan.Name.ValueString(), nil, principals)...)
+}
+
+func (r *ServiceDelegationTarget) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ServiceDelegationTargetModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entry, err := r.show(ctx, state.Name.ValueString())
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if errors.As(err, &freeipaErr) && freeipaErr.Code == freeipa.NotFoundCode {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Failed to read service delegation target", "Reason: "+err.Error())
+		return
+	}
+
+	var currentPrincipals []string
+	var diags diag.Diagnostics
+
+	resp.Diagnostics.Append(state.Principals.ElementsAs(ctx, &currentPrincipals, false)...)
+
+	principals := normalizePrincipals(entryStrings(entry, "memberprincipal"), currentPrincipals)
+
+	state.Principals, diags = membersSetValue(ctx, state.Principals, &principals)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *ServiceDelegationTarget) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state, plan ServiceDelegationTargetModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var actualPrincipals, desiredPrincipals []string
+
+	resp.Diagnostics.Append(state.Principals.ElementsAs(ctx, &actualPrincipals, false)...)
+	resp.Diagnostics.Append(plan.Principals.ElementsAs(ctx, &desiredPrincipals, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.updatePrincipals(ctx, plan.Name.ValueString(), actualPrincipals, desiredPrincipals)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state = plan
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *ServiceDelegationTarget) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ServiceDelegationTargetModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.ServicedelegationtargetDelArgs{
+		Cn: []string{state.Name.ValueString()},
+	}
+
+	tflog.Trace(ctx, "Calling ServicedelegationtargetDel", map[string]any{
+		"args":     args,
+		"opt_args": nil,
+	})
+
+	res, err := r.provider.Client().ServicedelegationtargetDel(args, nil)
+
+	tflog.Trace(ctx, "Called ServicedelegationtargetDel", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.NotFoundCode {
+			resp.Diagnostics.AddError("Failed to delete service delegation target", "Reason: "+err.Error())
+			return
+		}
+	}
+}
+
+func (r *ServiceDelegationTarget) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	state := ServiceDelegationTargetModel{
+		Name:       types.StringValue(req.ID),
+		Principals: types.SetNull(types.StringType),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func NewServiceDelegationTarget(p *provider.Provider) resource.Resource {
+	r := &ServiceDelegationTarget{
+		provider: p,
+	}
+
+	var _ resource.Resource = r
+	var _ resource.ResourceWithImportState = r
+
+	return r
+}
+
+func init() {
+	resources = append(resources, NewServiceDelegationTarget)
+}
+
+func (r *ServiceDelegationTarget) updatePrincipals(ctx context.Context, cn string, actualPrincipals, desiredPrincipals []string) (diags diag.Diagnostics) {
+	principalsToAdd, principalsToRemove := utils.SetDiff(actualPrincipals, desiredPrincipals)
+
+	if len(principalsToRemove) > 0 {
+		args := &freeipa.ServicedelegationtargetRemoveMemberArgs{
+			Cn: cn,
+		}
+
+		optArgs := &freeipa.ServicedelegationtargetRemoveMemberOptionalArgs{
+			Principal: &principalsToRemove,
+		}
+
+		tflog.Trace(ctx, "Calling ServicedelegationtargetRemoveMember", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		res, err := r.provider.Client().ServicedelegationtargetRemoveMember(args, optArgs)
+
+		tflog.Trace(ctx, "Called ServicedelegationtargetRemoveMember", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		var failed freeipa.FailedOperations
+		if res != nil {
+			failed = res.Failed
+		}
+
+		diags.Append(membershipDiags("Failed to remove service delegation target principals", failed, err)...)
+	}
+
+	if len(principalsToAdd) > 0 {
+		args := &freeipa.ServicedelegationtargetAddMemberArgs{
+			Cn: cn,
+		}
+
+		optArgs := &freeipa.ServicedelegationtargetAddMemberOptionalArgs{
+			Principal: &principalsToAdd,
+		}
+
+		tflog.Trace(ctx, "Calling ServicedelegationtargetAddMember", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		res, err := r.provider.Client().ServicedelegationtargetAddMember(args, optArgs)
+
+		tflog.Trace(ctx, "Called ServicedelegationtargetAddMember", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		var failed freeipa.FailedOperations
+		if res != nil {
+			failed = res.Failed
+		}
+
+		diags.Append(membershipDiags("Failed to add service delegation target principals", failed, err)...)
+	}
+
+	return
+}
+
+// show returns the entry of a service delegation target.
+func (r *ServiceDelegationTarget) show(ctx context.Context, cn string) (map[string]any, error) {
+	optArgs := &freeipa.ServicedelegationtargetShowOptionalArgs{
+		All: freeipa.Bool(true),
+	}
+
+	tflog.Trace(ctx, "Calling ServicedelegationtargetShow", map[string]any{
+		"args":     cn,
+		"opt_args": optArgs,
+	})
+
+	var res rpcEntryResult
+
+	err := r.provider.Call("servicedelegationtarget_show", []any{cn}, optArgs, &res)
+	tflog.Trace(ctx, "Called ServicedelegationtargetShow", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	return res.Result, err
+}
@@ -3,25 +3,62 @@ package resources
 import (
 	"context"
 	"errors"
+	"slices"
+	"strings"
 
 	"github.com/camptocamp/go-freeipa/freeipa"
 	"github.com/camptocamp/terraform-provider-freeipa/internal/provider"
+	"github.com/camptocamp/terraform-provider-freeipa/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+var (
+	servicePACTypes        = []string{"MS-PAC", "PAD", "NONE"}
+	kerberosAuthIndicators = []string{"otp", "radius", "pkinit", "hardened", "idp", "passkey"}
+)
+
 type Service struct {
 	provider *provider.Provider
 }
 
 type ServiceModel struct {
-	KrbHostname   types.String `tfsdk:"krb_hostname"`
-	Force         types.Bool   `tfsdk:"force"`
-	SkipHostCheck types.Bool   `tfsdk:"skip_host_check"`
+	KrbHostname              types.String `tfsdk:"krb_hostname"`
+	Force                    types.Bool   `tfsdk:"force"`
+	SkipHostCheck            types.Bool   `tfsdk:"skip_host_check"`
+	PrincipalAliases         types.Set    `tfsdk:"principal_aliases"`
+	PACType                  types.Set    `tfsdk:"pac_type"`
+	AuthIndicators           types.Set    `tfsdk:"auth_indicators"`
+	RequiresPreAuth          types.Bool   `tfsdk:"requires_pre_auth"`
+	OkAsDelegate             types.Bool   `tfsdk:"ok_as_delegate"`
+	OkToAuthAsDelegate       types.Bool   `tfsdk:"ok_to_auth_as_delegate"`
+	ManagedByHosts           types.Set    `tfsdk:"managed_by_hosts"`
+	RetrieveKeytabUsers      types.Set    `tfsdk:"retrieve_keytab_users"`
+	RetrieveKeytabGroups     types.Set    `tfsdk:"retrieve_keytab_groups"`
+	RetrieveKeytabHosts      types.Set    `tfsdk:"retrieve_keytab_hosts"`
+	RetrieveKeytabHostgroups types.Set    `tfsdk:"retrieve_keytab_hostgroups"`
+	CreateKeytabUsers        types.Set    `tfsdk:"create_keytab_users"`
+	CreateKeytabGroups       types.Set    `tfsdk:"create_keytab_groups"`
+	CreateKeytabHosts        types.Set    `tfsdk:"create_keytab_hosts"`
+	CreateKeytabHostgroups   types.Set    `tfsdk:"create_keytab_hostgroups"`
+	DelegationPrincipals     types.Set    `tfsdk:"delegation_principals"`
+}
+
+// serviceKeytabMembers holds the principals allowed to retrieve or create the
+// keytab of a service.
+type serviceKeytabMembers struct {
+	users      []string
+	groups     []string
+	hosts      []string
+	hostgroups []string
 }
 
 func (r *Service) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -47,10 +84,143 @@ func (r *Service) Schema(ctx context.Context, req resource.SchemaRequest, resp *
 				Description: "Skip host check force service to be created even when host object does not exist to manage it",
 				Optional:    true,
 			},
+			"principal_aliases": schema.SetAttribute{
+				Description: "Additional principal names of the service",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"pac_type": schema.SetAttribute{
+				Description: "Override the default PAC type of the service, one of: " + strings.Join(servicePACTypes, ", "),
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"auth_indicators": schema.SetAttribute{
+				Description: "Authentication indicators required to obtain a ticket for the service, one of: " + strings.Join(kerberosAuthIndicators, ", "),
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"requires_pre_auth": schema.BoolAttribute{
+				Description: "Pre-authentication is required for the service",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ok_as_delegate": schema.BoolAttribute{
+				Description: "Client credentials may be delegated to the service",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ok_to_auth_as_delegate": schema.BoolAttribute{
+				Description: "The service is allowed to authenticate on behalf of a client",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"managed_by_hosts": schema.SetAttribute{
+				Description: "Hosts that can manage the service (defaults to the host of the service)",
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"retrieve_keytab_users": schema.SetAttribute{
+				Description: "Users allowed to retrieve the keytab of the service",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"retrieve_keytab_groups": schema.SetAttribute{
+				Description: "Groups allowed to retrieve the keytab of the service",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"retrieve_keytab_hosts": schema.SetAttribute{
+				Description: "Hosts allowed to retrieve the keytab of the service",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"retrieve_keytab_hostgroups": schema.SetAttribute{
+				Description: "Host groups allowed to retrieve the keytab of the service",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"create_keytab_users": schema.SetAttribute{
+				Description: "Users allowed to create the keytab of the service",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"create_keytab_groups": schema.SetAttribute{
+				Description: "Groups allowed to create the keytab of the service",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"create_keytab_hosts": schema.SetAttribute{
+				Description: "Hosts allowed to create the keytab of the service",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"create_keytab_hostgroups": schema.SetAttribute{
+				Description: "Host groups allowed to create the keytab of the service",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"delegation_principals": schema.SetAttribute{
+				Description: "Service principals allowed to delegate user credentials to the service (resource-based constrained delegation)",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
 		},
 	}
 }
 
+func (r *Service) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config ServiceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pacTypes := knownSetStrings(config.PACType)
+	authIndicators := knownSetStrings(config.AuthIndicators)
+
+	for _, pacType := range pacTypes {
+		if !slices.Contains(servicePACTypes, pacType) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("pac_type"),
+				"Invalid configuration",
+				"“pac_type” values must be one of: "+strings.Join(servicePACTypes, ", ")+".",
+			)
+		}
+	}
+
+	if len(pacTypes) > 1 && slices.Contains(pacTypes, "NONE") {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("pac_type"),
+			"Invalid configuration",
+			"“NONE” cannot be combined with other “pac_type” values.",
+		)
+	}
+
+	for _, authIndicator := range authIndicators {
+		if !slices.Contains(kerberosAuthIndicators, authIndicator) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("auth_indicators"),
+				"Invalid configuration",
+				"“auth_indicators” values must be one of: "+strings.Join(kerberosAuthIndicators, ", ")+".",
+			)
+		}
+	}
+}
+
 func (r *Service) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan, state ServiceModel
 
@@ -59,13 +229,41 @@ func (r *Service) Create(ctx context.Context, req resource.CreateRequest, resp *
 		return
 	}
 
+	var principalAliases, pacTypes, authIndicators, delegationPrincipals []string
+
+	resp.Diagnostics.Append(plan.PrincipalAliases.ElementsAs(ctx, &principalAliases, false)...)
+	resp.Diagnostics.Append(plan.DelegationPrincipals.ElementsAs(ctx, &delegationPrincipals, false)...)
+	resp.Diagnostics.Append(plan.PACType.ElementsAs(ctx, &pacTypes, false)...)
+	resp.Diagnostics.Append(plan.AuthIndicators.ElementsAs(ctx, &authIndicators, false)...)
+
+	retrieveKeytab, diags := serviceKeytabMembersFromSets(ctx, plan.RetrieveKeytabUsers, plan.RetrieveKeytabGroups, plan.RetrieveKeytabHosts, plan.RetrieveKeytabHostgroups)
+	resp.Diagnostics.Append(diags...)
+
+	createKeytab, diags := serviceKeytabMembersFromSets(ctx, plan.CreateKeytabUsers, plan.CreateKeytabGroups, plan.CreateKeytabHosts, plan.CreateKeytabHostgroups)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	args := &freeipa.ServiceAddArgs{
 		Krbcanonicalname: plan.KrbHostname.ValueString(),
 	}
 	optArgs := &freeipa.ServiceAddOptionalArgs{
-		Force:         plan.Force.ValueBoolPointer(),
-		SkipHostCheck: plan.SkipHostCheck.ValueBoolPointer(),
-		All:           freeipa.Bool(true),
+		Force:                    plan.Force.ValueBoolPointer(),
+		SkipHostCheck:            plan.SkipHostCheck.ValueBoolPointer(),
+		Ipakrbrequirespreauth:    knownBool(plan.RequiresPreAuth),
+		Ipakrbokasdelegate:       knownBool(plan.OkAsDelegate),
+		Ipakrboktoauthasdelegate: knownBool(plan.OkToAuthAsDelegate),
+		All:                      freeipa.Bool(true),
+	}
+
+	if len(pacTypes) > 0 {
+		optArgs.Ipakrbauthzdata = &pacTypes
+	}
+
+	if len(authIndicators) > 0 {
+		optArgs.Krbprincipalauthind = &authIndicators
 	}
 
 	tflog.Trace(ctx, "Calling ServiceAdd", map[string]any{
@@ -73,7 +271,9 @@ func (r *Service) Create(ctx context.Context, req resource.CreateRequest, resp *
 		"opt_args": optArgs,
 	})
 
-	res, err := r.provider.Client().ServiceAdd(args, optArgs)
+	var res rpcEntryResult
+
+	err := r.provider.Call("service_add", []any{args.Krbcanonicalname}, optArgs, &res)
 	tflog.Trace(ctx, "Called ServiceAdd", map[string]any{
 		"res": res,
 		"err": err,
@@ -86,6 +286,39 @@ func (r *Service) Create(ctx context.Context, req resource.CreateRequest, resp *
 
 	state = plan
 
+	resp.Diagnostics.Append(state.setEntry(ctx, res.Result)...)
+	saveCreated(ctx, resp, state)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := plan.KrbHostname.ValueString()
+
+	resp.Diagnostics.Append(r.updatePrincipalAliases(ctx, name, nil, principalAliases)...)
+
+	if !plan.ManagedByHosts.IsUnknown() {
+		var actualManagedByHosts, desiredManagedByHosts []string
+
+		resp.Diagnostics.Append(state.ManagedByHosts.ElementsAs(ctx, &actualManagedByHosts, false)...)
+		resp.Diagnostics.Append(plan.ManagedByHosts.ElementsAs(ctx, &desiredManagedByHosts, false)...)
+		resp.Diagnostics.Append(r.updateManagedByHosts(ctx, name, actualManagedByHosts, desiredManagedByHosts)...)
+	}
+
+	resp.Diagnostics.Append(r.updateKeytabMembers(ctx, name, true, serviceKeytabMembers{}, retrieveKeytab)...)
+	resp.Diagnostics.Append(r.updateKeytabMembers(ctx, name, false, serviceKeytabMembers{}, createKeytab)...)
+	resp.Diagnostics.Append(r.updateDelegationPrincipals(ctx, name, nil, delegationPrincipals)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entry, err := r.show(ctx, name)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read Service", "Reason: "+err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(state.setEntry(ctx, entry)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 }
 
@@ -97,23 +330,7 @@ func (r *Service) Read(ctx context.Context, req resource.ReadRequest, resp *reso
 		return
 	}
 
-	args := &freeipa.ServiceShowArgs{
-		Krbcanonicalname: state.KrbHostname.ValueString(),
-	}
-	optArgs := &freeipa.ServiceShowOptionalArgs{
-		All: freeipa.Bool(true),
-	}
-
-	tflog.Trace(ctx, "Calling ServiceShow", map[string]any{
-		"args":     args,
-		"opt_args": optArgs,
-	})
-
-	res, err := r.provider.Client().ServiceShow(args, optArgs)
-	tflog.Trace(ctx, "Called ServiceShow", map[string]any{
-		"res": res,
-		"err": err,
-	})
+	entry, err := r.show(ctx, state.KrbHostname.ValueString())
 	if err != nil {
 		var freeipaErr *freeipa.Error
 
@@ -126,6 +343,11 @@ func (r *Service) Read(ctx context.Context, req resource.ReadRequest, resp *reso
 		return
 	}
 
+	resp.Diagnostics.Append(state.setEntry(ctx, entry)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 }
 
@@ -139,14 +361,51 @@ func (r *Service) Update(ctx context.Context, req resource.UpdateRequest, resp *
 		return
 	}
 
+	name := plan.KrbHostname.ValueString()
+
 	args := &freeipa.ServiceModArgs{
-		Krbcanonicalname: plan.KrbHostname.ValueString(),
+		Krbcanonicalname: name,
 	}
 	optArgs := &freeipa.ServiceModOptionalArgs{
 		All: freeipa.Bool(true),
 	}
 
-	hasDiff = !plan.KrbHostname.Equal(state.KrbHostname)
+	if !plan.PACType.Equal(state.PACType) {
+		pacTypes := []string{}
+
+		resp.Diagnostics.Append(plan.PACType.ElementsAs(ctx, &pacTypes, false)...)
+
+		optArgs.Ipakrbauthzdata = &pacTypes
+		hasDiff = true
+	}
+
+	if !plan.AuthIndicators.Equal(state.AuthIndicators) {
+		authIndicators := []string{}
+
+		resp.Diagnostics.Append(plan.AuthIndicators.ElementsAs(ctx, &authIndicators, false)...)
+
+		optArgs.Krbprincipalauthind = &authIndicators
+		hasDiff = true
+	}
+
+	if v := knownBool(plan.RequiresPreAuth); v != nil && !plan.RequiresPreAuth.Equal(state.RequiresPreAuth) {
+		optArgs.Ipakrbrequirespreauth = v
+		hasDiff = true
+	}
+
+	if v := knownBool(plan.OkAsDelegate); v != nil && !plan.OkAsDelegate.Equal(state.OkAsDelegate) {
+		optArgs.Ipakrbokasdelegate = v
+		hasDiff = true
+	}
+
+	if v := knownBool(plan.OkToAuthAsDelegate); v != nil && !plan.OkToAuthAsDelegate.Equal(state.OkToAuthAsDelegate) {
+		optArgs.Ipakrboktoauthasdelegate = v
+		hasDiff = true
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	if hasDiff {
 		tflog.Trace(ctx, "Calling ServiceMod", map[string]any{
@@ -154,14 +413,17 @@ func (r *Service) Update(ctx context.Context, req resource.UpdateRequest, resp *
 			"opt_args": optArgs,
 		})
 
-		res, err := r.provider.Client().ServiceMod(args, optArgs)
+		err := r.provider.Call("service_mod", []any{name}, optArgs, nil)
 		tflog.Trace(ctx, "Called ServiceMod", map[string]any{
-			"res": res,
 			"err": err,
 		})
 		if err != nil {
-			resp.Diagnostics.AddError("Failed to update service", "Reason: "+err.Error())
-			return
+			var freeipaErr *freeipa.Error
+
+			if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.EmptyModlistCode {
+				resp.Diagnostics.AddError("Failed to update service", "Reason: "+err.Error())
+				return
+			}
 		}
 	} else {
 		tflog.Debug(ctx, "Updated service has no effective difference", map[string]any{
@@ -169,6 +431,41 @@ func (r *Service) Update(ctx context.Context, req resource.UpdateRequest, resp *
 		})
 	}
 
+	var actualAliases, desiredAliases, actualManagedByHosts, desiredManagedByHosts, actualDelegationPrincipals, desiredDelegationPrincipals []string
+
+	resp.Diagnostics.Append(state.PrincipalAliases.ElementsAs(ctx, &actualAliases, false)...)
+	resp.Diagnostics.Append(plan.PrincipalAliases.ElementsAs(ctx, &desiredAliases, false)...)
+	resp.Diagnostics.Append(state.ManagedByHosts.ElementsAs(ctx, &actualManagedByHosts, false)...)
+	resp.Diagnostics.Append(plan.ManagedByHosts.ElementsAs(ctx, &desiredManagedByHosts, false)...)
+	resp.Diagnostics.Append(state.DelegationPrincipals.ElementsAs(ctx, &actualDelegationPrincipals, false)...)
+	resp.Diagnostics.Append(plan.DelegationPrincipals.ElementsAs(ctx, &desiredDelegationPrincipals, false)...)
+
+	actualRetrieveKeytab, diags := serviceKeytabMembersFromSets(ctx, state.RetrieveKeytabUsers, state.RetrieveKeytabGroups, state.RetrieveKeytabHosts, state.RetrieveKeytabHostgroups)
+	resp.Diagnostics.Append(diags...)
+
+	desiredRetrieveKeytab, diags := serviceKeytabMembersFromSets(ctx, plan.RetrieveKeytabUsers, plan.RetrieveKeytabGroups, plan.RetrieveKeytabHosts, plan.RetrieveKeytabHostgroups)
+	resp.Diagnostics.Append(diags...)
+
+	actualCreateKeytab, diags := serviceKeytabMembersFromSets(ctx, state.CreateKeytabUsers, state.CreateKeytabGroups, state.CreateKeytabHosts, state.CreateKeytabHostgroups)
+	resp.Diagnostics.Append(diags...)
+
+	desiredCreateKeytab, diags := serviceKeytabMembersFromSets(ctx, plan.CreateKeytabUsers, plan.CreateKeytabGroups, plan.CreateKeytabHosts, plan.CreateKeytabHostgroups)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.updatePrincipalAliases(ctx, name, actualAliases, desiredAliases)...)
+	resp.Diagnostics.Append(r.updateManagedByHosts(ctx, name, actualManagedByHosts, desiredManagedByHosts)...)
+	resp.Diagnostics.Append(r.updateKeytabMembers(ctx, name, true, actualRetrieveKeytab, desiredRetrieveKeytab)...)
+	resp.Diagnostics.Append(r.updateKeytabMembers(ctx, name, false, actualCreateKeytab, desiredCreateKeytab)...)
+	resp.Diagnostics.Append(r.updateDelegationPrincipals(ctx, name, actualDelegationPrincipals, desiredDelegationPrincipals)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	state = plan
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
@@ -211,7 +508,20 @@ func (r *Service) Delete(ctx context.Context, req resource.DeleteRequest, resp *
 
 func (r *Service) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	state := ServiceModel{
-		KrbHostname: types.StringValue(req.ID),
+		KrbHostname:              types.StringValue(req.ID),
+		PrincipalAliases:         types.SetNull(types.StringType),
+		PACType:                  types.SetNull(types.StringType),
+		AuthIndicators:           types.SetNull(types.StringType),
+		ManagedByHosts:           types.SetNull(types.StringType),
+		RetrieveKeytabUsers:      types.SetNull(types.StringType),
+		RetrieveKeytabGroups:     types.SetNull(types.StringType),
+		RetrieveKeytabHosts:      types.SetNull(types.StringType),
+		RetrieveKeytabHostgroups: types.SetNull(types.StringType),
+		CreateKeytabUsers:        types.SetNull(types.StringType),
+		CreateKeytabGroups:       types.SetNull(types.StringType),
+		CreateKeytabHosts:        types.SetNull(types.StringType),
+		CreateKeytabHostgroups:   types.SetNull(types.StringType),
+		DelegationPrincipals:     types.SetNull(types.StringType),
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
@@ -223,6 +533,7 @@ func NewService(p *provider.Provider) resource.Resource {
 	}
 
 	var _ resource.Resource = r
+	var _ resource.ResourceWithValidateConfig = r
 	var _ resource.ResourceWithImportState = r
 
 	return r
@@ -231,3 +542,411 @@ func NewService(p *provider.Provider) resource.Resource {
 func init() {
 	resources = append(resources, NewService)
 }
+
+// show returns the entry of a service.
+func (r *Service) show(ctx context.Context, name string) (map[string]any, error) {
+	optArgs := &freeipa.ServiceShowOptionalArgs{
+		All: freeipa.Bool(true),
+	}
+
+	tflog.Trace(ctx, "Calling ServiceShow", map[string]any{
+		"args":     name,
+		"opt_args": optArgs,
+	})
+
+	var res rpcEntryResult
+
+	err := r.provider.Call("service_show", []any{name}, optArgs, &res)
+	tflog.Trace(ctx, "Called ServiceShow", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	return res.Result, err
+}
+
+// setEntry sets the attributes of a service from its entry.
+func (m *ServiceModel) setEntry(ctx context.Context, entry map[string]any) (diags diag.Diagnostics) {
+	var d diag.Diagnostics
+
+	canonicalName := entryString(entry, "krbcanonicalname")
+
+	var currentAliases []string
+
+	diags.Append(m.PrincipalAliases.ElementsAs(ctx, &currentAliases, false)...)
+
+	aliases := servicePrincipalAliases(canonicalName, entryStrings(entry, "krbprincipalname"), currentAliases)
+
+	m.PrincipalAliases, d = membersSetValue(ctx, m.PrincipalAliases, &aliases)
+	diags.Append(d...)
+
+	pacTypes := entryStrings(entry, "ipakrbauthzdata")
+	m.PACType, d = membersSetValue(ctx, m.PACType, &pacTypes)
+	diags.Append(d...)
+
+	authIndicators := entryStrings(entry, "krbprincipalauthind")
+	m.AuthIndicators, d = membersSetValue(ctx, m.AuthIndicators, &authIndicators)
+	diags.Append(d...)
+
+	m.RequiresPreAuth = types.BoolPointerValue(entryBool(entry, "ipakrbrequirespreauth"))
+	m.OkAsDelegate = types.BoolPointerValue(entryBool(entry, "ipakrbokasdelegate"))
+	m.OkToAuthAsDelegate = types.BoolPointerValue(entryBool(entry, "ipakrboktoauthasdelegate"))
+
+	m.ManagedByHosts, d = types.SetValueFrom(ctx, types.StringType, append([]string{}, entryStrings(entry, "managedby_host")...))
+	diags.Append(d...)
+
+	for _, attr := range []struct {
+		set  *types.Set
+		name string
+	}{
+		{&m.RetrieveKeytabUsers, "ipaallowedtoperform_read_keys_user"},
+		{&m.RetrieveKeytabGroups, "ipaallowedtoperform_read_keys_group"},
+		{&m.RetrieveKeytabHosts, "ipaallowedtoperform_read_keys_host"},
+		{&m.RetrieveKeytabHostgroups, "ipaallowedtoperform_read_keys_hostgroup"},
+		{&m.CreateKeytabUsers, "ipaallowedtoperform_write_keys_user"},
+		{&m.CreateKeytabGroups, "ipaallowedtoperform_write_keys_group"},
+		{&m.CreateKeytabHosts, "ipaallowedtoperform_write_keys_host"},
+		{&m.CreateKeytabHostgroups, "ipaallowedtoperform_write_keys_hostgroup"},
+	} {
+		values := entryStrings(entry, attr.name)
+
+		*attr.set, d = membersSetValue(ctx, *attr.set, &values)
+		diags.Append(d...)
+	}
+
+	var currentDelegationPrincipals []string
+
+	diags.Append(m.DelegationPrincipals.ElementsAs(ctx, &currentDelegationPrincipals, false)...)
+
+	delegationPrincipals := normalizePrincipals(entryStrings(entry, "memberprincipal"), currentDelegationPrincipals)
+
+	m.DelegationPrincipals, d = membersSetValue(ctx, m.DelegationPrincipals, &delegationPrincipals)
+	diags.Append(d...)
+
+	return
+}
+
+// servicePrincipalAliases returns the principal names of a service other than
+// its canonical name.
+func servicePrincipalAliases(canonicalName *string, principals, current []string) []string {
+	aliases := []string{}
+
+	for _, principal := range principals {
+		if canonicalName == nil || principal != *canonicalName {
+			aliases = append(aliases, principal)
+		}
+	}
+
+	return normalizePrincipals(aliases, current)
+}
+
+func serviceKeytabMembersFromSets(ctx context.Context, users, groups, hosts, hostgroups types.Set) (members serviceKeytabMembers, diags diag.Diagnostics) {
+	diags.Append(users.ElementsAs(ctx, &members.users, false)...)
+	diags.Append(groups.ElementsAs(ctx, &members.groups, false)...)
+	diags.Append(hosts.ElementsAs(ctx, &members.hosts, false)...)
+	diags.Append(hostgroups.ElementsAs(ctx, &members.hostgroups, false)...)
+
+	return
+}
+
+func (r *Service) updatePrincipalAliases(ctx context.Context, name string, actualAliases, desiredAliases []string) (diags diag.Diagnostics) {
+	aliasesToAdd, aliasesToRemove := utils.SetDiff(actualAliases, desiredAliases)
+
+	if len(aliasesToRemove) > 0 {
+		args := &freeipa.ServiceRemovePrincipalArgs{
+			Krbcanonicalname: name,
+			Krbprincipalname: aliasesToRemove,
+		}
+
+		tflog.Trace(ctx, "Calling ServiceRemovePrincipal", map[string]any{
+			"args":     args,
+			"opt_args": nil,
+		})
+
+		res, err := r.provider.Client().ServiceRemovePrincipal(args, nil)
+
+		tflog.Trace(ctx, "Called ServiceRemovePrincipal", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		if err != nil {
+			diags.AddError("Failed to remove service principal aliases", "Reason: "+err.Error())
+		}
+	}
+
+	if len(aliasesToAdd) > 0 {
+		args := &freeipa.ServiceAddPrincipalArgs{
+			Krbcanonicalname: name,
+			Krbprincipalname: aliasesToAdd,
+		}
+
+		tflog.Trace(ctx, "Calling ServiceAddPrincipal", map[string]any{
+			"args":     args,
+			"opt_args": nil,
+		})
+
+		res, err := r.provider.Client().ServiceAddPrincipal(args, nil)
+
+		tflog.Trace(ctx, "Called ServiceAddPrincipal", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		if err != nil {
+			diags.AddError("Failed to add service principal aliases", "Reason: "+err.Error())
+		}
+	}
+
+	return
+}
+
+func (r *Service) updateManagedByHosts(ctx context.Context, name string, actualHosts, desiredHosts []string) (diags diag.Diagnostics) {
+	hostsToAdd, hostsToRemove := utils.SetDiff(actualHosts, desiredHosts)
+
+	if len(hostsToAdd) > 0 {
+		args := &freeipa.ServiceAddHostArgs{
+			Krbcanonicalname: name,
+		}
+
+		optArgs := &freeipa.ServiceAddHostOptionalArgs{
+			Host: &hostsToAdd,
+		}
+
+		tflog.Trace(ctx, "Calling ServiceAddHost", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		res, err := r.provider.Client().ServiceAddHost(args, optArgs)
+
+		tflog.Trace(ctx, "Called ServiceAddHost", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		var failed freeipa.FailedOperations
+		if res != nil {
+			failed = res.Failed
+		}
+
+		diags.Append(membershipDiags("Failed to add service managed by hosts", failed, err)...)
+	}
+
+	if len(hostsToRemove) > 0 {
+		args := &freeipa.ServiceRemoveHostArgs{
+			Krbcanonicalname: name,
+		}
+
+		optArgs := &freeipa.ServiceRemoveHostOptionalArgs{
+			Host: &hostsToRemove,
+		}
+
+		tflog.Trace(ctx, "Calling ServiceRemoveHost", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		res, err := r.provider.Client().ServiceRemoveHost(args, optArgs)
+
+		tflog.Trace(ctx, "Called ServiceRemoveHost", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		var failed freeipa.FailedOperations
+		if res != nil {
+			failed = res.Failed
+		}
+
+		diags.Append(membershipDiags("Failed to remove service managed by hosts", failed, err)...)
+	}
+
+	return
+}
+
+// updateKeytabMembers updates the principals allowed to retrieve the keytab
+// of a service if retrieve is true, or to create it otherwise.
+func (r *Service) updateKeytabMembers(ctx context.Context, name string, retrieve bool, actual, desired serviceKeytabMembers) (diags diag.Diagnostics) {
+	var toAdd, toRemove serviceKeytabMembers
+
+	toAdd.users, toRemove.users = utils.SetDiff(actual.users, desired.users)
+	toAdd.groups, toRemove.groups = utils.SetDiff(actual.groups, desired.groups)
+	toAdd.hosts, toRemove.hosts = utils.SetDiff(actual.hosts, desired.hosts)
+	toAdd.hostgroups, toRemove.hostgroups = utils.SetDiff(actual.hostgroups, desired.hostgroups)
+
+	client := r.provider.Client()
+
+	if !toAdd.isEmpty() {
+		var failed freeipa.FailedOperations
+		var err error
+
+		optArgs := &freeipa.ServiceAllowRetrieveKeytabOptionalArgs{
+			User:      membersPointer(toAdd.users),
+			Group:     membersPointer(toAdd.groups),
+			Host:      membersPointer(toAdd.hosts),
+			Hostgroup: membersPointer(toAdd.hostgroups),
+		}
+
+		if retrieve {
+			args := &freeipa.ServiceAllowRetrieveKeytabArgs{
+				Krbcanonicalname: name,
+			}
+
+			tflog.Trace(ctx, "Calling ServiceAllowRetrieveKeytab", map[string]any{
+				"args":     args,
+				"opt_args": optArgs,
+			})
+
+			var res *freeipa.ServiceAllowRetrieveKeytabResult
+
+			res, err = client.ServiceAllowRetrieveKeytab(args, optArgs)
+
+			tflog.Trace(ctx, "Called ServiceAllowRetrieveKeytab", map[string]any{
+				"res": res,
+				"err": err,
+			})
+
+			if res != nil {
+				failed = res.Failed
+			}
+		} else {
+			args := &freeipa.ServiceAllowCreateKeytabArgs{
+				Krbcanonicalname: name,
+			}
+
+			createOptArgs := (*freeipa.ServiceAllowCreateKeytabOptionalArgs)(optArgs)
+
+			tflog.Trace(ctx, "Calling ServiceAllowCreateKeytab", map[string]any{
+				"args":     args,
+				"opt_args": createOptArgs,
+			})
+
+			var res *freeipa.ServiceAllowCreateKeytabResult
+
+			res, err = client.ServiceAllowCreateKeytab(args, createOptArgs)
+
+			tflog.Trace(ctx, "Called ServiceAllowCreateKeytab", map[string]any{
+				"res": res,
+				"err": err,
+			})
+
+			if res != nil {
+				failed = res.Failed
+			}
+		}
+
+		diags.Append(membershipDiags("Failed to allow service keytab access", failed, err)...)
+	}
+
+	if !toRemove.isEmpty() {
+		var failed freeipa.FailedOperations
+		var err error
+
+		optArgs := &freeipa.ServiceDisallowRetrieveKeytabOptionalArgs{
+			User:      membersPointer(toRemove.users),
+			Group:     membersPointer(toRemove.groups),
+			Host:      membersPointer(toRemove.hosts),
+			Hostgroup: membersPointer(toRemove.hostgroups),
+		}
+
+		if retrieve {
+			args := &freeipa.ServiceDisallowRetrieveKeytabArgs{
+				Krbcanonicalname: name,
+			}
+
+			tflog.Trace(ctx, "Calling ServiceDisallowRetrieveKeytab", map[string]any{
+				"args":     args,
+				"opt_args": optArgs,
+			})
+
+			var res *freeipa.ServiceDisallowRetrieveKeytabResult
+
+			res, err = client.ServiceDisallowRetrieveKeytab(args, optArgs)
+
+			tflog.Trace(ctx, "Called ServiceDisallowRetrieveKeytab", map[string]any{
+				"res": res,
+				"err": err,
+			})
+
+			if res != nil {
+				failed = res.Failed
+			}
+		} else {
+			args := &freeipa.ServiceDisallowCreateKeytabArgs{
+				Krbcanonicalname: name,
+			}
+
+			createOptArgs := (*freeipa.ServiceDisallowCreateKeytabOptionalArgs)(optArgs)
+
+			tflog.Trace(ctx, "Calling ServiceDisallowCreateKeytab", map[string]any{
+				"args":     args,
+				"opt_args": createOptArgs,
+			})
+
+			var res *freeipa.ServiceDisallowCreateKeytabResult
+
+			res, err = client.ServiceDisallowCreateKeytab(args, createOptArgs)
+
+			tflog.Trace(ctx, "Called ServiceDisallowCreateKeytab", map[string]any{
+				"res": res,
+				"err": err,
+			})
+
+			if res != nil {
+				failed = res.Failed
+			}
+		}
+
+		diags.Append(membershipDiags("Failed to disallow service keytab access", failed, err)...)
+	}
+
+	return
+}
+
+// updateDelegationPrincipals updates the principals allowed to delegate to
+// a service. The client does not know the service_*_delegation commands,
+// which are called raw.
+func (r *Service) updateDelegationPrincipals(ctx context.Context, name string, actualPrincipals, desiredPrincipals []string) (diags diag.Diagnostics) {
+	principalsToAdd, principalsToRemove := utils.SetDiff(actualPrincipals, desiredPrincipals)
+
+	if len(principalsToRemove) > 0 {
+		tflog.Trace(ctx, "Calling ServiceRemoveDelegation", map[string]any{
+			"args":     []any{name, principalsToRemove},
+			"opt_args": nil,
+		})
+
+		var res rpcMembershipResult
+
+		err := r.provider.Call("service_remove_delegation", []any{name, principalsToRemove}, nil, &res)
+		tflog.Trace(ctx, "Called ServiceRemoveDelegation", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		diags.Append(membershipDiags("Failed to remove service delegation principals", res.Failed, err)...)
+	}
+
+	if len(principalsToAdd) > 0 {
+		tflog.Trace(ctx, "Calling ServiceAddDelegation", map[string]any{
+			"args":     []any{name, principalsToAdd},
+			"opt_args": nil,
+		})
+
+		var res rpcMembershipResult
+
+		err := r.provider.Call("service_add_delegation", []any{name, principalsToAdd}, nil, &res)
+		tflog.Trace(ctx, "Called ServiceAddDelegation", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		diags.Append(membershipDiags("Failed to add service delegation principals", res.Failed, err)...)
+	}
+
+	return
+}
+
+func (m serviceKeytabMembers) isEmpty() bool {
+	return len(m.users) == 0 && len(m.groups) == 0 && len(m.hosts) == 0 && len(m.hostgroups) == 0
+}
@@ -0,0 +1,120 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func testServiceModel(t *testing.T) ServiceModel {
+	t.Helper()
+
+	aliases, diags := types.SetValueFrom(context.Background(), types.StringType, []string{"HTTP/alias.example.test"})
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	return ServiceModel{
+		KrbHostname:              types.StringValue("HTTP/test.example.test"),
+		PrincipalAliases:         aliases,
+		PACType:                  types.SetNull(types.StringType),
+		AuthIndicators:           types.SetNull(types.StringType),
+		ManagedByHosts:           types.SetNull(types.StringType),
+		RetrieveKeytabUsers:      types.SetNull(types.StringType),
+		RetrieveKeytabGroups:     types.SetNull(types.StringType),
+		RetrieveKeytabHosts:      types.SetNull(types.StringType),
+		RetrieveKeytabHostgroups: types.SetNull(types.StringType),
+		CreateKeytabUsers:        types.SetNull(types.StringType),
+		CreateKeytabGroups:       types.SetNull(types.StringType),
+		CreateKeytabHosts:        types.SetNull(types.StringType),
+		CreateKeytabHostgroups:   types.SetNull(types.StringType),
+		DelegationPrincipals:     types.SetNull(types.StringType),
+	}
+}
+
+func TestServiceRead(t *testing.T) {
+	p := newTestProvider(t, map[string]rpcHandler{
+		"service_show": func(t *testing.T, params map[string]any) string {
+			if params["all"] != true {
+				t.Errorf("expected all attributes to be requested")
+			}
+
+			// A service without certificate, which the client fails to decode.
+			return rpcResult(map[string]any{
+				"summary": nil,
+				"value":   "HTTP/test.example.test@EXAMPLE.TEST",
+				"result": map[string]any{
+					"krbcanonicalname":                   []string{"HTTP/test.example.test@EXAMPLE.TEST"},
+					"krbprincipalname":                   []string{"HTTP/test.example.test@EXAMPLE.TEST", "HTTP/alias.example.test@EXAMPLE.TEST", "HTTP/other.example.test@EXAMPLE.TEST"},
+					"ipakrbauthzdata":                    []string{"MS-PAC"},
+					"ipakrbrequirespreauth":              true,
+					"ipakrbokasdelegate":                 false,
+					"ipakrboktoauthasdelegate":           false,
+					"managedby_host":                     []string{"test.example.test"},
+					"ipaallowedtoperform_read_keys_user": []string{"admin"},
+					"memberprincipal":                    []string{"HTTP/web.example.test@EXAMPLE.TEST"},
+					"has_keytab":                         false,
+				},
+			})
+		},
+	})
+
+	model := testRead(t, NewService(p), testServiceModel(t))
+
+	tests := map[string]struct {
+		value    types.Set
+		expected []string
+	}{
+		"principal_aliases":     {model.PrincipalAliases, []string{"HTTP/alias.example.test", "HTTP/other.example.test@EXAMPLE.TEST"}},
+		"pac_type":              {model.PACType, []string{"MS-PAC"}},
+		"managed_by_hosts":      {model.ManagedByHosts, []string{"test.example.test"}},
+		"retrieve_keytab_users": {model.RetrieveKeytabUsers, []string{"admin"}},
+		"delegation_principals": {model.DelegationPrincipals, []string{"HTTP/web.example.test@EXAMPLE.TEST"}},
+	}
+
+	for name, test := range tests {
+		expected, _ := types.SetValueFrom(context.Background(), types.StringType, test.expected)
+
+		if !test.value.Equal(expected) {
+			t.Errorf("unexpected %s %v, expected %v", name, test.value, expected)
+		}
+	}
+
+	if !model.AuthIndicators.IsNull() || !model.CreateKeytabUsers.IsNull() {
+		t.Errorf("unset attributes without values should stay null")
+	}
+
+	if !model.RequiresPreAuth.ValueBool() || model.OkAsDelegate.ValueBool() {
+		t.Errorf("unexpected flags %v, %v", model.RequiresPreAuth, model.OkAsDelegate)
+	}
+}
+
+func TestServiceUpdateDelegationPrincipals(t *testing.T) {
+	p := newTestProvider(t, map[string]rpcHandler{
+		"service_add_delegation": func(t *testing.T, params map[string]any) string {
+			// The RPC succeeds, failures are reported per member.
+			return rpcResult(map[string]any{
+				"summary":   nil,
+				"value":     "HTTP/test.example.test@EXAMPLE.TEST",
+				"completed": 0,
+				"failed": map[string]any{
+					"memberprincipal": map[string]any{
+						"memberprincipal": [][]string{{"HTTP/missing.example.test@EXAMPLE.TEST", "no such entry"}},
+					},
+				},
+				"result": map[string]any{
+					"krbcanonicalname": []string{"HTTP/test.example.test@EXAMPLE.TEST"},
+				},
+			})
+		},
+	})
+
+	r := NewService(p).(*Service)
+
+	diags := r.updateDelegationPrincipals(context.Background(), "HTTP/test.example.test", nil, []string{"HTTP/missing.example.test@EXAMPLE.TEST"})
+
+	if !diags.HasError() {
+		t.Errorf("expected the rejected principal to be reported")
+	}
+}
@@ -0,0 +1,292 @@
+package resources
+
+import (
+	"context"
+	"errors"
+
+	"github.com/camptocamp/go-freeipa/freeipa"
+	"github.com/camptocamp/terraform-provider-freeipa/internal/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type SubID struct {
+	provider *provider.Provider
+}
+
+type SubIDModel struct {
+	UniqueID    types.String `tfsdk:"unique_id"`
+	Owner       types.String `tfsdk:"owner"`
+	Description types.String `tfsdk:"description"`
+	UIDNumber   types.Int64  `tfsdk:"uid_number"`
+	UIDCount    types.Int64  `tfsdk:"uid_count"`
+	GIDNumber   types.Int64  `tfsdk:"gid_number"`
+	GIDCount    types.Int64  `tfsdk:"gid_count"`
+}
+
+func (r *SubID) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_subid"
+}
+
+func (r *SubID) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	computedAttribute := func(description string) schema.Int64Attribute {
+		return schema.Int64Attribute{
+			Description: description,
+			Computed:    true,
+			PlanModifiers: []planmodifier.Int64{
+				int64planmodifier.UseStateForUnknown(),
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Version:     0,
+		Description: "Manages a range of subordinate user and group IDs of a user, used by rootless containers",
+		Attributes: map[string]schema.Attribute{
+			"unique_id": schema.StringAttribute{
+				Description: "Unique ID of the subordinate ID range",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"owner": schema.StringAttribute{
+				Description: "User the subordinate ID range is assigned to",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description: "Subordinate ID range description",
+				Optional:    true,
+			},
+			"uid_number": schema.Int64Attribute{
+				Description: "First subordinate user ID, generated when unset",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"uid_count":  computedAttribute("Number of subordinate user IDs"),
+			"gid_number": computedAttribute("First subordinate group ID"),
+			"gid_count":  computedAttribute("Number of subordinate group IDs"),
+		},
+	}
+}
+
+func (r *SubID) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan, state SubIDModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.SubidAddArgs{
+		Ipaowner: plan.Owner.ValueString(),
+	}
+
+	optArgs := &freeipa.SubidAddOptionalArgs{
+		Description:     plan.Description.ValueStringPointer(),
+		Ipasubuidnumber: knownInt(plan.UIDNumber),
+		All:             freeipa.Bool(true),
+	}
+
+	tflog.Trace(ctx, "Calling SubidAdd", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	var res rpcEntryResult
+
+	// The client requires the unique ID, which FreeIPA generates along with
+	// the range when unset, use a raw call instead.
+	err := r.provider.Call("subid_add", nil, struct {
+		*freeipa.SubidAddArgs
+		*freeipa.SubidAddOptionalArgs
+	}{args, optArgs}, &res)
+	tflog.Trace(ctx, "Called SubidAdd", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create subordinate ID range", "Reason: "+err.Error())
+		return
+	}
+
+	state = plan
+	state.UniqueID = types.StringPointerValue(entryString(res.Result, "ipauniqueid"))
+	state.UIDNumber = types.Int64PointerValue(entryInt64(res.Result, "ipasubuidnumber"))
+	state.UIDCount = types.Int64PointerValue(entryInt64(res.Result, "ipasubuidcount"))
+	state.GIDNumber = types.Int64PointerValue(entryInt64(res.Result, "ipasubgidnumber"))
+	state.GIDCount = types.Int64PointerValue(entryInt64(res.Result, "ipasubgidcount"))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *SubID) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state SubIDModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.SubidShowArgs{
+		Ipauniqueid: state.UniqueID.ValueString(),
+	}
+
+	optArgs := &freeipa.SubidShowOptionalArgs{
+		All: freeipa.Bool(true),
+	}
+
+	tflog.Trace(ctx, "Calling SubidShow", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	res, err := r.provider.Client().SubidShow(args, optArgs)
+
+	tflog.Trace(ctx, "Called SubidShow", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if errors.As(err, &freeipaErr) && freeipaErr.Code == freeipa.NotFoundCode {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Failed to read subordinate ID range", "Reason: "+err.Error())
+		return
+	}
+
+	state.Owner = types.StringValue(res.Result.Ipaowner)
+	state.Description = types.StringPointerValue(res.Result.Description)
+	state.UIDNumber = types.Int64PointerValue(int64Pointer(res.Result.Ipasubuidnumber))
+	state.UIDCount = types.Int64PointerValue(int64Pointer(res.Result.Ipasubuidcount))
+	state.GIDNumber = types.Int64PointerValue(int64Pointer(res.Result.Ipasubgidnumber))
+	state.GIDCount = types.Int64PointerValue(int64Pointer(res.Result.Ipasubgidcount))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *SubID) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state, plan SubIDModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the description can be changed, the range is kept as is.
+	if !plan.Description.Equal(state.Description) {
+		args := &freeipa.SubidModArgs{
+			Ipauniqueid: state.UniqueID.ValueString(),
+		}
+
+		optArgs := &freeipa.SubidModOptionalArgs{
+			Description: freeipa.String(plan.Description.ValueString()),
+		}
+
+		tflog.Trace(ctx, "Calling SubidMod", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+
+		res, err := r.provider.Client().SubidMod(args, optArgs)
+
+		tflog.Trace(ctx, "Called SubidMod", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		if err != nil {
+			var freeipaErr *freeipa.Error
+
+			if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.EmptyModlistCode {
+				resp.Diagnostics.AddError("Failed to update subordinate ID range", "Reason: "+err.Error())
+				return
+			}
+
+			tflog.Debug(ctx, "Updated subordinate ID range has no effective difference", map[string]any{
+				"args":     args,
+				"opt_args": optArgs,
+			})
+		}
+	}
+
+	state.Description = plan.Description
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *SubID) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state SubIDModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.SubidDelArgs{
+		Ipauniqueid: []string{state.UniqueID.ValueString()},
+	}
+
+	tflog.Trace(ctx, "Calling SubidDel", map[string]any{
+		"args":     args,
+		"opt_args": nil,
+	})
+
+	res, err := r.provider.Client().SubidDel(args, nil)
+
+	tflog.Trace(ctx, "Called SubidDel", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.NotFoundCode {
+			resp.Diagnostics.AddError("Failed to delete subordinate ID range", "Reason: "+err.Error())
+			return
+		}
+	}
+}
+
+func (r *SubID) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	state := SubIDModel{
+		UniqueID: types.StringValue(req.ID),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func NewSubID(p *provider.Provider) resource.Resource {
+	r := &SubID{
+		provider: p,
+	}
+
+	var _ resource.Resource = r
+	var _ resource.ResourceWithImportState = r
+
+	return r
+}
+
+func init() {
+	resources = append(resources, NewSubID)
+}
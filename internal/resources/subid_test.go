@@ -0,0 +1,55 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSubIDCreate(t *testing.T) {
+	p := newTestProvider(t, map[string]rpcHandler{
+		"subid_add": func(t *testing.T, params map[string]any) string {
+			if params["ipaowner"] != "jdoe" {
+				t.Errorf("unexpected owner %v", params["ipaowner"])
+			}
+
+			if _, ok := params["ipasubuidnumber"]; ok {
+				t.Errorf("unexpected subordinate user ID number %v", params["ipasubuidnumber"])
+			}
+
+			return rpcResult(map[string]any{
+				"summary": "Added subordinate id \"0a1b2c3d-1a4c-11ef-9d4b-525400c2c1b3\"",
+				"value":   "0a1b2c3d-1a4c-11ef-9d4b-525400c2c1b3",
+				"result": map[string]any{
+					"ipauniqueid":     []string{"0a1b2c3d-1a4c-11ef-9d4b-525400c2c1b3"},
+					"ipaowner":        []string{"jdoe"},
+					"description":     []string{"Rootless containers"},
+					"ipasubuidnumber": []string{"2147483648"},
+					"ipasubuidcount":  []string{"65536"},
+					"ipasubgidnumber": []string{"2147483648"},
+					"ipasubgidcount":  []string{"65536"},
+				},
+			})
+		},
+	})
+
+	plan := SubIDModel{
+		UniqueID:    types.StringUnknown(),
+		Owner:       types.StringValue("jdoe"),
+		Description: types.StringValue("Rootless containers"),
+		UIDNumber:   types.Int64Unknown(),
+		UIDCount:    types.Int64Unknown(),
+		GIDNumber:   types.Int64Unknown(),
+		GIDCount:    types.Int64Unknown(),
+	}
+
+	model := testCreate(t, NewSubID(p), plan, plan)
+
+	testValues(t, map[string]testValue{
+		"unique_id":  {model.UniqueID, types.StringValue("0a1b2c3d-1a4c-11ef-9d4b-525400c2c1b3")},
+		"uid_number": {model.UIDNumber, types.Int64Value(2147483648)},
+		"uid_count":  {model.UIDCount, types.Int64Value(65536)},
+		"gid_number": {model.GIDNumber, types.Int64Value(2147483648)},
+		"gid_count":  {model.GIDCount, types.Int64Value(65536)},
+	})
+}
@@ -0,0 +1,257 @@
+package resources
+
+import (
+	"context"
+	"errors"
+
+	"github.com/camptocamp/go-freeipa/freeipa"
+	"github.com/camptocamp/terraform-provider-freeipa/internal/provider"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// defaultFallbackPrimaryGroup is the group FreeIPA assigns as primary group
+// to the users without one by default.
+const defaultFallbackPrimaryGroup = "Default SMB Group"
+
+type TrustConfig struct {
+	provider *provider.Provider
+}
+
+type TrustConfigModel struct {
+	TrustType            types.String `tfsdk:"trust_type"`
+	FallbackPrimaryGroup types.String `tfsdk:"fallback_primary_group"`
+	Domain               types.String `tfsdk:"domain"`
+	FlatName             types.String `tfsdk:"flat_name"`
+	SID                  types.String `tfsdk:"sid"`
+}
+
+func (r *TrustConfig) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_trust_config"
+}
+
+func (r *TrustConfig) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	computedAttribute := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{
+			Description: description,
+			Computed:    true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Version:     0,
+		Description: "Manages the configuration of the trusts of FreeIPA, which requires AD trust support to be installed. Destroying the resource resets the fallback primary group to its default",
+		Attributes: map[string]schema.Attribute{
+			"trust_type": schema.StringAttribute{
+				Description: "Trust type the configuration applies to (allowed value: ad)",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("ad"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"fallback_primary_group": schema.StringAttribute{
+				Description: "Group assigned as primary group to the users without a POSIX primary group, “" + defaultFallbackPrimaryGroup + "” by default",
+				Required:    true,
+			},
+			"domain":    computedAttribute("FreeIPA domain name"),
+			"flat_name": computedAttribute("NetBIOS name of the FreeIPA domain"),
+			"sid":       computedAttribute("SID of the FreeIPA domain"),
+		},
+	}
+}
+
+func (r *TrustConfig) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config TrustConfigModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.TrustType.IsNull() && !config.TrustType.IsUnknown() && config.TrustType.ValueString() != "ad" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("trust_type"),
+			"Invalid configuration",
+			"“trust_type” only accepts the value “ad”.",
+		)
+	}
+}
+
+func (r *TrustConfig) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan, state TrustConfigModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.setFallbackPrimaryGroup(ctx, plan.TrustType.ValueString(), plan.FallbackPrimaryGroup.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state = plan
+
+	resp.Diagnostics.Append(r.read(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *TrustConfig) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state TrustConfigModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.read(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *TrustConfig) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state, plan TrustConfigModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.FallbackPrimaryGroup.Equal(state.FallbackPrimaryGroup) {
+		resp.Diagnostics.Append(r.setFallbackPrimaryGroup(ctx, plan.TrustType.ValueString(), plan.FallbackPrimaryGroup.ValueString())...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	state = plan
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *TrustConfig) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state TrustConfigModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The configuration cannot be deleted, only reset the fallback primary
+	// group.
+	resp.Diagnostics.Append(r.setFallbackPrimaryGroup(ctx, state.TrustType.ValueString(), defaultFallbackPrimaryGroup)...)
+}
+
+func (r *TrustConfig) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	state := TrustConfigModel{
+		TrustType: types.StringValue(req.ID),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func NewTrustConfig(p *provider.Provider) resource.Resource {
+	r := &TrustConfig{
+		provider: p,
+	}
+
+	var _ resource.Resource = r
+	var _ resource.ResourceWithValidateConfig = r
+	var _ resource.ResourceWithImportState = r
+
+	return r
+}
+
+func init() {
+	resources = append(resources, NewTrustConfig)
+}
+
+func (r *TrustConfig) read(ctx context.Context, state *TrustConfigModel) (diags diag.Diagnostics) {
+	args := &freeipa.TrustconfigShowArgs{}
+
+	optArgs := &freeipa.TrustconfigShowOptionalArgs{
+		TrustType: freeipa.String(state.TrustType.ValueString()),
+		All:       freeipa.Bool(true),
+	}
+
+	tflog.Trace(ctx, "Calling TrustconfigShow", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	res, err := r.provider.Client().TrustconfigShow(args, optArgs)
+
+	tflog.Trace(ctx, "Called TrustconfigShow", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		diags.AddError("Failed to read trust configuration", "Reason: "+err.Error())
+		return
+	}
+
+	state.FallbackPrimaryGroup = types.StringValue(res.Result.Ipantfallbackprimarygroup)
+	state.Domain = types.StringValue(res.Result.Cn)
+	state.FlatName = types.StringValue(res.Result.Ipantflatname)
+	state.SID = types.StringValue(res.Result.Ipantsecurityidentifier)
+
+	return
+}
+
+func (r *TrustConfig) setFallbackPrimaryGroup(ctx context.Context, trustType, group string) (diags diag.Diagnostics) {
+	args := &freeipa.TrustconfigModArgs{}
+
+	optArgs := &freeipa.TrustconfigModOptionalArgs{
+		TrustType:                 freeipa.String(trustType),
+		Ipantfallbackprimarygroup: freeipa.String(group),
+	}
+
+	tflog.Trace(ctx, "Calling TrustconfigMod", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	res, err := r.provider.Client().TrustconfigMod(args, optArgs)
+
+	tflog.Trace(ctx, "Called TrustconfigMod", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.EmptyModlistCode {
+			diags.AddError("Failed to update trust configuration", "Reason: "+err.Error())
+			return
+		}
+
+		tflog.Debug(ctx, "Updated trust configuration has no effective difference", map[string]any{
+			"args":     args,
+			"opt_args": optArgs,
+		})
+	}
+
+	return
+}
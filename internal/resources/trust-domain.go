@@ -0,0 +1,315 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/camptocamp/go-freeipa/freeipa"
+	"github.com/camptocamp/terraform-provider-freeipa/internal/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type TrustDomain struct {
+	provider *provider.Provider
+}
+
+type TrustDomainModel struct {
+	Trust    types.String `tfsdk:"trust"`
+	Domain   types.String `tfsdk:"domain"`
+	Enabled  types.Bool   `tfsdk:"enabled"`
+	FlatName types.String `tfsdk:"flat_name"`
+	SID      types.String `tfsdk:"sid"`
+}
+
+func (r *TrustDomain) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_trust_domain"
+}
+
+func (r *TrustDomain) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version:     0,
+		Description: "Manages whether a domain of a trusted forest is enabled. Destroying the resource enables the domain back",
+		Attributes: map[string]schema.Attribute{
+			"trust": schema.StringAttribute{
+				Description: "Realm name of the trust",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"domain": schema.StringAttribute{
+				Description: "Name of the domain, fetched when establishing the trust",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Allow the users of the domain to access FreeIPA resources",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"flat_name": schema.StringAttribute{
+				Description: "NetBIOS name of the domain",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"sid": schema.StringAttribute{
+				Description: "SID of the domain",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *TrustDomain) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan, state TrustDomainModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entry, err := r.find(ctx, plan.Trust.ValueString(), plan.Domain.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read trust domain", "Reason: "+err.Error())
+		return
+	}
+
+	if entry == nil {
+		resp.Diagnostics.AddError("Failed to read trust domain", "Reason: the domain is not part of the trust")
+		return
+	}
+
+	state = plan
+	state.FlatName = types.StringPointerValue(entryString(entry, "ipantflatname"))
+	state.SID = types.StringPointerValue(entryString(entry, "ipanttrusteddomainsid"))
+
+	if trustDomainEnabled(entry) != plan.Enabled.ValueBool() {
+		if err := r.setEnabled(ctx, plan.Trust.ValueString(), plan.Domain.ValueString(), plan.Enabled.ValueBool()); err != nil {
+			resp.Diagnostics.AddError("Failed to update trust domain", "Reason: "+err.Error())
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *TrustDomain) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state TrustDomainModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entry, err := r.find(ctx, state.Trust.ValueString(), state.Domain.ValueString())
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		// The trust itself is not found.
+		if errors.As(err, &freeipaErr) && freeipaErr.Code == freeipa.NotFoundCode {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Failed to read trust domain", "Reason: "+err.Error())
+		return
+	}
+
+	if entry == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.Enabled = types.BoolValue(trustDomainEnabled(entry))
+	state.FlatName = types.StringPointerValue(entryString(entry, "ipantflatname"))
+	state.SID = types.StringPointerValue(entryString(entry, "ipanttrusteddomainsid"))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *TrustDomain) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state, plan TrustDomainModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Enabled.Equal(state.Enabled) {
+		if err := r.setEnabled(ctx, plan.Trust.ValueString(), plan.Domain.ValueString(), plan.Enabled.ValueBool()); err != nil {
+			resp.Diagnostics.AddError("Failed to update trust domain", "Reason: "+err.Error())
+			return
+		}
+	}
+
+	state = plan
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *TrustDomain) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state TrustDomainModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The domain is part of the trust, it is only enabled back.
+	if state.Enabled.ValueBool() {
+		return
+	}
+
+	err := r.setEnabled(ctx, state.Trust.ValueString(), state.Domain.ValueString(), true)
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		// The trust may have been deleted along with its domains.
+		if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.NotFoundCode {
+			resp.Diagnostics.AddError("Failed to delete trust domain", "Reason: "+err.Error())
+			return
+		}
+	}
+}
+
+func (r *TrustDomain) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := strings.Split(req.ID, "/")
+
+	if len(id) != 2 {
+		resp.Diagnostics.AddError("Invalid ID format", "Expected ID format is “<trust>/<domain>”")
+
+		return
+	}
+
+	state := TrustDomainModel{
+		Trust:  types.StringValue(id[0]),
+		Domain: types.StringValue(id[1]),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func NewTrustDomain(p *provider.Provider) resource.Resource {
+	r := &TrustDomain{
+		provider: p,
+	}
+
+	var _ resource.Resource = r
+	var _ resource.ResourceWithImportState = r
+
+	return r
+}
+
+func init() {
+	resources = append(resources, NewTrustDomain)
+}
+
+// find returns the domain of a trust, or nil if it is not part of the trust.
+func (r *TrustDomain) find(ctx context.Context, trust, domain string) (map[string]any, error) {
+	args := &freeipa.TrustdomainFindArgs{
+		Trustcn: trust,
+	}
+
+	optArgs := &freeipa.TrustdomainFindOptionalArgs{
+		Cn:        freeipa.String(domain),
+		All:       freeipa.Bool(true),
+		Sizelimit: freeipa.Int(0),
+	}
+
+	tflog.Trace(ctx, "Calling TrustdomainFind", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	var res rpcListResult
+
+	// FreeIPA returns the status of the domains either as a boolean or as a
+	// list, use a raw call to read both.
+	err := r.provider.Call("trustdomain_find", []any{args.Trustcn}, optArgs, &res)
+	tflog.Trace(ctx, "Called TrustdomainFind", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	// The search also matches domains whose name contains the given one.
+	for _, entry := range res.Result {
+		if name := entryString(entry, "cn"); name != nil && strings.EqualFold(*name, domain) {
+			return entry, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// setEnabled enables or disables a domain of a trust.
+func (r *TrustDomain) setEnabled(ctx context.Context, trust, domain string, enabled bool) error {
+	if enabled {
+		args := &freeipa.TrustdomainEnableArgs{
+			Trustcn: trust,
+			Cn:      domain,
+		}
+
+		tflog.Trace(ctx, "Calling TrustdomainEnable", map[string]any{
+			"args":     args,
+			"opt_args": nil,
+		})
+
+		res, err := r.provider.Client().TrustdomainEnable(args, nil)
+
+		tflog.Trace(ctx, "Called TrustdomainEnable", map[string]any{
+			"res": res,
+			"err": err,
+		})
+
+		return err
+	}
+
+	args := &freeipa.TrustdomainDisableArgs{
+		Trustcn: trust,
+		Cn:      domain,
+	}
+
+	tflog.Trace(ctx, "Calling TrustdomainDisable", map[string]any{
+		"args":     args,
+		"opt_args": nil,
+	})
+
+	res, err := r.provider.Client().TrustdomainDisable(args, nil)
+
+	tflog.Trace(ctx, "Called TrustdomainDisable", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	return err
+}
+
+// trustDomainEnabled returns whether a trust domain is enabled, which FreeIPA
+// only reports for the domains of forest trusts.
+func trustDomainEnabled(entry map[string]any) bool {
+	if enabled := entryBool(entry, "domain_enabled"); enabled != nil {
+		return *enabled
+	}
+
+	return true
+}
@@ -0,0 +1,367 @@
+package resources
+
+import (
+	"context"
+	"errors"
+
+	"github.com/camptocamp/go-freeipa/freeipa"
+	"github.com/camptocamp/terraform-provider-freeipa/internal/provider"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// trustDirections maps the trust directions FreeIPA returns to whether the
+// trust is two-way.
+var trustDirections = map[string]bool{
+	"Trusting forest": false,
+	"Trusted forest":  false,
+	"Two-way trust":   true,
+}
+
+// trustTypes maps the trust types FreeIPA returns to whether the trust is
+// external.
+var trustTypes = map[string]bool{
+	"Active Directory domain": false,
+	"Non-transitive external trust to a domain in another Active Directory forest": true,
+}
+
+type Trust struct {
+	provider *provider.Provider
+}
+
+type TrustModel struct {
+	Realm        types.String `tfsdk:"realm"`
+	Type         types.String `tfsdk:"type"`
+	TwoWay       types.Bool   `tfsdk:"two_way"`
+	External     types.Bool   `tfsdk:"external"`
+	Admin        types.String `tfsdk:"admin"`
+	Password     types.String `tfsdk:"password"`
+	Server       types.String `tfsdk:"server"`
+	SharedSecret types.String `tfsdk:"shared_secret"`
+	FlatName     types.String `tfsdk:"flat_name"`
+	SID          types.String `tfsdk:"sid"`
+}
+
+func (r *Trust) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_trust"
+}
+
+func (r *Trust) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version:     0,
+		Description: "Manages a trust with an Active Directory forest or domain",
+		Attributes: map[string]schema.Attribute{
+			"realm": schema.StringAttribute{
+				Description: "Realm name of the trusted forest or domain",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Description: "Trust type (allowed value: ad)",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("ad"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"two_way": schema.BoolAttribute{
+				Description: "Establish a two-way trust, allowing the trusted domain to look up FreeIPA users and groups",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"external": schema.BoolAttribute{
+				Description: "Establish a non-transitive external trust with a domain of another forest",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"admin": schema.StringAttribute{
+				Description: "Administrator of the trusted domain used to establish the trust",
+				Optional:    true,
+			},
+			"password": schema.StringAttribute{
+				Description: "Password of the administrator of the trusted domain, only used to establish the trust and never saved in the state (requires Terraform 1.11 or later)",
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+			},
+			"server": schema.StringAttribute{
+				Description: "Domain controller of the trusted domain used to establish the trust",
+				Optional:    true,
+			},
+			"shared_secret": schema.StringAttribute{
+				Description: "Shared secret of a trust created beforehand on the trusted domain side, only used to establish the trust and never saved in the state (requires Terraform 1.11 or later)",
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+			},
+			"flat_name": schema.StringAttribute{
+				Description: "NetBIOS name of the trusted domain",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"sid": schema.StringAttribute{
+				Description: "SID of the trusted domain",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *Trust) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config TrustModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Type.IsNull() && !config.Type.IsUnknown() && config.Type.ValueString() != "ad" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("type"),
+			"Invalid configuration",
+			"“type” only accepts the value “ad”.",
+		)
+	}
+
+	switch {
+	case !config.SharedSecret.IsNull():
+		for name, value := range map[string]types.String{"admin": config.Admin, "password": config.Password} {
+			if !value.IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(name),
+					"Invalid configuration",
+					"“"+name+"” cannot be set along with “shared_secret”.",
+				)
+			}
+		}
+	case config.Admin.IsNull() && config.Password.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("admin"),
+			"Invalid configuration",
+			"Either “admin” and “password” or “shared_secret” must be set.",
+		)
+	case config.Password.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password"),
+			"Invalid configuration",
+			"“password” must be set along with “admin”.",
+		)
+	}
+}
+
+func (r *Trust) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var config, plan, state TrustModel
+
+	// The write-only secrets are only available in the configuration.
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.TrustAddArgs{
+		Cn: plan.Realm.ValueString(),
+	}
+
+	optArgs := &freeipa.TrustAddOptionalArgs{
+		TrustType:     plan.Type.ValueStringPointer(),
+		RealmAdmin:    plan.Admin.ValueStringPointer(),
+		RealmPasswd:   config.Password.ValueStringPointer(),
+		RealmServer:   plan.Server.ValueStringPointer(),
+		TrustSecret:   config.SharedSecret.ValueStringPointer(),
+		Bidirectional: plan.TwoWay.ValueBoolPointer(),
+		External:      plan.External.ValueBoolPointer(),
+		All:           freeipa.Bool(true),
+	}
+
+	// Do not log the credentials.
+	tflog.Trace(ctx, "Calling TrustAdd", map[string]any{
+		"args": args,
+	})
+
+	var res rpcEntryResult
+
+	err := r.provider.Call("trust_add", nil, struct {
+		*freeipa.TrustAddArgs
+		*freeipa.TrustAddOptionalArgs
+	}{args, optArgs}, &res)
+	tflog.Trace(ctx, "Called TrustAdd", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create trust", "Reason: "+err.Error())
+		return
+	}
+
+	state = plan
+	state.FlatName = types.StringPointerValue(entryString(res.Result, "ipantflatname"))
+	state.SID = types.StringPointerValue(entryString(res.Result, "ipanttrusteddomainsid"))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *Trust) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state TrustModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.TrustShowArgs{
+		Cn: state.Realm.ValueString(),
+	}
+
+	optArgs := &freeipa.TrustShowOptionalArgs{
+		All: freeipa.Bool(true),
+	}
+
+	tflog.Trace(ctx, "Calling TrustShow", map[string]any{
+		"args":     args,
+		"opt_args": optArgs,
+	})
+
+	var res rpcEntryResult
+
+	err := r.provider.Call("trust_show", []any{args.Cn}, optArgs, &res)
+	tflog.Trace(ctx, "Called TrustShow", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if errors.As(err, &freeipaErr) && freeipaErr.Code == freeipa.NotFoundCode {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Failed to read trust", "Reason: "+err.Error())
+		return
+	}
+
+	// The credentials are never read back. FreeIPA returns the direction and
+	// type of the trust as descriptions, only read the known ones.
+	state.FlatName = types.StringPointerValue(entryString(res.Result, "ipantflatname"))
+	state.SID = types.StringPointerValue(entryString(res.Result, "ipanttrusteddomainsid"))
+
+	if direction := entryString(res.Result, "trustdirection"); direction != nil {
+		if twoWay, ok := trustDirections[*direction]; ok {
+			state.TwoWay = types.BoolValue(twoWay)
+		}
+	}
+
+	if trustType := entryString(res.Result, "trusttype"); trustType != nil {
+		if external, ok := trustTypes[*trustType]; ok {
+			state.External = types.BoolValue(external)
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *Trust) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state, plan TrustModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The credentials are only used to establish the trust, changing them
+	// has no effect on the existing trust. The secrets are write-only and
+	// never saved.
+	state.Admin = plan.Admin
+	state.Server = plan.Server
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *Trust) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state TrustModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := &freeipa.TrustDelArgs{
+		Cn: []string{state.Realm.ValueString()},
+	}
+
+	tflog.Trace(ctx, "Calling TrustDel", map[string]any{
+		"args":     args,
+		"opt_args": nil,
+	})
+
+	res, err := r.provider.Client().TrustDel(args, nil)
+
+	tflog.Trace(ctx, "Called TrustDel", map[string]any{
+		"res": res,
+		"err": err,
+	})
+
+	if err != nil {
+		var freeipaErr *freeipa.Error
+
+		if !errors.As(err, &freeipaErr) || freeipaErr.Code != freeipa.NotFoundCode {
+			resp.Diagnostics.AddError("Failed to delete trust", "Reason: "+err.Error())
+			return
+		}
+	}
+}
+
+func (r *Trust) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	state := TrustModel{
+		Realm:    types.StringValue(req.ID),
+		Type:     types.StringValue("ad"),
+		TwoWay:   types.BoolValue(false),
+		External: types.BoolValue(false),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func NewTrust(p *provider.Provider) resource.Resource {
+	r := &Trust{
+		provider: p,
+	}
+
+	var _ resource.Resource = r
+	var _ resource.ResourceWithValidateConfig = r
+	var _ resource.ResourceWithImportState = r
+
+	return r
+}
+
+func init() {
+	resources = append(resources, NewTrust)
+}
@@ -0,0 +1,120 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestTrustCreate(t *testing.T) {
+	p := newTestProvider(t, map[string]rpcHandler{
+		"trust_add": func(t *testing.T, params map[string]any) string {
+			expected := map[string]any{
+				"cn":            "ad.example.test",
+				"trust_type":    "ad",
+				"realm_admin":   "Administrator",
+				"realm_passwd":  "secret",
+				"bidirectional": true,
+				"external":      false,
+			}
+
+			for name, value := range expected {
+				if params[name] != value {
+					t.Errorf("unexpected %s %v, expected %v", name, params[name], value)
+				}
+			}
+
+			if _, ok := params["trust_secret"]; ok {
+				t.Errorf("unexpected shared secret %v", params["trust_secret"])
+			}
+
+			return rpcResult(map[string]any{
+				"summary": "Added Active Directory trust for realm \"ad.example.test\"",
+				"value":   "ad.example.test",
+				"result": map[string]any{
+					"cn":                    []string{"ad.example.test"},
+					"ipantflatname":         []string{"AD"},
+					"ipanttrusteddomainsid": []string{"S-1-5-21-1234567890-1234567890-1234567890"},
+					"trustdirection":        []string{"Two-way trust"},
+					"trusttype":             []string{"Active Directory domain"},
+					"truststatus":           []string{"Established and verified"},
+				},
+			})
+		},
+	})
+
+	// The write-only password is only in the configuration.
+	model := testCreate(t, NewTrust(p), TrustModel{
+		Realm:        types.StringValue("ad.example.test"),
+		Type:         types.StringNull(),
+		TwoWay:       types.BoolValue(true),
+		External:     types.BoolNull(),
+		Admin:        types.StringValue("Administrator"),
+		Password:     types.StringValue("secret"),
+		Server:       types.StringNull(),
+		SharedSecret: types.StringNull(),
+		FlatName:     types.StringNull(),
+		SID:          types.StringNull(),
+	}, TrustModel{
+		Realm:        types.StringValue("ad.example.test"),
+		Type:         types.StringValue("ad"),
+		TwoWay:       types.BoolValue(true),
+		External:     types.BoolValue(false),
+		Admin:        types.StringValue("Administrator"),
+		Password:     types.StringNull(),
+		Server:       types.StringNull(),
+		SharedSecret: types.StringNull(),
+		FlatName:     types.StringUnknown(),
+		SID:          types.StringUnknown(),
+	})
+
+	testValues(t, map[string]testValue{
+		"flat_name": {model.FlatName, types.StringValue("AD")},
+		"sid":       {model.SID, types.StringValue("S-1-5-21-1234567890-1234567890-1234567890")},
+		"password":  {model.Password, types.StringNull()},
+	})
+}
+
+func TestTrustDomainRead(t *testing.T) {
+	p := newTestProvider(t, map[string]rpcHandler{
+		"trustdomain_find": func(t *testing.T, params map[string]any) string {
+			if params["cn"] != "child.ad.example.test" {
+				t.Errorf("unexpected domain %v", params["cn"])
+			}
+
+			return rpcResult(map[string]any{
+				"count":     2,
+				"truncated": false,
+				"summary":   "2 domains matched",
+				"result": []map[string]any{
+					{
+						"cn":                    []string{"sub.child.ad.example.test"},
+						"ipantflatname":         []string{"SUB"},
+						"ipanttrusteddomainsid": []string{"S-1-5-21-3"},
+						"domain_enabled":        []bool{true},
+					},
+					{
+						"cn":                    []string{"Child.ad.example.test"},
+						"ipantflatname":         []string{"CHILD"},
+						"ipanttrusteddomainsid": []string{"S-1-5-21-2"},
+						"domain_enabled":        false,
+					},
+				},
+			})
+		},
+	})
+
+	model := testRead(t, NewTrustDomain(p), TrustDomainModel{
+		Trust:    types.StringValue("ad.example.test"),
+		Domain:   types.StringValue("child.ad.example.test"),
+		Enabled:  types.BoolValue(true),
+		FlatName: types.StringValue("CHILD"),
+		SID:      types.StringValue("S-1-5-21-2"),
+	})
+
+	testValues(t, map[string]testValue{
+		"enabled":   {model.Enabled, types.BoolValue(false)},
+		"flat_name": {model.FlatName, types.StringValue("CHILD")},
+		"sid":       {model.SID, types.StringValue("S-1-5-21-2")},
+	})
+}
@@ -0,0 +1,345 @@
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ZoneRecord is a single resource record parsed from a zone file.
+//
+// Name is relative to the zone origin (“@” for the apex), Type is upper
+// case and Value holds the record data as written in the zone file, with
+// tokens separated by a single space. The domain names of the data are
+// made absolute unless they are relative to the zone itself.
+type ZoneRecord struct {
+	Name  string
+	Type  string
+	TTL   *int64
+	Value string
+}
+
+// ParseZoneFile parses an RFC 1035 master file fragment for the given zone.
+//
+// The $ORIGIN and $TTL directives, multi-line records using parentheses,
+// comments and owner name inheritance are supported. $INCLUDE and $GENERATE
+// are not. Only the IN class is accepted.
+func ParseZoneFile(data, zone string) ([]ZoneRecord, error) {
+	zone = canonicalZoneName(zone)
+	origin := zone
+
+	var records []ZoneRecord
+	var defaultTTL *int64
+	var owner string
+
+	lines, err := zoneFileLines(data)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, line := range lines {
+		tokens := line.tokens
+
+		if strings.HasPrefix(tokens[0], "$") {
+			switch strings.ToUpper(tokens[0]) {
+			case "$ORIGIN":
+				if len(tokens) != 2 {
+					return nil, fmt.Errorf("line %d: $ORIGIN expects exactly one argument", line.number)
+				}
+
+				origin = absoluteZoneName(tokens[1], origin)
+			case "$TTL":
+				if len(tokens) != 2 {
+					return nil, fmt.Errorf("line %d: $TTL expects exactly one argument", line.number)
+				}
+
+				ttl, ok := parseZoneTTL(tokens[1])
+				if !ok {
+					return nil, fmt.Errorf("line %d: invalid TTL “%s”", line.number, tokens[1])
+				}
+
+				defaultTTL = &ttl
+			default:
+				return nil, fmt.Errorf("line %d: unsupported directive “%s”", line.number, tokens[0])
+			}
+
+			continue
+		}
+
+		if !line.inheritOwner {
+			owner = absoluteZoneName(tokens[0], origin)
+			tokens = tokens[1:]
+		} else if owner == "" {
+			return nil, fmt.Errorf("line %d: record has no owner name", line.number)
+		}
+
+		name, ok := relativeZoneName(owner, zone)
+		if !ok {
+			return nil, fmt.Errorf("line %d: owner name “%s” is outside of zone “%s”", line.number, owner, zone)
+		}
+
+		record := ZoneRecord{
+			Name: name,
+			TTL:  defaultTTL,
+		}
+
+		// TTL and class may appear in any order before the record type
+		for len(tokens) > 0 {
+			if ttl, ok := parseZoneTTL(tokens[0]); ok {
+				record.TTL = &ttl
+				tokens = tokens[1:]
+			} else if isZoneClass(tokens[0]) {
+				if !strings.EqualFold(tokens[0], "IN") {
+					return nil, fmt.Errorf("line %d: unsupported class “%s”", line.number, tokens[0])
+				}
+
+				tokens = tokens[1:]
+			} else {
+				break
+			}
+		}
+
+		if len(tokens) < 2 {
+			return nil, fmt.Errorf("line %d: missing record type or data", line.number)
+		}
+
+		record.Type = strings.ToUpper(tokens[0])
+		data := tokens[1:]
+
+		for _, i := range zoneDataNames[record.Type] {
+			if i < len(data) {
+				data[i] = zoneDataName(data[i], origin, zone)
+			}
+		}
+
+		record.Value = strings.Join(data, " ")
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// zoneDataNames holds the positions of the domain names in the data of the
+// record types.
+var zoneDataNames = map[string][]int{
+	"AFSDB": {1},
+	"CNAME": {0},
+	"DNAME": {0},
+	"KX":    {1},
+	"MX":    {1},
+	"NAPTR": {5},
+	"NS":    {0},
+	"PTR":   {0},
+	"SRV":   {3},
+}
+
+// zoneDataName expands a domain name of record data, which FreeIPA resolves
+// relatively to the zone, against the current origin.
+func zoneDataName(name, origin, zone string) string {
+	if strings.HasSuffix(name, ".") || (name != "@" && origin == zone) {
+		return name
+	}
+
+	return absoluteZoneName(name, origin)
+}
+
+type zoneFileLine struct {
+	number       int
+	inheritOwner bool
+	tokens       []string
+}
+
+// zoneFileLines splits a zone file into logical lines of tokens, removing
+// comments and joining lines enclosed in parentheses.
+func zoneFileLines(data string) ([]zoneFileLine, error) {
+	var lines []zoneFileLine
+	var current *zoneFileLine
+	var depth int
+
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	number := 0
+
+	for scanner.Scan() {
+		number++
+
+		text := scanner.Text()
+
+		if current == nil {
+			current = &zoneFileLine{
+				number:       number,
+				inheritOwner: len(text) > 0 && unicode.IsSpace(rune(text[0])),
+			}
+		}
+
+		var token strings.Builder
+		var quoted, escaped, hasToken bool
+
+		flush := func() {
+			if hasToken {
+				current.tokens = append(current.tokens, token.String())
+				token.Reset()
+				hasToken = false
+			}
+		}
+
+	scan:
+		for _, c := range text {
+			switch {
+			case escaped:
+				token.WriteRune(c)
+				escaped = false
+			case c == '\\':
+				token.WriteRune(c)
+				hasToken = true
+				escaped = true
+			case c == '"':
+				token.WriteRune(c)
+				hasToken = true
+				quoted = !quoted
+			case quoted:
+				token.WriteRune(c)
+			case c == ';':
+				break scan
+			case c == '(':
+				flush()
+				depth++
+			case c == ')':
+				flush()
+				depth--
+
+				if depth < 0 {
+					return nil, fmt.Errorf("line %d: unbalanced parentheses", number)
+				}
+			case unicode.IsSpace(c):
+				flush()
+			default:
+				token.WriteRune(c)
+				hasToken = true
+			}
+		}
+
+		if quoted {
+			return nil, fmt.Errorf("line %d: unterminated quoted string", number)
+		}
+
+		flush()
+
+		if depth > 0 {
+			continue
+		}
+
+		if len(current.tokens) > 0 {
+			lines = append(lines, *current)
+		}
+
+		current = nil
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if depth > 0 {
+		return nil, fmt.Errorf("line %d: unbalanced parentheses", current.number)
+	}
+
+	return lines, nil
+}
+
+// parseZoneTTL parses a TTL either as plain seconds or using the BIND
+// unit suffixes (e.g. “1h30m”).
+func parseZoneTTL(s string) (int64, bool) {
+	if s == "" || !unicode.IsDigit(rune(s[0])) {
+		return 0, false
+	}
+
+	if ttl, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return ttl, true
+	}
+
+	var ttl, value int64
+	var hasValue bool
+
+	for _, c := range strings.ToLower(s) {
+		if unicode.IsDigit(c) {
+			value = value*10 + int64(c-'0')
+			hasValue = true
+
+			continue
+		}
+
+		if !hasValue {
+			return 0, false
+		}
+
+		switch c {
+		case 's':
+		case 'm':
+			value *= 60
+		case 'h':
+			value *= 60 * 60
+		case 'd':
+			value *= 24 * 60 * 60
+		case 'w':
+			value *= 7 * 24 * 60 * 60
+		default:
+			return 0, false
+		}
+
+		ttl += value
+		value = 0
+		hasValue = false
+	}
+
+	if hasValue {
+		return 0, false
+	}
+
+	return ttl, true
+}
+
+func isZoneClass(s string) bool {
+	switch strings.ToUpper(s) {
+	case "IN", "CH", "CS", "HS":
+		return true
+	}
+
+	return false
+}
+
+func canonicalZoneName(name string) string {
+	name = strings.ToLower(name)
+
+	if !strings.HasSuffix(name, ".") {
+		name += "."
+	}
+
+	return name
+}
+
+func absoluteZoneName(name, origin string) string {
+	if name == "@" {
+		return origin
+	}
+
+	if strings.HasSuffix(name, ".") {
+		return strings.ToLower(name)
+	}
+
+	return strings.ToLower(name) + "." + origin
+}
+
+func relativeZoneName(name, zone string) (string, bool) {
+	if name == zone {
+		return "@", true
+	}
+
+	if relative, ok := strings.CutSuffix(name, "."+zone); ok {
+		return relative, true
+	}
+
+	return "", false
+}
@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func ttl(v int64) *int64 {
+	return &v
+}
+
+func TestParseZoneFile(t *testing.T) {
+	data := `
+$TTL 1h
+@           IN  MX    10 mail          ; primary MX
+            IN  TXT   "v=spf1 mx -all"
+www     300 IN  A     192.0.2.10
+            IN  AAAA  2001:db8::10
+mail.example.test.  A 192.0.2.20
+_sip._tcp   SRV   ( 10 60
+                    5060 sip )
+alias       CNAME @
+$ORIGIN sub.example.test.
+host        CNAME www.example.test.
+relative    CNAME www
+@           MX    10 @
+_ldap._tcp  SRV   0 100 389 ldap
+`
+
+	records, err := ParseZoneFile(data, "example.test")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []ZoneRecord{
+		{Name: "@", Type: "MX", TTL: ttl(3600), Value: "10 mail"},
+		{Name: "@", Type: "TXT", TTL: ttl(3600), Value: `"v=spf1 mx -all"`},
+		{Name: "www", Type: "A", TTL: ttl(300), Value: "192.0.2.10"},
+		{Name: "www", Type: "AAAA", TTL: ttl(3600), Value: "2001:db8::10"},
+		{Name: "mail", Type: "A", TTL: ttl(3600), Value: "192.0.2.20"},
+		{Name: "_sip._tcp", Type: "SRV", TTL: ttl(3600), Value: "10 60 5060 sip"},
+		{Name: "alias", Type: "CNAME", TTL: ttl(3600), Value: "example.test."},
+		{Name: "host.sub", Type: "CNAME", TTL: ttl(3600), Value: "www.example.test."},
+		{Name: "relative.sub", Type: "CNAME", TTL: ttl(3600), Value: "www.sub.example.test."},
+		{Name: "sub", Type: "MX", TTL: ttl(3600), Value: "10 sub.example.test."},
+		{Name: "_ldap._tcp.sub", Type: "SRV", TTL: ttl(3600), Value: "0 100 389 ldap.sub.example.test."},
+	}
+
+	if !reflect.DeepEqual(records, expected) {
+		t.Errorf("unexpected records:\n got: %+v\nwant: %+v", records, expected)
+	}
+}
+
+func TestParseZoneFileErrors(t *testing.T) {
+	tests := map[string]string{
+		"outside zone":       "www.example.org. A 192.0.2.1",
+		"no owner":           "  A 192.0.2.1",
+		"unsupported class":  "www CH A 192.0.2.1",
+		"missing data":       "www A",
+		"include":            "$INCLUDE other.zone",
+		"unbalanced":         "www SRV ( 10 60 5060 sip",
+		"unterminated quote": `www TXT "foo`,
+	}
+
+	for name, data := range tests {
+		if _, err := ParseZoneFile(data, "example.test."); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestParseZoneTTL(t *testing.T) {
+	tests := map[string]int64{
+		"3600":  3600,
+		"1h":    3600,
+		"1h30m": 5400,
+		"1w1d":  691200,
+	}
+
+	for s, expected := range tests {
+		if v, ok := parseZoneTTL(s); !ok || v != expected {
+			t.Errorf("parseZoneTTL(%q) = %d, %t; want %d", s, v, ok, expected)
+		}
+	}
+
+	for _, s := range []string{"", "h", "1x", "1h3"} {
+		if _, ok := parseZoneTTL(s); ok {
+			t.Errorf("parseZoneTTL(%q) should fail", s)
+		}
+	}
+}